@@ -0,0 +1,425 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/chekist32/go-monero/utils"
+)
+
+const (
+	// The number of calls sent in a single request, the bigger batches are split
+	DEFAULT_BATCH_MAX_SIZE int = 100
+)
+
+var (
+	ErrBatchNotExecuted   = errors.New("the batch hasn't been executed yet")
+	ErrBatchMissingResult = errors.New("the batch response has no result for the call")
+	// The daemon doesn't accept the JSON RPC 2.0 batches, the calls are sent one by one instead
+	errBatchUnsupported = errors.New("batch requests aren't supported")
+)
+
+// BatchCall is the pending result of a call added to a Batch
+type BatchCall[R JsonRpcResponseResult] struct {
+	res  *JsonRpcGenericResponse[R]
+	err  error
+	done bool
+}
+
+// Returns the result of the call, the same way the corresponding DaemonRpcClient method would.
+// ErrBatchNotExecuted is returned until the batch is executed.
+func (c *BatchCall[R]) Result() (*JsonRpcGenericResponse[R], error) {
+	if !c.done {
+		return nil, ErrBatchNotExecuted
+	}
+
+	return c.res, c.err
+}
+
+type batchEntry struct {
+	id     string
+	method string
+	body   json.RawMessage
+	// returns whether the daemon responded with PAYMENT REQUIRED
+	decode func(data []byte, httpStatus int) bool
+	fail   func(err error)
+}
+
+// Batch packs many JSON RPC calls into a few HTTP requests (JSON RPC 2.0 arrays),
+// which saves the round trips when e.g. a lot of block headers are fetched.
+// The batch is split into the requests of MaxSize calls. If the daemon doesn't accept batches,
+// the calls are sent one by one, and the client remembers that for the following batches.
+// On an RpcPaymentClient the calls are signed and paid for the same way as the single ones,
+// the ones the daemon responds to with PAYMENT REQUIRED are sent once more after the credits are topped up.
+// A Batch isn't safe for concurrent use.
+type Batch struct {
+	client  *DaemonRpcClient
+	entries []*batchEntry
+	MaxSize int
+}
+
+func (c *DaemonRpcClient) NewBatch() *Batch {
+	return &Batch{client: c, MaxSize: DEFAULT_BATCH_MAX_SIZE}
+}
+
+// The number of calls in the batch
+func (b *Batch) Len() int {
+	return len(b.entries)
+}
+
+// Adds a call of any JSON RPC method to the batch. The typed methods of the Batch should be preferred.
+func AddBatchCall[R JsonRpcResponseResult, P JsonRpcRequestParams](b *Batch, method string, params P) *BatchCall[R] {
+	call := &BatchCall[R]{}
+
+	// the ids are unique within the batch, so the responses can be matched with the calls
+	id := strconv.Itoa(len(b.entries))
+	body, err := json.Marshal(&JsonRpcGenericRequestBody[P]{JsonRpcHeader{Id: id, Jsonrpc: "2.0"}, method, params})
+
+	entry := &batchEntry{
+		id:     id,
+		method: method,
+		body:   body,
+		decode: func(data []byte, httpStatus int) bool {
+			call.done = true
+			call.res, call.err = nil, nil
+
+			res := &JsonRpcGenericResponse[R]{}
+			if err := json.Unmarshal(data, res); err != nil {
+				call.err = err
+				return false
+			}
+			setErrorContextHelper(res, method, httpStatus)
+			b.client.payment.updateHelper(res)
+			_, _, paymentRequired := rpcPaymentInfoHelper(res)
+			if res.Error.Code != 0 {
+				call.err = &res.Error
+				return paymentRequired
+			}
+			if err := checkJsonRpcStatusHelper(method, res); err != nil {
+				call.err = err
+				return false
+			}
+
+			// the same post-processing as the one of the DaemonRpcClient methods
+			if block, ok := any(res).(*JsonRpcGenericResponse[GetBlockResult]); ok {
+				if _, err := fillBlockDetailsHelper(block); err != nil {
+					call.err = err
+					return false
+				}
+			}
+			call.res = res
+			return paymentRequired
+		},
+		fail: func(err error) {
+			call.done = true
+			call.err = err
+		},
+	}
+	if err != nil {
+		entry.fail(err)
+		return call
+	}
+
+	b.entries = append(b.entries, entry)
+	return call
+}
+
+// Sends all the calls of the batch. The results are available through the BatchCall values.
+// The returned error is the first transport error (the calls it affected have it as their result),
+// the errors returned by the daemon for the particular calls are only reported by the calls.
+func (b *Batch) Execute(ctx context.Context) error {
+	size := b.MaxSize
+	if size <= 0 {
+		size = DEFAULT_BATCH_MAX_SIZE
+	}
+
+	var firstErr error
+	for start := 0; start < len(b.entries); start += size {
+		end := start + size
+		if end > len(b.entries) {
+			end = len(b.entries)
+		}
+
+		if err := b.executeChunkHelper(ctx, b.entries[start:end]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// Tops up the credits before the chunk if they are low, and after the PAYMENT REQUIRED responses,
+// in which case the calls that got them are sent once more
+func (b *Batch) executeChunkHelper(ctx context.Context, entries []*batchEntry) error {
+	s := b.client.payment
+	if s == nil || s.config.Funder == nil {
+		_, err := b.sendChunkHelper(ctx, entries)
+		return err
+	}
+
+	if s.lowHelper() {
+		if err := s.topUpHelper(ctx, b.client, false); err != nil {
+			for _, v := range entries {
+				v.fail(err)
+			}
+			return err
+		}
+	}
+
+	res, err := b.sendChunkHelper(ctx, entries)
+	if err != nil {
+		return err
+	}
+
+	// the credits don't help the calls that can't be signed
+	var unpaid []*batchEntry
+	for _, v := range res {
+		if b.payableHelper(v) {
+			unpaid = append(unpaid, v)
+		}
+	}
+	if len(unpaid) == 0 {
+		return nil
+	}
+
+	if err := s.topUpHelper(ctx, b.client, true); err != nil {
+		return err
+	}
+	_, err = b.sendChunkHelper(ctx, unpaid)
+	return err
+}
+
+// Returns the entries the daemon responded to with PAYMENT REQUIRED
+func (b *Batch) sendChunkHelper(ctx context.Context, entries []*batchEntry) ([]*batchEntry, error) {
+	if len(entries) > 1 && !b.client.batchUnsupported.Load() {
+		unpaid, err := b.sendBatchHelper(ctx, entries)
+		if !errors.Is(err, errBatchUnsupported) {
+			return unpaid, err
+		}
+		b.client.batchUnsupported.Store(true)
+	}
+
+	var unpaid []*batchEntry
+	var firstErr error
+	for _, v := range entries {
+		paymentRequired, err := b.sendSingleHelper(ctx, v)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if paymentRequired {
+			unpaid = append(unpaid, v)
+		}
+	}
+
+	return unpaid, firstErr
+}
+
+func (b *Batch) idempotentHelper(entries []*batchEntry) bool {
+	for _, v := range entries {
+		if !idempotentMethods[v.method] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// The RPC payment methods themselves are never paid for, neither are the ones with the positional params
+func (b *Batch) payableHelper(entry *batchEntry) bool {
+	return !strings.HasPrefix(entry.method, "rpc_access_") && !positionalParamsHelper(entry.body)
+}
+
+// Signs the body of the call for the RPC payments
+func (b *Batch) signHelper(entry *batchEntry) ([]byte, error) {
+	if !b.payableHelper(entry) {
+		return entry.body, nil
+	}
+
+	return b.client.payment.signJsonHelper(entry.body, true)
+}
+
+// Posts the body to the JSON RPC endpoint and returns the response body along with the HTTP status.
+// The body is made for each attempt, so it gets a new signature.
+func (b *Batch) postHelper(ctx context.Context, method string, idempotent bool, makeBody func() ([]byte, error)) ([]byte, int, error) {
+	var data []byte
+	var status int
+
+	err := b.client.retry.Do(ctx, method, idempotent, IsRetryableError, func() error {
+		body, err := makeBody()
+		if err != nil {
+			return err
+		}
+
+		res, err := b.client.sendRequest(ctx, http.MethodPost, DEFAULT_MONERO_RPC_ENDPOINT, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		status = res.StatusCode
+		if res.StatusCode >= 400 {
+			return utils.NewHttpStatusError(method, res)
+		}
+
+		data, err = io.ReadAll(res.Body)
+		return err
+	})
+
+	return data, status, err
+}
+
+func (b *Batch) sendBatchHelper(ctx context.Context, entries []*batchEntry) ([]*batchEntry, error) {
+	data, status, err := b.postHelper(ctx, "batch", b.idempotentHelper(entries), func() ([]byte, error) {
+		bodies := make([]json.RawMessage, len(entries))
+		for i, v := range entries {
+			body, err := b.signHelper(v)
+			if err != nil {
+				return nil, err
+			}
+			bodies[i] = body
+		}
+
+		return json.Marshal(bodies)
+	})
+
+	var httpErr *utils.HttpStatusError
+	if errors.As(err, &httpErr) && httpErr.StatusCode >= 400 && httpErr.StatusCode < 500 &&
+		httpErr.StatusCode != http.StatusUnauthorized && httpErr.StatusCode != http.StatusForbidden && httpErr.StatusCode != http.StatusTooManyRequests {
+		return nil, errBatchUnsupported
+	}
+	if err != nil {
+		for _, v := range entries {
+			v.fail(err)
+		}
+		return nil, err
+	}
+
+	// the daemons that don't support batches answer with a single error object
+	var responses []json.RawMessage
+	if err := json.Unmarshal(data, &responses); err != nil {
+		return nil, errBatchUnsupported
+	}
+
+	byId := make(map[string]json.RawMessage, len(responses))
+	for _, v := range responses {
+		var header struct {
+			Id any `json:"id"`
+		}
+		if err := json.Unmarshal(v, &header); err != nil {
+			continue
+		}
+		byId[fmt.Sprint(header.Id)] = v
+	}
+
+	var unpaid []*batchEntry
+	for _, v := range entries {
+		res, ok := byId[v.id]
+		if !ok {
+			v.fail(fmt.Errorf("%s: %w", v.method, ErrBatchMissingResult))
+			continue
+		}
+		if v.decode(res, status) {
+			unpaid = append(unpaid, v)
+		}
+	}
+
+	return unpaid, nil
+}
+
+func (b *Batch) sendSingleHelper(ctx context.Context, entry *batchEntry) (bool, error) {
+	data, status, err := b.postHelper(ctx, entry.method, idempotentMethods[entry.method], func() ([]byte, error) {
+		return b.signHelper(entry)
+	})
+	if err != nil {
+		entry.fail(err)
+		return false, err
+	}
+
+	return entry.decode(data, status), nil
+}
+
+/**
+	The typed calls, the parameters are the same as the ones of the DaemonRpcClient methods
+**/
+
+// get_block_count
+func (b *Batch) GetBlockCount() *BatchCall[GetBlockCountResult] {
+	return AddBatchCall[GetBlockCountResult](b, "get_block_count", GetBlockCountParams{})
+}
+
+// on_get_block_hash
+func (b *Batch) OnGetBlockHash(height uint64) *BatchCall[OnGetBlockHashResult] {
+	return AddBatchCall[OnGetBlockHashResult](b, "on_get_block_hash", OnGetBlockHashParams{height})
+}
+
+// get_last_block_header
+func (b *Batch) GetLastBlockHeader(fillPowHash bool) *BatchCall[GetBlockHeaderResult] {
+	return AddBatchCall[GetBlockHeaderResult](b, "get_last_block_header", GetBlockHeaderDefaultParams{fillPowHash})
+}
+
+// get_block_header_by_hash
+func (b *Batch) GetBlockHeaderByHash(fillPowHash bool, hash string) *BatchCall[GetBlockHeaderResult] {
+	return AddBatchCall[GetBlockHeaderResult](b, "get_block_header_by_hash", GetBlockHeaderByHashParams{GetBlockHeaderDefaultParams{fillPowHash}, hash})
+}
+
+// get_block_header_by_height
+func (b *Batch) GetBlockHeaderByHeight(fillPowHash bool, height uint64) *BatchCall[GetBlockHeaderResult] {
+	return AddBatchCall[GetBlockHeaderResult](b, "get_block_header_by_height", GetBlockHeaderByHeightParams{GetBlockHeaderDefaultParams{fillPowHash}, height})
+}
+
+// get_block_headers_range
+func (b *Batch) GetBlockHeadersRange(fillPowHash bool, startHeight uint64, endHeight uint64) *BatchCall[GetBlockHeadersRangeResult] {
+	return AddBatchCall[GetBlockHeadersRangeResult](b, "get_block_headers_range", GetBlockHeadersRangeParams{GetBlockHeaderDefaultParams{fillPowHash}, startHeight, endHeight})
+}
+
+// get_block
+func (b *Batch) GetBlockByHeight(fillPowHash bool, height uint64) *BatchCall[GetBlockResult] {
+	return AddBatchCall[GetBlockResult](b, "get_block", GetBlockByHeightParams{GetBlockHeaderDefaultParams{fillPowHash}, height})
+}
+
+// get_block
+func (b *Batch) GetBlockByHash(fillPowHash bool, hash string) *BatchCall[GetBlockResult] {
+	return AddBatchCall[GetBlockResult](b, "get_block", GetBlockByHashParams{GetBlockHeaderDefaultParams{fillPowHash}, hash})
+}
+
+// get_fee_estimate
+func (b *Batch) GetFeeEstimate() *BatchCall[GetFeeEstimateResult] {
+	return AddBatchCall[GetFeeEstimateResult](b, "get_fee_estimate", EmptyMoneroRpcParams{})
+}
+
+// get_version
+func (b *Batch) GetVersion() *BatchCall[GetVersionResult] {
+	return AddBatchCall[GetVersionResult](b, "get_version", EmptyMoneroRpcParams{})
+}
+
+// get_info
+func (b *Batch) GetInfo() *BatchCall[GetInfoResult] {
+	return AddBatchCall[GetInfoResult](b, "get_info", EmptyMoneroRpcParams{})
+}
+
+// get_coinbase_tx_sum
+func (b *Batch) GetCoinbaseTxSum(height uint64, count uint64) *BatchCall[GetCoinbaseTxSumResult] {
+	return AddBatchCall[GetCoinbaseTxSumResult](b, "get_coinbase_tx_sum", GetCoinbaseTxSumParams{height, count})
+}
+
+// get_output_histogram
+func (b *Batch) GetOutputHistogram(amounts []uint64, minCount uint64, maxCount uint64, unlocked bool, recentCutoff uint64) *BatchCall[GetOutputHistogramResult] {
+	return AddBatchCall[GetOutputHistogramResult](b, "get_output_histogram", GetOutputHistogramParams{amounts, minCount, maxCount, unlocked, recentCutoff})
+}
+
+// get_output_distribution
+func (b *Batch) GetOutputDistribution(amounts []uint64, cumulative bool, fromHeight uint64, toHeight uint64) *BatchCall[GetOutputDistributionResult] {
+	return AddBatchCall[GetOutputDistributionResult](b, "get_output_distribution", GetOutputDistributionParams{amounts, cumulative, fromHeight, toHeight, false})
+}
+
+// hard_fork_info
+func (b *Batch) HardForkInfo() *BatchCall[HardForkInfoResult] {
+	return AddBatchCall[HardForkInfoResult](b, "hard_fork_info", EmptyMoneroRpcParams{})
+}
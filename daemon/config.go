@@ -0,0 +1,194 @@
+package daemon
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chekist32/go-monero/utils"
+	"github.com/icholy/digest"
+)
+
+const (
+	DEFAULT_DIAL_TIMEOUT          time.Duration = 30 * time.Second
+	DEFAULT_TLS_HANDSHAKE_TIMEOUT time.Duration = 10 * time.Second
+)
+
+var (
+	ErrInvalidFingerprint    = errors.New("invalid certificate fingerprint")
+	ErrCertificateNotAllowed = errors.New("the certificate fingerprint isn't allowed")
+	ErrUnsupportedProxy      = errors.New("unsupported proxy scheme")
+	// The TLS and the proxy options only apply to the default transport
+	ErrTransportConflict = errors.New("the custom transport can't be combined with the TLS and the proxy options")
+)
+
+// The options of NewDaemonRpcClientWithConfig. The zero value verifies the node certificates against the system roots.
+type DaemonRpcClientConfig struct {
+	// Replaces the default transport, the digest authentication is still applied on top of it
+	Transport http.RoundTripper
+
+	// The CAs the node certificate is verified against instead of the system roots
+	RootCAs *x509.CertPool
+	// The client certificates sent to the nodes that require them
+	ClientCertificates []tls.Certificate
+	// The SHA-256 fingerprints of the accepted node certificates in hex, the colons are optional
+	// (the same format as the --rpc-ssl-allowed-fingerprints option of monerod).
+	// The matching certificates are accepted even if they are self-signed, the others are verified as usual if RootCAs is set
+	// and are rejected otherwise.
+	AllowedFingerprints []string
+	// Disables the certificate verification altogether
+	InsecureSkipVerify bool
+
+	// socks5://[user:password@]host:port (e.g. the Tor or I2P SOCKS port for .onion nodes, the host names are resolved by the proxy)
+	// or an HTTP(S) proxy
+	Proxy *url.URL
+
+	// Limits each request including the reading of the response, no limit if zero. The context deadlines apply as well.
+	Timeout     time.Duration
+	DialTimeout time.Duration
+	// Added to each request
+	CustomHeaders map[string]string
+	RetryPolicy   *utils.RetryPolicy
+}
+
+func parseFingerprintHelper(s string) ([]byte, error) {
+	res, err := hex.DecodeString(strings.ReplaceAll(s, ":", ""))
+	if err != nil || len(res) != sha256.Size {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidFingerprint, s)
+	}
+
+	return res, nil
+}
+
+func (config *DaemonRpcClientConfig) tlsConfigHelper() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		RootCAs:            config.RootCAs,
+		Certificates:       config.ClientCertificates,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+	if len(config.AllowedFingerprints) == 0 || config.InsecureSkipVerify {
+		return tlsConfig, nil
+	}
+
+	fingerprints := make([][]byte, len(config.AllowedFingerprints))
+	for i, v := range config.AllowedFingerprints {
+		f, err := parseFingerprintHelper(v)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints[i] = f
+	}
+
+	/**
+		The standard verification would reject the self-signed certificates before the fingerprints are checked,
+		so it is disabled and done here instead.
+	**/
+	tlsConfig.InsecureSkipVerify = true
+	tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return ErrCertificateNotAllowed
+		}
+
+		leaf := sha256.Sum256(cs.PeerCertificates[0].Raw)
+		for _, v := range fingerprints {
+			if subtle.ConstantTimeCompare(leaf[:], v) == 1 {
+				return nil
+			}
+		}
+		if config.RootCAs == nil {
+			return fmt.Errorf("%w: %s", ErrCertificateNotAllowed, hex.EncodeToString(leaf[:]))
+		}
+
+		opts := x509.VerifyOptions{DNSName: cs.ServerName, Roots: config.RootCAs, Intermediates: x509.NewCertPool()}
+		for _, v := range cs.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(v)
+		}
+		_, err := cs.PeerCertificates[0].Verify(opts)
+		return err
+	}
+
+	return tlsConfig, nil
+}
+
+func (config *DaemonRpcClientConfig) transportHelper() (http.RoundTripper, error) {
+	if config.Transport != nil {
+		if config.RootCAs != nil || len(config.ClientCertificates) > 0 || len(config.AllowedFingerprints) > 0 || config.InsecureSkipVerify || config.Proxy != nil {
+			return nil, ErrTransportConflict
+		}
+		return config.Transport, nil
+	}
+
+	tlsConfig, err := config.tlsConfigHelper()
+	if err != nil {
+		return nil, err
+	}
+
+	dialTimeout := config.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = DEFAULT_DIAL_TIMEOUT
+	}
+
+	transport := &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}).DialContext,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: DEFAULT_TLS_HANDSHAKE_TIMEOUT,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if config.Proxy != nil {
+		switch config.Proxy.Scheme {
+		case "socks5", "http", "https":
+			transport.Proxy = http.ProxyURL(config.Proxy)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedProxy, config.Proxy.Scheme)
+		}
+	}
+
+	return transport, nil
+}
+
+func newDigestTransportHelper(connection *RpcConnection, base http.RoundTripper) http.RoundTripper {
+	return &digest.Transport{
+		Username:  connection.username,
+		Password:  connection.password,
+		Transport: base,
+	}
+}
+
+func newDaemonRpcClientHelper(connection *RpcConnection, config DaemonRpcClientConfig) (*DaemonRpcClient, error) {
+	transport, err := config.transportHelper()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DaemonRpcClient{
+		connData:  *connection,
+		transport: transport,
+		headers:   config.CustomHeaders,
+		retry:     config.RetryPolicy,
+		httpcl: &http.Client{
+			Transport: newDigestTransportHelper(connection, transport),
+			Timeout:   config.Timeout,
+		},
+	}, nil
+}
+
+// Creates a client with the custom transport, TLS, proxy and timeout options.
+func NewDaemonRpcClientWithConfig(connection *RpcConnection, config DaemonRpcClientConfig) (IDaemonRpcClient, error) {
+	client, err := newDaemonRpcClientHelper(connection, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
@@ -2,21 +2,22 @@ package daemon
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
+	"sync/atomic"
 
+	"github.com/chekist32/go-monero/daemon/epee"
 	"github.com/chekist32/go-monero/utils"
-
-	"github.com/icholy/digest"
 )
 
 var defaultMoneroRpcHeader = JsonRpcHeader{Id: "0", Jsonrpc: "2.0"}
 
 type IDaemonRpcClient interface {
 	SetRpcConnection(connection *RpcConnection)
+	// Only the idempotent methods are retried, unless the others are listed in utils.RetryPolicy.AlsoRetry
+	SetRetryPolicy(policy *utils.RetryPolicy)
 
 	/**
 		JSON RPC METHODS
@@ -24,30 +25,110 @@ type IDaemonRpcClient interface {
 
 	// get_block_count
 	GetBlockCount() (*JsonRpcGenericResponse[GetBlockCountResult], error)
+	GetBlockCountCtx(ctx context.Context) (*JsonRpcGenericResponse[GetBlockCountResult], error)
 	// on_get_block_hash
 	OnGetBlockHash(height uint64) (*JsonRpcGenericResponse[OnGetBlockHashResult], error)
+	OnGetBlockHashCtx(ctx context.Context, height uint64) (*JsonRpcGenericResponse[OnGetBlockHashResult], error)
 	// get_block_template
 	GetBlockTemplate(wallet string, reverseSize uint64) (*JsonRpcGenericResponse[GetBlockTemplateResult], error)
+	GetBlockTemplateCtx(ctx context.Context, wallet string, reverseSize uint64) (*JsonRpcGenericResponse[GetBlockTemplateResult], error)
 	// submit_block
 	SubmitBlock(blobData []string) (*JsonRpcGenericResponse[SubmitBlockResult], error)
+	SubmitBlockCtx(ctx context.Context, blobData []string) (*JsonRpcGenericResponse[SubmitBlockResult], error)
 	// get_last_block_header
 	GetLastBlockHeader(fillPowHash bool) (*JsonRpcGenericResponse[GetBlockHeaderResult], error)
+	GetLastBlockHeaderCtx(ctx context.Context, fillPowHash bool) (*JsonRpcGenericResponse[GetBlockHeaderResult], error)
 	// get_block_header_by_hash
 	GetBlockHeaderByHash(fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockHeaderResult], error)
+	GetBlockHeaderByHashCtx(ctx context.Context, fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockHeaderResult], error)
 	// get_block_header_by_height
 	GetBlockHeaderByHeight(fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockHeaderResult], error)
+	GetBlockHeaderByHeightCtx(ctx context.Context, fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockHeaderResult], error)
 	// get_block_headers_range
 	GetBlockHeadersRange(fillPowHash bool, startHeight uint64, endHeight uint64) (*JsonRpcGenericResponse[GetBlockHeadersRangeResult], error)
+	GetBlockHeadersRangeCtx(ctx context.Context, fillPowHash bool, startHeight uint64, endHeight uint64) (*JsonRpcGenericResponse[GetBlockHeadersRangeResult], error)
 	// get_block
 	GetBlockByHeight(fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockResult], error)
+	GetBlockByHeightCtx(ctx context.Context, fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockResult], error)
 	// get_block
 	GetBlockByHash(fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockResult], error)
+	GetBlockByHashCtx(ctx context.Context, fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockResult], error)
 	// get_fee_estimate
 	GetFeeEstimate() (*JsonRpcGenericResponse[GetFeeEstimateResult], error)
+	GetFeeEstimateCtx(ctx context.Context) (*JsonRpcGenericResponse[GetFeeEstimateResult], error)
 	// get_version
 	GetVersion() (*JsonRpcGenericResponse[GetVersionResult], error)
+	GetVersionCtx(ctx context.Context) (*JsonRpcGenericResponse[GetVersionResult], error)
 	// get_info
 	GetInfo() (*JsonRpcGenericResponse[GetInfoResult], error)
+	GetInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[GetInfoResult], error)
+	// get_connections
+	GetConnections() (*JsonRpcGenericResponse[GetConnectionsResult], error)
+	GetConnectionsCtx(ctx context.Context) (*JsonRpcGenericResponse[GetConnectionsResult], error)
+	// get_bans
+	GetBans() (*JsonRpcGenericResponse[GetBansResult], error)
+	GetBansCtx(ctx context.Context) (*JsonRpcGenericResponse[GetBansResult], error)
+	// set_bans
+	SetBans(bans []SetBan) (*JsonRpcGenericResponse[SetBansResult], error)
+	SetBansCtx(ctx context.Context, bans []SetBan) (*JsonRpcGenericResponse[SetBansResult], error)
+	// flush_txpool
+	FlushTxpool(txIds []string) (*JsonRpcGenericResponse[FlushTxpoolResult], error)
+	FlushTxpoolCtx(ctx context.Context, txIds []string) (*JsonRpcGenericResponse[FlushTxpoolResult], error)
+	// get_output_histogram
+	GetOutputHistogram(amounts []uint64, minCount uint64, maxCount uint64, unlocked bool, recentCutoff uint64) (*JsonRpcGenericResponse[GetOutputHistogramResult], error)
+	GetOutputHistogramCtx(ctx context.Context, amounts []uint64, minCount uint64, maxCount uint64, unlocked bool, recentCutoff uint64) (*JsonRpcGenericResponse[GetOutputHistogramResult], error)
+	// get_coinbase_tx_sum
+	GetCoinbaseTxSum(height uint64, count uint64) (*JsonRpcGenericResponse[GetCoinbaseTxSumResult], error)
+	GetCoinbaseTxSumCtx(ctx context.Context, height uint64, count uint64) (*JsonRpcGenericResponse[GetCoinbaseTxSumResult], error)
+	// get_alternate_chains
+	GetAlternateChains() (*JsonRpcGenericResponse[GetAlternateChainsResult], error)
+	GetAlternateChainsCtx(ctx context.Context) (*JsonRpcGenericResponse[GetAlternateChainsResult], error)
+	// relay_tx
+	RelayTx(txIds []string) (*JsonRpcGenericResponse[RelayTxResult], error)
+	RelayTxCtx(ctx context.Context, txIds []string) (*JsonRpcGenericResponse[RelayTxResult], error)
+	// sync_info
+	SyncInfo() (*JsonRpcGenericResponse[SyncInfoResult], error)
+	SyncInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[SyncInfoResult], error)
+	// get_txpool_backlog
+	GetTxpoolBacklog() (*JsonRpcGenericResponse[GetTxpoolBacklogResult], error)
+	GetTxpoolBacklogCtx(ctx context.Context) (*JsonRpcGenericResponse[GetTxpoolBacklogResult], error)
+	// get_output_distribution
+	GetOutputDistribution(amounts []uint64, cumulative bool, fromHeight uint64, toHeight uint64) (*JsonRpcGenericResponse[GetOutputDistributionResult], error)
+	GetOutputDistributionCtx(ctx context.Context, amounts []uint64, cumulative bool, fromHeight uint64, toHeight uint64) (*JsonRpcGenericResponse[GetOutputDistributionResult], error)
+	// get_miner_data
+	GetMinerData() (*JsonRpcGenericResponse[GetMinerDataResult], error)
+	GetMinerDataCtx(ctx context.Context) (*JsonRpcGenericResponse[GetMinerDataResult], error)
+	// calc_pow
+	CalcPow(majorVersion uint8, height uint64, blockBlob string, seedHash string) (*JsonRpcGenericResponse[CalcPowResult], error)
+	CalcPowCtx(ctx context.Context, majorVersion uint8, height uint64, blockBlob string, seedHash string) (*JsonRpcGenericResponse[CalcPowResult], error)
+	// add_aux_pow
+	AddAuxPow(blocktemplateBlob string, auxPow []AuxPow) (*JsonRpcGenericResponse[AddAuxPowResult], error)
+	AddAuxPowCtx(ctx context.Context, blocktemplateBlob string, auxPow []AuxPow) (*JsonRpcGenericResponse[AddAuxPowResult], error)
+	// prune_blockchain
+	PruneBlockchain(check bool) (*JsonRpcGenericResponse[PruneBlockchainResult], error)
+	PruneBlockchainCtx(ctx context.Context, check bool) (*JsonRpcGenericResponse[PruneBlockchainResult], error)
+	// hard_fork_info
+	HardForkInfo() (*JsonRpcGenericResponse[HardForkInfoResult], error)
+	HardForkInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[HardForkInfoResult], error)
+	// banned
+	Banned(address string) (*JsonRpcGenericResponse[BannedResult], error)
+	BannedCtx(ctx context.Context, address string) (*JsonRpcGenericResponse[BannedResult], error)
+	// flush_cache
+	FlushCache(badTxs bool, badBlocks bool) (*JsonRpcGenericResponse[FlushCacheResult], error)
+	FlushCacheCtx(ctx context.Context, badTxs bool, badBlocks bool) (*JsonRpcGenericResponse[FlushCacheResult], error)
+	// rpc_access_info
+	// The RPC payment methods need the signature of NewRpcPaymentClient, the plain client returns ErrNoRpcPaymentKey
+	RpcAccessInfo() (*JsonRpcGenericResponse[RpcAccessInfoResult], error)
+	RpcAccessInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[RpcAccessInfoResult], error)
+	// rpc_access_submit_nonce
+	RpcAccessSubmitNonce(nonce uint32, cookie uint32) (*JsonRpcGenericResponse[RpcAccessSubmitNonceResult], error)
+	RpcAccessSubmitNonceCtx(ctx context.Context, nonce uint32, cookie uint32) (*JsonRpcGenericResponse[RpcAccessSubmitNonceResult], error)
+	// rpc_access_pay
+	RpcAccessPay(payingFor string, payment uint64) (*JsonRpcGenericResponse[RpcAccessPayResult], error)
+	RpcAccessPayCtx(ctx context.Context, payingFor string, payment uint64) (*JsonRpcGenericResponse[RpcAccessPayResult], error)
+	// rpc_access_account
+	RpcAccessAccount(deltaBalance int64) (*JsonRpcGenericResponse[RpcAccessAccountResult], error)
+	RpcAccessAccountCtx(ctx context.Context, deltaBalance int64) (*JsonRpcGenericResponse[RpcAccessAccountResult], error)
 
 	/**
 		OTHER RPC METHODS
@@ -55,38 +136,139 @@ type IDaemonRpcClient interface {
 
 	// get_height
 	GetCurrentHeight() (*GetHeightResponse, error)
+	GetCurrentHeightCtx(ctx context.Context) (*GetHeightResponse, error)
 	// get_transaction_pool
 	GetTransactionPool() (*GetTransactionPoolResponse, error)
+	GetTransactionPoolCtx(ctx context.Context) (*GetTransactionPoolResponse, error)
 	// get_transactions
 	GetTransactions(txHashes []string, decodeAsJson bool, prune bool, split bool) (*GetTransactionsResponse, error)
+	GetTransactionsCtx(ctx context.Context, txHashes []string, decodeAsJson bool, prune bool, split bool) (*GetTransactionsResponse, error)
+	// send_raw_transaction
+	// If the tx is rejected, the response is returned along with the error, so the reason flags can be inspected
+	SendRawTransaction(txAsHex string, doNotRelay bool, doSanityChecks bool) (*SendRawTransactionResponse, error)
+	SendRawTransactionCtx(ctx context.Context, txAsHex string, doNotRelay bool, doSanityChecks bool) (*SendRawTransactionResponse, error)
+	// is_key_image_spent
+	IsKeyImageSpent(keyImages []string) (*IsKeyImageSpentResponse, error)
+	IsKeyImageSpentCtx(ctx context.Context, keyImages []string) (*IsKeyImageSpentResponse, error)
+	// get_alt_blocks_hashes
+	GetAltBlocksHashes() (*GetAltBlocksHashesResponse, error)
+	GetAltBlocksHashesCtx(ctx context.Context) (*GetAltBlocksHashesResponse, error)
+	// get_transaction_pool_hashes
+	GetTransactionPoolHashes() (*GetTransactionPoolHashesResponse, error)
+	GetTransactionPoolHashesCtx(ctx context.Context) (*GetTransactionPoolHashesResponse, error)
+	// get_transaction_pool_stats
+	GetTransactionPoolStats() (*GetTransactionPoolStatsResponse, error)
+	GetTransactionPoolStatsCtx(ctx context.Context) (*GetTransactionPoolStatsResponse, error)
+	// get_limit
+	GetLimit() (*GetLimitResponse, error)
+	GetLimitCtx(ctx context.Context) (*GetLimitResponse, error)
+	// set_limit
+	SetLimit(limitDown int64, limitUp int64) (*SetLimitResponse, error)
+	SetLimitCtx(ctx context.Context, limitDown int64, limitUp int64) (*SetLimitResponse, error)
+	// get_peer_list
+	GetPeerList(publicOnly bool, includeBlocked bool) (*GetPeerListResponse, error)
+	GetPeerListCtx(ctx context.Context, publicOnly bool, includeBlocked bool) (*GetPeerListResponse, error)
+	// get_public_nodes
+	GetPublicNodes(gray bool, white bool, includeBlocked bool) (*GetPublicNodesResponse, error)
+	GetPublicNodesCtx(ctx context.Context, gray bool, white bool, includeBlocked bool) (*GetPublicNodesResponse, error)
+	// out_peers
+	OutPeers(outPeers uint32) (*OutPeersResponse, error)
+	OutPeersCtx(ctx context.Context, outPeers uint32) (*OutPeersResponse, error)
+	// in_peers
+	InPeers(inPeers uint32) (*InPeersResponse, error)
+	InPeersCtx(ctx context.Context, inPeers uint32) (*InPeersResponse, error)
+	// start_mining
+	StartMining(minerAddress string, threadsCount uint64, doBackgroundMining bool, ignoreBattery bool) (*StartMiningResponse, error)
+	StartMiningCtx(ctx context.Context, minerAddress string, threadsCount uint64, doBackgroundMining bool, ignoreBattery bool) (*StartMiningResponse, error)
+	// stop_mining
+	StopMining() (*StopMiningResponse, error)
+	StopMiningCtx(ctx context.Context) (*StopMiningResponse, error)
+	// mining_status
+	MiningStatus() (*MiningStatusResponse, error)
+	MiningStatusCtx(ctx context.Context) (*MiningStatusResponse, error)
+	// save_bc
+	SaveBc() (*SaveBcResponse, error)
+	SaveBcCtx(ctx context.Context) (*SaveBcResponse, error)
+	// get_net_stats
+	GetNetStats() (*GetNetStatsResponse, error)
+	GetNetStatsCtx(ctx context.Context) (*GetNetStatsResponse, error)
+	// pop_blocks
+	PopBlocks(nBlocks uint64) (*PopBlocksResponse, error)
+	PopBlocksCtx(ctx context.Context, nBlocks uint64) (*PopBlocksResponse, error)
+	// update
+	Update(command string, path string) (*UpdateResponse, error)
+	UpdateCtx(ctx context.Context, command string, path string) (*UpdateResponse, error)
+	// stop_daemon
+	StopDaemon() (*StopDaemonResponse, error)
+	StopDaemonCtx(ctx context.Context) (*StopDaemonResponse, error)
+
+	/**
+		BINARY RPC METHODS
+	**/
+
+	// get_blocks.bin
+	GetBlocksBin(blockIds []string, startHeight uint64, prune bool, noMinerTx bool) (*GetBlocksBinResponse, error)
+	GetBlocksBinCtx(ctx context.Context, blockIds []string, startHeight uint64, prune bool, noMinerTx bool) (*GetBlocksBinResponse, error)
+	// get_blocks_by_height.bin
+	GetBlocksByHeightBin(heights []uint64) (*GetBlocksByHeightBinResponse, error)
+	GetBlocksByHeightBinCtx(ctx context.Context, heights []uint64) (*GetBlocksByHeightBinResponse, error)
+	// get_hashes.bin
+	GetHashesBin(blockIds []string, startHeight uint64) (*GetHashesBinResponse, error)
+	GetHashesBinCtx(ctx context.Context, blockIds []string, startHeight uint64) (*GetHashesBinResponse, error)
+	// get_o_indexes.bin
+	GetOIndexesBin(txId string) (*GetOIndexesBinResponse, error)
+	GetOIndexesBinCtx(ctx context.Context, txId string) (*GetOIndexesBinResponse, error)
+	// get_outs.bin
+	GetOutsBin(outputs []GetOutputsOut, getTxId bool) (*GetOutsBinResponse, error)
+	GetOutsBinCtx(ctx context.Context, outputs []GetOutputsOut, getTxId bool) (*GetOutsBinResponse, error)
 }
 
 type DaemonRpcClient struct {
 	connData RpcConnection
 	httpcl   *http.Client
+	// the transport below the digest authentication
+	transport http.RoundTripper
+	headers   map[string]string
+	retry     *utils.RetryPolicy
+	// set once the daemon rejects a batch request
+	batchUnsupported atomic.Bool
+	// set by NewRpcPaymentClient
+	payment *rpcPaymentState
 }
 
 func (c *DaemonRpcClient) SetRpcConnection(connection *RpcConnection) {
 	c.connData = *connection
-	c.httpcl.Transport = &digest.Transport{
-		Username: connection.username,
-		Password: connection.password,
+	c.batchUnsupported.Store(false)
+	if c.payment != nil {
+		c.payment.resetHelper()
 	}
+	c.httpcl.Transport = newDigestTransportHelper(connection, c.transport)
+}
+
+// The nil policy disables the retries, which is the default
+func (c *DaemonRpcClient) SetRetryPolicy(policy *utils.RetryPolicy) {
+	c.retry = policy
 }
 
-func (c *DaemonRpcClient) sendRequest(method string, path string, body io.Reader) (*http.Response, error) {
+func (c *DaemonRpcClient) sendRequest(ctx context.Context, method string, path string, contentType string, body io.Reader) (*http.Response, error) {
 	url := c.connData.host.Scheme + "://" + c.connData.host.Host + path
 
-	req, err := http.NewRequest(method, url, body)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Accept", "application/json")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Add("Accept", contentType)
+	if contentType != "application/json" {
+		req.Header.Add("Content-Type", contentType)
+	}
 
 	return c.httpcl.Do(req)
 }
 
-func getResultFromDaemonRpc[R MoneroRpcResponse, B MoneroRpcRequestBody](c *DaemonRpcClient, req *MoneroRpcRequest[B]) (*R, error) {
+func getResultFromDaemonRpc[R MoneroRpcResponse, B MoneroRpcRequestBody](ctx context.Context, c *DaemonRpcClient, req *MoneroRpcRequest[B]) (*R, error) {
 	var data []byte
 	var err error
 
@@ -98,39 +280,113 @@ func getResultFromDaemonRpc[R MoneroRpcResponse, B MoneroRpcRequestBody](c *Daem
 		}
 	}
 
-	res, err := c.sendRequest(http.MethodPost, req.Endpoint, bytes.NewReader(data))
+	method := rpcMethodHelper(req)
+	_, jsonRpc := any(body).(jsonRpcMethod)
+	signable := !jsonRpc || !positionalParamsHelper(data)
+	res, err := doWithPaymentHelper(ctx, c, method, signable, func() (*R, error) {
+		return doWithRetryHelper(ctx, c, method, func() (*R, error) {
+			// each attempt gets a new signature, the daemon rejects the reused timestamps
+			signed, err := c.payment.signJsonHelper(data, jsonRpc)
+			if err != nil {
+				return nil, err
+			}
+
+			res, err := c.sendRequest(ctx, http.MethodPost, req.Endpoint, "application/json", bytes.NewReader(signed))
+			if err != nil {
+				return nil, err
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode >= 400 {
+				return nil, utils.NewHttpStatusError(method, res)
+			}
+
+			result, err := utils.ParseResponse[R](res.Body)
+			if err != nil {
+				return nil, err
+			}
+			setErrorContextHelper(result, method, res.StatusCode)
+			c.payment.updateHelper(result)
+
+			return result, nil
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode >= 400 {
-		return nil, errors.New(res.Status)
+	if err := checkJsonRpcStatusHelper(method, res); err != nil {
+		return nil, err
 	}
 
-	defer res.Body.Close()
+	return res, nil
+}
 
-	result, err := utils.ParseResponse[R](res.Body)
+func getResultFromDaemonRpcBin[R BinRpcResponse, B BinRpcRequestBody](ctx context.Context, c *DaemonRpcClient, req *MoneroRpcRequest[B]) (*R, error) {
+	data, err := epee.Marshal(req.Body)
 	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	method := rpcMethodHelper(req)
+	_, signable := any(req.Body).(rpcAccessRequest)
+	return doWithPaymentHelper(ctx, c, method, signable, func() (*R, error) {
+		return doWithRetryHelper(ctx, c, method, func() (*R, error) {
+			signed, err := c.payment.signBinHelper(req.Body, data)
+			if err != nil {
+				return nil, err
+			}
+
+			res, err := c.sendRequest(ctx, http.MethodPost, req.Endpoint, "application/octet-stream", bytes.NewReader(signed))
+			if err != nil {
+				return nil, err
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode >= 400 {
+				return nil, utils.NewHttpStatusError(method, res)
+			}
+
+			body, err := io.ReadAll(res.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			var result R
+			if err := epee.Unmarshal(body, &result); err != nil {
+				return nil, err
+			}
+			c.payment.updateHelper(&result)
+
+			return &result, nil
+		})
+	})
 }
 
-func NewDaemonRpcClient(connection *RpcConnection) IDaemonRpcClient {
-	return &DaemonRpcClient{
-		connData: *connection,
-		httpcl: &http.Client{
-			Transport: &digest.Transport{
-				Username: connection.username,
-				Password: connection.password,
-				Transport: &http.Transport{
-					TLSClientConfig: &tls.Config{
-						InsecureSkipVerify: true,
-					},
-				},
-			},
-		},
+// Most of the other and binary endpoints report failures by the status only
+func checkStatusHelper(endpoint string, status string) error {
+	if status != RPC_STATUS_OK {
+		return &RpcStatusError{endpoint, status}
+	}
+
+	return nil
+}
+
+// The JSON RPC methods report the busy daemon either by the error object or by the status of the result,
+// the latter is turned into an RpcStatusError. The other statuses are left to the caller.
+func checkJsonRpcStatusHelper(method string, res any) error {
+	if r, ok := res.(interface{ busyResult() bool }); ok && r.busyResult() {
+		return &RpcStatusError{method, RPC_STATUS_BUSY}
 	}
+
+	return nil
+}
+
+// Creates a client with the default options, the node certificates are verified against the system roots.
+// NewDaemonRpcClientWithConfig allows to pin the certificates of the nodes with the self-signed ones.
+func NewDaemonRpcClient(connection *RpcConnection) IDaemonRpcClient {
+	// the default config is always valid
+	client, _ := NewDaemonRpcClientWithConfig(connection, DaemonRpcClientConfig{})
+	return client
 }
 
 /**
@@ -139,10 +395,14 @@ func NewDaemonRpcClient(connection *RpcConnection) IDaemonRpcClient {
 
 // get_block_count
 func (c *DaemonRpcClient) GetBlockCount() (*JsonRpcGenericResponse[GetBlockCountResult], error) {
+	return c.GetBlockCountCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetBlockCountCtx(ctx context.Context) (*JsonRpcGenericResponse[GetBlockCountResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[GetBlockCountParams]{defaultMoneroRpcHeader, "get_block_count", GetBlockCountParams{}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetBlockCountParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockCountResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockCountResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -155,10 +415,14 @@ func (c *DaemonRpcClient) GetBlockCount() (*JsonRpcGenericResponse[GetBlockCount
 
 // on_get_block_hash
 func (c *DaemonRpcClient) OnGetBlockHash(height uint64) (*JsonRpcGenericResponse[OnGetBlockHashResult], error) {
+	return c.OnGetBlockHashCtx(context.Background(), height)
+}
+
+func (c *DaemonRpcClient) OnGetBlockHashCtx(ctx context.Context, height uint64) (*JsonRpcGenericResponse[OnGetBlockHashResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[OnGetBlockHashParams]{defaultMoneroRpcHeader, "on_get_block_hash", [1]uint64{height}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[OnGetBlockHashParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[OnGetBlockHashResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[OnGetBlockHashResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -171,10 +435,14 @@ func (c *DaemonRpcClient) OnGetBlockHash(height uint64) (*JsonRpcGenericResponse
 
 // get_block_template
 func (c *DaemonRpcClient) GetBlockTemplate(wallet string, reverseSize uint64) (*JsonRpcGenericResponse[GetBlockTemplateResult], error) {
+	return c.GetBlockTemplateCtx(context.Background(), wallet, reverseSize)
+}
+
+func (c *DaemonRpcClient) GetBlockTemplateCtx(ctx context.Context, wallet string, reverseSize uint64) (*JsonRpcGenericResponse[GetBlockTemplateResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[GetBlockTemplateParams]{defaultMoneroRpcHeader, "get_block_template", GetBlockTemplateParams{wallet, reverseSize}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetBlockTemplateParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockTemplateResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockTemplateResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -187,10 +455,14 @@ func (c *DaemonRpcClient) GetBlockTemplate(wallet string, reverseSize uint64) (*
 
 // submit_block
 func (c *DaemonRpcClient) SubmitBlock(blobData []string) (*JsonRpcGenericResponse[SubmitBlockResult], error) {
+	return c.SubmitBlockCtx(context.Background(), blobData)
+}
+
+func (c *DaemonRpcClient) SubmitBlockCtx(ctx context.Context, blobData []string) (*JsonRpcGenericResponse[SubmitBlockResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[SubmitBlockParams]{defaultMoneroRpcHeader, "submit_block", blobData}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[SubmitBlockParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[SubmitBlockResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[SubmitBlockResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -203,10 +475,14 @@ func (c *DaemonRpcClient) SubmitBlock(blobData []string) (*JsonRpcGenericRespons
 
 // get_last_block_header
 func (c *DaemonRpcClient) GetLastBlockHeader(fillPowHash bool) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return c.GetLastBlockHeaderCtx(context.Background(), fillPowHash)
+}
+
+func (c *DaemonRpcClient) GetLastBlockHeaderCtx(ctx context.Context, fillPowHash bool) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[GetBlockHeaderDefaultParams]{defaultMoneroRpcHeader, "get_last_block_header", GetBlockHeaderDefaultParams{fillPowHash}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetBlockHeaderDefaultParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockHeaderResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockHeaderResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -219,10 +495,14 @@ func (c *DaemonRpcClient) GetLastBlockHeader(fillPowHash bool) (*JsonRpcGenericR
 
 // get_block_header_by_hash
 func (c *DaemonRpcClient) GetBlockHeaderByHash(fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return c.GetBlockHeaderByHashCtx(context.Background(), fillPowHash, hash)
+}
+
+func (c *DaemonRpcClient) GetBlockHeaderByHashCtx(ctx context.Context, fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[GetBlockHeaderByHashParams]{defaultMoneroRpcHeader, "get_block_header_by_hash", GetBlockHeaderByHashParams{GetBlockHeaderDefaultParams{fillPowHash}, hash}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetBlockHeaderByHashParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockHeaderResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockHeaderResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -235,10 +515,14 @@ func (c *DaemonRpcClient) GetBlockHeaderByHash(fillPowHash bool, hash string) (*
 
 // get_block_header_by_height
 func (c *DaemonRpcClient) GetBlockHeaderByHeight(fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return c.GetBlockHeaderByHeightCtx(context.Background(), fillPowHash, height)
+}
+
+func (c *DaemonRpcClient) GetBlockHeaderByHeightCtx(ctx context.Context, fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[GetBlockHeaderByHeightParams]{defaultMoneroRpcHeader, "get_block_header_by_height", GetBlockHeaderByHeightParams{GetBlockHeaderDefaultParams{fillPowHash}, height}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetBlockHeaderByHeightParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockHeaderResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockHeaderResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -251,10 +535,14 @@ func (c *DaemonRpcClient) GetBlockHeaderByHeight(fillPowHash bool, height uint64
 
 // get_block_headers_range
 func (c *DaemonRpcClient) GetBlockHeadersRange(fillPowHash bool, startHeight uint64, endHeight uint64) (*JsonRpcGenericResponse[GetBlockHeadersRangeResult], error) {
+	return c.GetBlockHeadersRangeCtx(context.Background(), fillPowHash, startHeight, endHeight)
+}
+
+func (c *DaemonRpcClient) GetBlockHeadersRangeCtx(ctx context.Context, fillPowHash bool, startHeight uint64, endHeight uint64) (*JsonRpcGenericResponse[GetBlockHeadersRangeResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[GetBlockHeadersRangeParams]{defaultMoneroRpcHeader, "get_block_headers_range", GetBlockHeadersRangeParams{GetBlockHeaderDefaultParams{fillPowHash}, startHeight, endHeight}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetBlockHeadersRangeParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockHeadersRangeResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockHeadersRangeResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -286,10 +574,14 @@ func fillBlockDetailsHelper(res *JsonRpcGenericResponse[GetBlockResult]) (*JsonR
 
 // get_block
 func (c *DaemonRpcClient) GetBlockByHeight(fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockResult], error) {
+	return c.GetBlockByHeightCtx(context.Background(), fillPowHash, height)
+}
+
+func (c *DaemonRpcClient) GetBlockByHeightCtx(ctx context.Context, fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[GetBlockByHeightParams]{defaultMoneroRpcHeader, "get_block", GetBlockByHeightParams{GetBlockHeaderDefaultParams{fillPowHash}, height}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetBlockByHeightParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -302,10 +594,14 @@ func (c *DaemonRpcClient) GetBlockByHeight(fillPowHash bool, height uint64) (*Js
 
 // get_block
 func (c *DaemonRpcClient) GetBlockByHash(fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockResult], error) {
+	return c.GetBlockByHashCtx(context.Background(), fillPowHash, hash)
+}
+
+func (c *DaemonRpcClient) GetBlockByHashCtx(ctx context.Context, fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[GetBlockByHashParams]{defaultMoneroRpcHeader, "get_block", GetBlockByHashParams{GetBlockHeaderDefaultParams{fillPowHash}, hash}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetBlockByHashParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBlockResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -318,10 +614,14 @@ func (c *DaemonRpcClient) GetBlockByHash(fillPowHash bool, hash string) (*JsonRp
 
 // get_fee_estimate
 func (c *DaemonRpcClient) GetFeeEstimate() (*JsonRpcGenericResponse[GetFeeEstimateResult], error) {
+	return c.GetFeeEstimateCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetFeeEstimateCtx(ctx context.Context) (*JsonRpcGenericResponse[GetFeeEstimateResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "get_fee_estimate", EmptyMoneroRpcParams{}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetFeeEstimateResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetFeeEstimateResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -334,10 +634,14 @@ func (c *DaemonRpcClient) GetFeeEstimate() (*JsonRpcGenericResponse[GetFeeEstima
 
 // get_version
 func (c *DaemonRpcClient) GetVersion() (*JsonRpcGenericResponse[GetVersionResult], error) {
+	return c.GetVersionCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetVersionCtx(ctx context.Context) (*JsonRpcGenericResponse[GetVersionResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "get_version", EmptyMoneroRpcParams{}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetVersionResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetVersionResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -350,10 +654,14 @@ func (c *DaemonRpcClient) GetVersion() (*JsonRpcGenericResponse[GetVersionResult
 
 // get_info
 func (c *DaemonRpcClient) GetInfo() (*JsonRpcGenericResponse[GetInfoResult], error) {
+	return c.GetInfoCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[GetInfoResult], error) {
 	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "get_info", EmptyMoneroRpcParams{}}
 	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetInfoResult]](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetInfoResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -364,15 +672,16 @@ func (c *DaemonRpcClient) GetInfo() (*JsonRpcGenericResponse[GetInfoResult], err
 	return res, nil
 }
 
-/**
-	OTHER RPC METHODS
-**/
+// get_connections
+func (c *DaemonRpcClient) GetConnections() (*JsonRpcGenericResponse[GetConnectionsResult], error) {
+	return c.GetConnectionsCtx(context.Background())
+}
 
-// get_height
-func (c *DaemonRpcClient) GetCurrentHeight() (*GetHeightResponse, error) {
-	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/get_height", nil}
+func (c *DaemonRpcClient) GetConnectionsCtx(ctx context.Context) (*JsonRpcGenericResponse[GetConnectionsResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "get_connections", EmptyMoneroRpcParams{}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
 
-	res, err := getResultFromDaemonRpc[GetHeightResponse](c, req)
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetConnectionsResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -383,56 +692,1051 @@ func (c *DaemonRpcClient) GetCurrentHeight() (*GetHeightResponse, error) {
 	return res, nil
 }
 
-// get_transaction_pool
-func fillGetTransactionPoolHelper(res *GetTransactionPoolResponse) (*GetTransactionPoolResponse, error) {
-	for i := range res.Transactions {
-		if err := fillMissedFieldHelper(res.Transactions[i].TxJson, &res.Transactions[i].TxInfo); err != nil {
-			return nil, err
-		}
+// get_bans
+func (c *DaemonRpcClient) GetBans() (*JsonRpcGenericResponse[GetBansResult], error) {
+	return c.GetBansCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetBansCtx(ctx context.Context) (*JsonRpcGenericResponse[GetBansResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "get_bans", EmptyMoneroRpcParams{}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetBansResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
 	}
 
 	return res, nil
 }
 
-// get_transaction_pool
-func (c *DaemonRpcClient) GetTransactionPool() (*GetTransactionPoolResponse, error) {
-	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/get_transaction_pool", nil}
+// set_bans
+func (c *DaemonRpcClient) SetBans(bans []SetBan) (*JsonRpcGenericResponse[SetBansResult], error) {
+	return c.SetBansCtx(context.Background(), bans)
+}
 
-	res, err := getResultFromDaemonRpc[GetTransactionPoolResponse](c, req)
+func (c *DaemonRpcClient) SetBansCtx(ctx context.Context, bans []SetBan) (*JsonRpcGenericResponse[SetBansResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[SetBansParams]{defaultMoneroRpcHeader, "set_bans", SetBansParams{bans}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[SetBansParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[SetBansResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
 
-	return fillGetTransactionPoolHelper(res)
+	return res, nil
 }
 
-func fillGetTransactionsHelper(res *GetTransactionsResponse) (*GetTransactionsResponse, error) {
-	for i := range res.Txs {
-		if err := fillMissedFieldHelper(res.Txs[i].AsJson, &res.Txs[i].TxInfo); err != nil {
-			return nil, err
-		}
+// flush_txpool
+func (c *DaemonRpcClient) FlushTxpool(txIds []string) (*JsonRpcGenericResponse[FlushTxpoolResult], error) {
+	return c.FlushTxpoolCtx(context.Background(), txIds)
+}
+
+func (c *DaemonRpcClient) FlushTxpoolCtx(ctx context.Context, txIds []string) (*JsonRpcGenericResponse[FlushTxpoolResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[FlushTxpoolParams]{defaultMoneroRpcHeader, "flush_txpool", FlushTxpoolParams{txIds}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[FlushTxpoolParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[FlushTxpoolResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
 	}
 
 	return res, nil
 }
 
-// get_transactions
-func (c *DaemonRpcClient) GetTransactions(txHashes []string, decodeAsJson bool, prune bool, split bool) (*GetTransactionsResponse, error) {
-	reqBody := &GetTransactionsParams{
-		TxHashes:     txHashes,
-		DecodeAsJson: decodeAsJson,
-		Prune:        prune,
-		Split:        split,
+// get_output_histogram
+func (c *DaemonRpcClient) GetOutputHistogram(amounts []uint64, minCount uint64, maxCount uint64, unlocked bool, recentCutoff uint64) (*JsonRpcGenericResponse[GetOutputHistogramResult], error) {
+	return c.GetOutputHistogramCtx(context.Background(), amounts, minCount, maxCount, unlocked, recentCutoff)
+}
+
+func (c *DaemonRpcClient) GetOutputHistogramCtx(ctx context.Context, amounts []uint64, minCount uint64, maxCount uint64, unlocked bool, recentCutoff uint64) (*JsonRpcGenericResponse[GetOutputHistogramResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[GetOutputHistogramParams]{defaultMoneroRpcHeader, "get_output_histogram", GetOutputHistogramParams{amounts, minCount, maxCount, unlocked, recentCutoff}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetOutputHistogramParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetOutputHistogramResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
 	}
-	req := &MoneroRpcRequest[GetTransactionsParams]{"/get_transactions", reqBody}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// get_coinbase_tx_sum
+func (c *DaemonRpcClient) GetCoinbaseTxSum(height uint64, count uint64) (*JsonRpcGenericResponse[GetCoinbaseTxSumResult], error) {
+	return c.GetCoinbaseTxSumCtx(context.Background(), height, count)
+}
 
-	res, err := getResultFromDaemonRpc[GetTransactionsResponse](c, req)
+func (c *DaemonRpcClient) GetCoinbaseTxSumCtx(ctx context.Context, height uint64, count uint64) (*JsonRpcGenericResponse[GetCoinbaseTxSumResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[GetCoinbaseTxSumParams]{defaultMoneroRpcHeader, "get_coinbase_tx_sum", GetCoinbaseTxSumParams{height, count}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetCoinbaseTxSumParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetCoinbaseTxSumResult]](ctx, c, req)
 	if err != nil {
 		return nil, err
 	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
 
-	if decodeAsJson {
-		return fillGetTransactionsHelper(res)
+	return res, nil
+}
+
+// get_alternate_chains
+func (c *DaemonRpcClient) GetAlternateChains() (*JsonRpcGenericResponse[GetAlternateChainsResult], error) {
+	return c.GetAlternateChainsCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetAlternateChainsCtx(ctx context.Context) (*JsonRpcGenericResponse[GetAlternateChainsResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "get_alternate_chains", EmptyMoneroRpcParams{}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetAlternateChainsResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// relay_tx
+func (c *DaemonRpcClient) RelayTx(txIds []string) (*JsonRpcGenericResponse[RelayTxResult], error) {
+	return c.RelayTxCtx(context.Background(), txIds)
+}
+
+func (c *DaemonRpcClient) RelayTxCtx(ctx context.Context, txIds []string) (*JsonRpcGenericResponse[RelayTxResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[RelayTxParams]{defaultMoneroRpcHeader, "relay_tx", RelayTxParams{txIds}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[RelayTxParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[RelayTxResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// sync_info
+func (c *DaemonRpcClient) SyncInfo() (*JsonRpcGenericResponse[SyncInfoResult], error) {
+	return c.SyncInfoCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) SyncInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[SyncInfoResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "sync_info", EmptyMoneroRpcParams{}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[SyncInfoResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// get_txpool_backlog
+func (c *DaemonRpcClient) GetTxpoolBacklog() (*JsonRpcGenericResponse[GetTxpoolBacklogResult], error) {
+	return c.GetTxpoolBacklogCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetTxpoolBacklogCtx(ctx context.Context) (*JsonRpcGenericResponse[GetTxpoolBacklogResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "get_txpool_backlog", EmptyMoneroRpcParams{}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetTxpoolBacklogResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// get_output_distribution
+func (c *DaemonRpcClient) GetOutputDistribution(amounts []uint64, cumulative bool, fromHeight uint64, toHeight uint64) (*JsonRpcGenericResponse[GetOutputDistributionResult], error) {
+	return c.GetOutputDistributionCtx(context.Background(), amounts, cumulative, fromHeight, toHeight)
+}
+
+func (c *DaemonRpcClient) GetOutputDistributionCtx(ctx context.Context, amounts []uint64, cumulative bool, fromHeight uint64, toHeight uint64) (*JsonRpcGenericResponse[GetOutputDistributionResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[GetOutputDistributionParams]{defaultMoneroRpcHeader, "get_output_distribution", GetOutputDistributionParams{amounts, cumulative, fromHeight, toHeight, false}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[GetOutputDistributionParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetOutputDistributionResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// get_miner_data
+func (c *DaemonRpcClient) GetMinerData() (*JsonRpcGenericResponse[GetMinerDataResult], error) {
+	return c.GetMinerDataCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetMinerDataCtx(ctx context.Context) (*JsonRpcGenericResponse[GetMinerDataResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "get_miner_data", EmptyMoneroRpcParams{}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[GetMinerDataResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// calc_pow
+func (c *DaemonRpcClient) CalcPow(majorVersion uint8, height uint64, blockBlob string, seedHash string) (*JsonRpcGenericResponse[CalcPowResult], error) {
+	return c.CalcPowCtx(context.Background(), majorVersion, height, blockBlob, seedHash)
+}
+
+func (c *DaemonRpcClient) CalcPowCtx(ctx context.Context, majorVersion uint8, height uint64, blockBlob string, seedHash string) (*JsonRpcGenericResponse[CalcPowResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[CalcPowParams]{defaultMoneroRpcHeader, "calc_pow", CalcPowParams{majorVersion, height, blockBlob, seedHash}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[CalcPowParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[CalcPowResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// add_aux_pow
+func (c *DaemonRpcClient) AddAuxPow(blocktemplateBlob string, auxPow []AuxPow) (*JsonRpcGenericResponse[AddAuxPowResult], error) {
+	return c.AddAuxPowCtx(context.Background(), blocktemplateBlob, auxPow)
+}
+
+func (c *DaemonRpcClient) AddAuxPowCtx(ctx context.Context, blocktemplateBlob string, auxPow []AuxPow) (*JsonRpcGenericResponse[AddAuxPowResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[AddAuxPowParams]{defaultMoneroRpcHeader, "add_aux_pow", AddAuxPowParams{blocktemplateBlob, auxPow}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[AddAuxPowParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[AddAuxPowResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// prune_blockchain
+func (c *DaemonRpcClient) PruneBlockchain(check bool) (*JsonRpcGenericResponse[PruneBlockchainResult], error) {
+	return c.PruneBlockchainCtx(context.Background(), check)
+}
+
+func (c *DaemonRpcClient) PruneBlockchainCtx(ctx context.Context, check bool) (*JsonRpcGenericResponse[PruneBlockchainResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[PruneBlockchainParams]{defaultMoneroRpcHeader, "prune_blockchain", PruneBlockchainParams{check}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[PruneBlockchainParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[PruneBlockchainResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// hard_fork_info
+func (c *DaemonRpcClient) HardForkInfo() (*JsonRpcGenericResponse[HardForkInfoResult], error) {
+	return c.HardForkInfoCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) HardForkInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[HardForkInfoResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "hard_fork_info", EmptyMoneroRpcParams{}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[HardForkInfoResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// banned
+func (c *DaemonRpcClient) Banned(address string) (*JsonRpcGenericResponse[BannedResult], error) {
+	return c.BannedCtx(context.Background(), address)
+}
+
+func (c *DaemonRpcClient) BannedCtx(ctx context.Context, address string) (*JsonRpcGenericResponse[BannedResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[BannedParams]{defaultMoneroRpcHeader, "banned", BannedParams{address}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[BannedParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[BannedResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// flush_cache
+func (c *DaemonRpcClient) FlushCache(badTxs bool, badBlocks bool) (*JsonRpcGenericResponse[FlushCacheResult], error) {
+	return c.FlushCacheCtx(context.Background(), badTxs, badBlocks)
+}
+
+func (c *DaemonRpcClient) FlushCacheCtx(ctx context.Context, badTxs bool, badBlocks bool) (*JsonRpcGenericResponse[FlushCacheResult], error) {
+	reqBody := &JsonRpcGenericRequestBody[FlushCacheParams]{defaultMoneroRpcHeader, "flush_cache", FlushCacheParams{badTxs, badBlocks}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[FlushCacheParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[FlushCacheResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// rpc_access_info
+func (c *DaemonRpcClient) RpcAccessInfo() (*JsonRpcGenericResponse[RpcAccessInfoResult], error) {
+	return c.RpcAccessInfoCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) RpcAccessInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[RpcAccessInfoResult], error) {
+	if c.payment == nil {
+		return nil, ErrNoRpcPaymentKey
+	}
+
+	reqBody := &JsonRpcGenericRequestBody[EmptyMoneroRpcParams]{defaultMoneroRpcHeader, "rpc_access_info", EmptyMoneroRpcParams{}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[EmptyMoneroRpcParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[RpcAccessInfoResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// rpc_access_submit_nonce
+func (c *DaemonRpcClient) RpcAccessSubmitNonce(nonce uint32, cookie uint32) (*JsonRpcGenericResponse[RpcAccessSubmitNonceResult], error) {
+	return c.RpcAccessSubmitNonceCtx(context.Background(), nonce, cookie)
+}
+
+func (c *DaemonRpcClient) RpcAccessSubmitNonceCtx(ctx context.Context, nonce uint32, cookie uint32) (*JsonRpcGenericResponse[RpcAccessSubmitNonceResult], error) {
+	if c.payment == nil {
+		return nil, ErrNoRpcPaymentKey
+	}
+
+	reqBody := &JsonRpcGenericRequestBody[RpcAccessSubmitNonceParams]{defaultMoneroRpcHeader, "rpc_access_submit_nonce", RpcAccessSubmitNonceParams{nonce, cookie}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[RpcAccessSubmitNonceParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[RpcAccessSubmitNonceResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// rpc_access_pay
+func (c *DaemonRpcClient) RpcAccessPay(payingFor string, payment uint64) (*JsonRpcGenericResponse[RpcAccessPayResult], error) {
+	return c.RpcAccessPayCtx(context.Background(), payingFor, payment)
+}
+
+func (c *DaemonRpcClient) RpcAccessPayCtx(ctx context.Context, payingFor string, payment uint64) (*JsonRpcGenericResponse[RpcAccessPayResult], error) {
+	if c.payment == nil {
+		return nil, ErrNoRpcPaymentKey
+	}
+
+	reqBody := &JsonRpcGenericRequestBody[RpcAccessPayParams]{defaultMoneroRpcHeader, "rpc_access_pay", RpcAccessPayParams{payingFor, payment}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[RpcAccessPayParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[RpcAccessPayResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// rpc_access_account
+func (c *DaemonRpcClient) RpcAccessAccount(deltaBalance int64) (*JsonRpcGenericResponse[RpcAccessAccountResult], error) {
+	return c.RpcAccessAccountCtx(context.Background(), deltaBalance)
+}
+
+func (c *DaemonRpcClient) RpcAccessAccountCtx(ctx context.Context, deltaBalance int64) (*JsonRpcGenericResponse[RpcAccessAccountResult], error) {
+	if c.payment == nil {
+		return nil, ErrNoRpcPaymentKey
+	}
+
+	reqBody := &JsonRpcGenericRequestBody[RpcAccessAccountParams]{defaultMoneroRpcHeader, "rpc_access_account", RpcAccessAccountParams{deltaBalance}}
+	req := &MoneroRpcRequest[JsonRpcGenericRequestBody[RpcAccessAccountParams]]{DEFAULT_MONERO_RPC_ENDPOINT, reqBody}
+
+	res, err := getResultFromDaemonRpc[JsonRpcGenericResponse[RpcAccessAccountResult]](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+/**
+	OTHER RPC METHODS
+**/
+
+// get_height
+func (c *DaemonRpcClient) GetCurrentHeight() (*GetHeightResponse, error) {
+	return c.GetCurrentHeightCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetCurrentHeightCtx(ctx context.Context) (*GetHeightResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/get_height", nil}
+
+	res, err := getResultFromDaemonRpc[GetHeightResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+
+	return res, nil
+}
+
+// get_transaction_pool
+func fillGetTransactionPoolHelper(res *GetTransactionPoolResponse) (*GetTransactionPoolResponse, error) {
+	for i := range res.Transactions {
+		if err := fillMissedFieldHelper(res.Transactions[i].TxJson, &res.Transactions[i].TxInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// get_transaction_pool
+func (c *DaemonRpcClient) GetTransactionPool() (*GetTransactionPoolResponse, error) {
+	return c.GetTransactionPoolCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetTransactionPoolCtx(ctx context.Context) (*GetTransactionPoolResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/get_transaction_pool", nil}
+
+	res, err := getResultFromDaemonRpc[GetTransactionPoolResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return fillGetTransactionPoolHelper(res)
+}
+
+func fillGetTransactionsHelper(res *GetTransactionsResponse) (*GetTransactionsResponse, error) {
+	for i := range res.Txs {
+		if err := fillMissedFieldHelper(res.Txs[i].AsJson, &res.Txs[i].TxInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// get_transactions
+func (c *DaemonRpcClient) GetTransactions(txHashes []string, decodeAsJson bool, prune bool, split bool) (*GetTransactionsResponse, error) {
+	return c.GetTransactionsCtx(context.Background(), txHashes, decodeAsJson, prune, split)
+}
+
+func (c *DaemonRpcClient) GetTransactionsCtx(ctx context.Context, txHashes []string, decodeAsJson bool, prune bool, split bool) (*GetTransactionsResponse, error) {
+	reqBody := &GetTransactionsParams{
+		TxHashes:     txHashes,
+		DecodeAsJson: decodeAsJson,
+		Prune:        prune,
+		Split:        split,
+	}
+	req := &MoneroRpcRequest[GetTransactionsParams]{"/get_transactions", reqBody}
+
+	res, err := getResultFromDaemonRpc[GetTransactionsResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if decodeAsJson {
+		return fillGetTransactionsHelper(res)
+	}
+
+	return res, nil
+}
+
+// send_raw_transaction
+func (c *DaemonRpcClient) SendRawTransaction(txAsHex string, doNotRelay bool, doSanityChecks bool) (*SendRawTransactionResponse, error) {
+	return c.SendRawTransactionCtx(context.Background(), txAsHex, doNotRelay, doSanityChecks)
+}
+
+func (c *DaemonRpcClient) SendRawTransactionCtx(ctx context.Context, txAsHex string, doNotRelay bool, doSanityChecks bool) (*SendRawTransactionResponse, error) {
+	reqBody := &SendRawTransactionParams{
+		TxAsHex:        txAsHex,
+		DoNotRelay:     doNotRelay,
+		DoSanityChecks: doSanityChecks,
+	}
+	req := &MoneroRpcRequest[SendRawTransactionParams]{"/send_raw_transaction", reqBody}
+
+	res, err := getResultFromDaemonRpc[SendRawTransactionResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// is_key_image_spent
+func (c *DaemonRpcClient) IsKeyImageSpent(keyImages []string) (*IsKeyImageSpentResponse, error) {
+	return c.IsKeyImageSpentCtx(context.Background(), keyImages)
+}
+
+func (c *DaemonRpcClient) IsKeyImageSpentCtx(ctx context.Context, keyImages []string) (*IsKeyImageSpentResponse, error) {
+	req := &MoneroRpcRequest[IsKeyImageSpentParams]{"/is_key_image_spent", &IsKeyImageSpentParams{keyImages}}
+
+	res, err := getResultFromDaemonRpc[IsKeyImageSpentResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_alt_blocks_hashes
+func (c *DaemonRpcClient) GetAltBlocksHashes() (*GetAltBlocksHashesResponse, error) {
+	return c.GetAltBlocksHashesCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetAltBlocksHashesCtx(ctx context.Context) (*GetAltBlocksHashesResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/get_alt_blocks_hashes", nil}
+
+	res, err := getResultFromDaemonRpc[GetAltBlocksHashesResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_transaction_pool_hashes
+func (c *DaemonRpcClient) GetTransactionPoolHashes() (*GetTransactionPoolHashesResponse, error) {
+	return c.GetTransactionPoolHashesCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetTransactionPoolHashesCtx(ctx context.Context) (*GetTransactionPoolHashesResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/get_transaction_pool_hashes", nil}
+
+	res, err := getResultFromDaemonRpc[GetTransactionPoolHashesResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_transaction_pool_stats
+func (c *DaemonRpcClient) GetTransactionPoolStats() (*GetTransactionPoolStatsResponse, error) {
+	return c.GetTransactionPoolStatsCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetTransactionPoolStatsCtx(ctx context.Context) (*GetTransactionPoolStatsResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/get_transaction_pool_stats", nil}
+
+	res, err := getResultFromDaemonRpc[GetTransactionPoolStatsResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_limit
+func (c *DaemonRpcClient) GetLimit() (*GetLimitResponse, error) {
+	return c.GetLimitCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetLimitCtx(ctx context.Context) (*GetLimitResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/get_limit", nil}
+
+	res, err := getResultFromDaemonRpc[GetLimitResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// set_limit
+func (c *DaemonRpcClient) SetLimit(limitDown int64, limitUp int64) (*SetLimitResponse, error) {
+	return c.SetLimitCtx(context.Background(), limitDown, limitUp)
+}
+
+func (c *DaemonRpcClient) SetLimitCtx(ctx context.Context, limitDown int64, limitUp int64) (*SetLimitResponse, error) {
+	req := &MoneroRpcRequest[SetLimitParams]{"/set_limit", &SetLimitParams{limitDown, limitUp}}
+
+	res, err := getResultFromDaemonRpc[SetLimitResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_peer_list
+func (c *DaemonRpcClient) GetPeerList(publicOnly bool, includeBlocked bool) (*GetPeerListResponse, error) {
+	return c.GetPeerListCtx(context.Background(), publicOnly, includeBlocked)
+}
+
+func (c *DaemonRpcClient) GetPeerListCtx(ctx context.Context, publicOnly bool, includeBlocked bool) (*GetPeerListResponse, error) {
+	req := &MoneroRpcRequest[GetPeerListParams]{"/get_peer_list", &GetPeerListParams{publicOnly, includeBlocked}}
+
+	res, err := getResultFromDaemonRpc[GetPeerListResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_public_nodes
+func (c *DaemonRpcClient) GetPublicNodes(gray bool, white bool, includeBlocked bool) (*GetPublicNodesResponse, error) {
+	return c.GetPublicNodesCtx(context.Background(), gray, white, includeBlocked)
+}
+
+func (c *DaemonRpcClient) GetPublicNodesCtx(ctx context.Context, gray bool, white bool, includeBlocked bool) (*GetPublicNodesResponse, error) {
+	req := &MoneroRpcRequest[GetPublicNodesParams]{"/get_public_nodes", &GetPublicNodesParams{gray, white, includeBlocked}}
+
+	res, err := getResultFromDaemonRpc[GetPublicNodesResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// out_peers
+func (c *DaemonRpcClient) OutPeers(outPeers uint32) (*OutPeersResponse, error) {
+	return c.OutPeersCtx(context.Background(), outPeers)
+}
+
+func (c *DaemonRpcClient) OutPeersCtx(ctx context.Context, outPeers uint32) (*OutPeersResponse, error) {
+	req := &MoneroRpcRequest[OutPeersParams]{"/out_peers", &OutPeersParams{true, outPeers}}
+
+	res, err := getResultFromDaemonRpc[OutPeersResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// in_peers
+func (c *DaemonRpcClient) InPeers(inPeers uint32) (*InPeersResponse, error) {
+	return c.InPeersCtx(context.Background(), inPeers)
+}
+
+func (c *DaemonRpcClient) InPeersCtx(ctx context.Context, inPeers uint32) (*InPeersResponse, error) {
+	req := &MoneroRpcRequest[InPeersParams]{"/in_peers", &InPeersParams{true, inPeers}}
+
+	res, err := getResultFromDaemonRpc[InPeersResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// start_mining
+func (c *DaemonRpcClient) StartMining(minerAddress string, threadsCount uint64, doBackgroundMining bool, ignoreBattery bool) (*StartMiningResponse, error) {
+	return c.StartMiningCtx(context.Background(), minerAddress, threadsCount, doBackgroundMining, ignoreBattery)
+}
+
+func (c *DaemonRpcClient) StartMiningCtx(ctx context.Context, minerAddress string, threadsCount uint64, doBackgroundMining bool, ignoreBattery bool) (*StartMiningResponse, error) {
+	req := &MoneroRpcRequest[StartMiningParams]{"/start_mining", &StartMiningParams{doBackgroundMining, ignoreBattery, minerAddress, threadsCount}}
+
+	res, err := getResultFromDaemonRpc[StartMiningResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// stop_mining
+func (c *DaemonRpcClient) StopMining() (*StopMiningResponse, error) {
+	return c.StopMiningCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) StopMiningCtx(ctx context.Context) (*StopMiningResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/stop_mining", nil}
+
+	res, err := getResultFromDaemonRpc[StopMiningResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// mining_status
+func (c *DaemonRpcClient) MiningStatus() (*MiningStatusResponse, error) {
+	return c.MiningStatusCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) MiningStatusCtx(ctx context.Context) (*MiningStatusResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/mining_status", nil}
+
+	res, err := getResultFromDaemonRpc[MiningStatusResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// save_bc
+func (c *DaemonRpcClient) SaveBc() (*SaveBcResponse, error) {
+	return c.SaveBcCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) SaveBcCtx(ctx context.Context) (*SaveBcResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/save_bc", nil}
+
+	res, err := getResultFromDaemonRpc[SaveBcResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_net_stats
+func (c *DaemonRpcClient) GetNetStats() (*GetNetStatsResponse, error) {
+	return c.GetNetStatsCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) GetNetStatsCtx(ctx context.Context) (*GetNetStatsResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/get_net_stats", nil}
+
+	res, err := getResultFromDaemonRpc[GetNetStatsResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// pop_blocks
+func (c *DaemonRpcClient) PopBlocks(nBlocks uint64) (*PopBlocksResponse, error) {
+	return c.PopBlocksCtx(context.Background(), nBlocks)
+}
+
+func (c *DaemonRpcClient) PopBlocksCtx(ctx context.Context, nBlocks uint64) (*PopBlocksResponse, error) {
+	req := &MoneroRpcRequest[PopBlocksParams]{"/pop_blocks", &PopBlocksParams{nBlocks}}
+
+	res, err := getResultFromDaemonRpc[PopBlocksResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// update
+func (c *DaemonRpcClient) Update(command string, path string) (*UpdateResponse, error) {
+	return c.UpdateCtx(context.Background(), command, path)
+}
+
+func (c *DaemonRpcClient) UpdateCtx(ctx context.Context, command string, path string) (*UpdateResponse, error) {
+	req := &MoneroRpcRequest[UpdateParams]{"/update", &UpdateParams{command, path}}
+
+	res, err := getResultFromDaemonRpc[UpdateResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// stop_daemon
+func (c *DaemonRpcClient) StopDaemon() (*StopDaemonResponse, error) {
+	return c.StopDaemonCtx(context.Background())
+}
+
+func (c *DaemonRpcClient) StopDaemonCtx(ctx context.Context) (*StopDaemonResponse, error) {
+	req := &MoneroRpcRequest[EmptyMoneroRpcParams]{"/stop_daemon", nil}
+
+	res, err := getResultFromDaemonRpc[StopDaemonResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Code != 0 {
+		return nil, &res.Error
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+/**
+	BINARY RPC METHODS
+**/
+
+// get_blocks.bin
+func (c *DaemonRpcClient) GetBlocksBin(blockIds []string, startHeight uint64, prune bool, noMinerTx bool) (*GetBlocksBinResponse, error) {
+	return c.GetBlocksBinCtx(context.Background(), blockIds, startHeight, prune, noMinerTx)
+}
+
+func (c *DaemonRpcClient) GetBlocksBinCtx(ctx context.Context, blockIds []string, startHeight uint64, prune bool, noMinerTx bool) (*GetBlocksBinResponse, error) {
+	reqBody := &GetBlocksBinParams{
+		BlockIds:    blockIds,
+		StartHeight: startHeight,
+		Prune:       prune,
+		NoMinerTx:   noMinerTx,
+	}
+	req := &MoneroRpcRequest[GetBlocksBinParams]{"/get_blocks.bin", reqBody}
+
+	res, err := getResultFromDaemonRpcBin[GetBlocksBinResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_blocks_by_height.bin
+func (c *DaemonRpcClient) GetBlocksByHeightBin(heights []uint64) (*GetBlocksByHeightBinResponse, error) {
+	return c.GetBlocksByHeightBinCtx(context.Background(), heights)
+}
+
+func (c *DaemonRpcClient) GetBlocksByHeightBinCtx(ctx context.Context, heights []uint64) (*GetBlocksByHeightBinResponse, error) {
+	req := &MoneroRpcRequest[GetBlocksByHeightBinParams]{"/get_blocks_by_height.bin", &GetBlocksByHeightBinParams{Heights: heights}}
+
+	res, err := getResultFromDaemonRpcBin[GetBlocksByHeightBinResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_hashes.bin
+func (c *DaemonRpcClient) GetHashesBin(blockIds []string, startHeight uint64) (*GetHashesBinResponse, error) {
+	return c.GetHashesBinCtx(context.Background(), blockIds, startHeight)
+}
+
+func (c *DaemonRpcClient) GetHashesBinCtx(ctx context.Context, blockIds []string, startHeight uint64) (*GetHashesBinResponse, error) {
+	req := &MoneroRpcRequest[GetHashesBinParams]{"/get_hashes.bin", &GetHashesBinParams{BlockIds: blockIds, StartHeight: startHeight}}
+
+	res, err := getResultFromDaemonRpcBin[GetHashesBinResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_o_indexes.bin
+func (c *DaemonRpcClient) GetOIndexesBin(txId string) (*GetOIndexesBinResponse, error) {
+	return c.GetOIndexesBinCtx(context.Background(), txId)
+}
+
+func (c *DaemonRpcClient) GetOIndexesBinCtx(ctx context.Context, txId string) (*GetOIndexesBinResponse, error) {
+	req := &MoneroRpcRequest[GetOIndexesBinParams]{"/get_o_indexes.bin", &GetOIndexesBinParams{TxId: txId}}
+
+	res, err := getResultFromDaemonRpcBin[GetOIndexesBinResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// get_outs.bin
+func (c *DaemonRpcClient) GetOutsBin(outputs []GetOutputsOut, getTxId bool) (*GetOutsBinResponse, error) {
+	return c.GetOutsBinCtx(context.Background(), outputs, getTxId)
+}
+
+func (c *DaemonRpcClient) GetOutsBinCtx(ctx context.Context, outputs []GetOutputsOut, getTxId bool) (*GetOutsBinResponse, error) {
+	req := &MoneroRpcRequest[GetOutsBinParams]{"/get_outs.bin", &GetOutsBinParams{Outputs: outputs, GetTxId: getTxId}}
+
+	res, err := getResultFromDaemonRpcBin[GetOutsBinResponse](ctx, c, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatusHelper(req.Endpoint, res.Status); err != nil {
+		return nil, err
 	}
 
 	return res, nil
@@ -0,0 +1,440 @@
+package epee
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+type decoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func (d *decoder) errorHelper(err error) error {
+	return &ParseError{Offset: d.pos, Err: err}
+}
+
+func (d *decoder) bytes(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, d.errorHelper(ErrUnexpectedEOF)
+	}
+
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+
+	return b, nil
+}
+
+func (d *decoder) byte() (byte, error) {
+	b, err := d.bytes(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return b[0], nil
+}
+
+// The two lowest bits of the first byte are the size of the varint: 1, 2, 4 or 8 bytes.
+// The rest is the little endian value shifted left by 2.
+func (d *decoder) varint() (uint64, error) {
+	if d.pos >= len(d.data) {
+		return 0, d.errorHelper(ErrUnexpectedEOF)
+	}
+
+	size := 1 << (d.data[d.pos] & 0x03)
+	b, err := d.bytes(size)
+	if err != nil {
+		return 0, err
+	}
+
+	var buf [8]byte
+	copy(buf[:], b)
+
+	return binary.LittleEndian.Uint64(buf[:]) >> 2, nil
+}
+
+// Reads a varint that is used as an element count, each element takes at least elemSize bytes
+func (d *decoder) count(elemSize int) (int, error) {
+	start := d.pos
+
+	n, err := d.varint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)-d.pos)/uint64(elemSize) {
+		return 0, &ParseError{Offset: start, Err: fmt.Errorf("invalid element count: %d", n)}
+	}
+
+	return int(n), nil
+}
+
+func (d *decoder) enterHelper() error {
+	d.depth++
+	if d.depth > MAX_DEPTH {
+		return d.errorHelper(ErrTooDeep)
+	}
+
+	return nil
+}
+
+func (d *decoder) section() (Section, error) {
+	if err := d.enterHelper(); err != nil {
+		return nil, err
+	}
+	defer func() { d.depth-- }()
+
+	// the name length, at least one name byte and the type
+	n, err := d.count(3)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(Section, n)
+	for i := 0; i < n; i++ {
+		nameLen, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		name, err := d.bytes(int(nameLen))
+		if err != nil {
+			return nil, err
+		}
+
+		typ, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		if res[string(name)], err = d.value(typ); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (d *decoder) array(elemType byte) ([]any, error) {
+	if err := d.enterHelper(); err != nil {
+		return nil, err
+	}
+	defer func() { d.depth-- }()
+
+	if elemType == 0 || elemType > SERIALIZE_TYPE_ARRAY {
+		// the type has just been read
+		return nil, &ParseError{Offset: d.pos - 1, Err: fmt.Errorf("%w: %#02x", ErrUnsupportedType, elemType|SERIALIZE_FLAG_ARRAY)}
+	}
+
+	n, err := d.count(1)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]any, n)
+	for i := range res {
+		typ := elemType
+		if elemType == SERIALIZE_TYPE_ARRAY {
+			// each of the nested arrays has its own type
+			if typ, err = d.byte(); err != nil {
+				return nil, err
+			}
+			if typ&SERIALIZE_FLAG_ARRAY == 0 {
+				return nil, &ParseError{Offset: d.pos - 1, Err: fmt.Errorf("%w: %#02x in an array of arrays", ErrUnsupportedType, typ)}
+			}
+		}
+
+		if res[i], err = d.value(typ); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (d *decoder) value(typ byte) (any, error) {
+	if typ&SERIALIZE_FLAG_ARRAY != 0 {
+		return d.array(typ &^ SERIALIZE_FLAG_ARRAY)
+	}
+
+	var size int
+	switch typ {
+	case SERIALIZE_TYPE_INT64, SERIALIZE_TYPE_UINT64, SERIALIZE_TYPE_DOUBLE:
+		size = 8
+	case SERIALIZE_TYPE_INT32, SERIALIZE_TYPE_UINT32:
+		size = 4
+	case SERIALIZE_TYPE_INT16, SERIALIZE_TYPE_UINT16:
+		size = 2
+	case SERIALIZE_TYPE_INT8, SERIALIZE_TYPE_UINT8, SERIALIZE_TYPE_BOOL:
+		size = 1
+	case SERIALIZE_TYPE_STRING:
+		n, err := d.count(1)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.bytes(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	case SERIALIZE_TYPE_OBJECT:
+		return d.section()
+	case SERIALIZE_TYPE_ARRAY:
+		typ, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		if typ&SERIALIZE_FLAG_ARRAY == 0 {
+			return nil, &ParseError{Offset: d.pos - 1, Err: fmt.Errorf("%w: %#02x", ErrUnsupportedType, typ)}
+		}
+		return d.array(typ &^ SERIALIZE_FLAG_ARRAY)
+	default:
+		// the type has just been read
+		return nil, &ParseError{Offset: d.pos - 1, Err: fmt.Errorf("%w: %#02x", ErrUnsupportedType, typ)}
+	}
+
+	b, err := d.bytes(size)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ {
+	case SERIALIZE_TYPE_INT64:
+		return int64(binary.LittleEndian.Uint64(b)), nil
+	case SERIALIZE_TYPE_INT32:
+		return int32(binary.LittleEndian.Uint32(b)), nil
+	case SERIALIZE_TYPE_INT16:
+		return int16(binary.LittleEndian.Uint16(b)), nil
+	case SERIALIZE_TYPE_INT8:
+		return int8(b[0]), nil
+	case SERIALIZE_TYPE_UINT64:
+		return binary.LittleEndian.Uint64(b), nil
+	case SERIALIZE_TYPE_UINT32:
+		return binary.LittleEndian.Uint32(b), nil
+	case SERIALIZE_TYPE_UINT16:
+		return binary.LittleEndian.Uint16(b), nil
+	case SERIALIZE_TYPE_UINT8:
+		return b[0], nil
+	case SERIALIZE_TYPE_DOUBLE:
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	default:
+		return b[0] != 0, nil
+	}
+}
+
+// Decodes the portable storage data into a Section
+func Parse(data []byte) (Section, error) {
+	d := &decoder{data: data}
+
+	header, err := d.bytes(PORTABLE_STORAGE_HEADER_SIZE)
+	if err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(header[0:4]) != PORTABLE_STORAGE_SIGNATUREA ||
+		binary.LittleEndian.Uint32(header[4:8]) != PORTABLE_STORAGE_SIGNATUREB ||
+		header[8] != PORTABLE_STORAGE_FORMAT_VER {
+		return nil, &ParseError{Offset: 0, Err: ErrInvalidSignature}
+	}
+
+	res, err := d.section()
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, d.errorHelper(ErrTrailingData)
+	}
+
+	return res, nil
+}
+
+// Decodes the portable storage data into the value pointed to by v.
+// The unknown fields are ignored and the missing ones are left untouched.
+func Unmarshal(data []byte, v any) error {
+	s, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	return UnmarshalValue(s, v)
+}
+
+// Stores a decoded value (see Section) into the value pointed to by v.
+// It is meant to be used by the Unmarshaler implementations.
+func UnmarshalValue(src any, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("epee: non-nil pointer expected, got %T", v)
+	}
+
+	return assignHelper(src, rv.Elem(), false)
+}
+
+func mismatchHelper(src any, dst reflect.Value) error {
+	return fmt.Errorf("%w: can't store %T into %v", ErrTypeMismatch, src, dst.Type())
+}
+
+func assignHelper(src any, dst reflect.Value, isHex bool) error {
+	if dst.Kind() != reflect.Pointer && dst.CanAddr() && dst.Addr().Type().Implements(unmarshalerType) {
+		return dst.Addr().Interface().(Unmarshaler).UnmarshalEpee(src)
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assignHelper(src, dst.Elem(), isHex)
+
+	case reflect.Interface:
+		if dst.NumMethod() != 0 {
+			return mismatchHelper(src, dst)
+		}
+		dst.Set(reflect.ValueOf(src))
+		return nil
+
+	case reflect.Bool:
+		b, ok := src.(bool)
+		if !ok {
+			return mismatchHelper(src, dst)
+		}
+		dst.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		rv := reflect.ValueOf(src)
+		var n int64
+		switch rv.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = rv.Int()
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if rv.Uint() > math.MaxInt64 {
+				return mismatchHelper(src, dst)
+			}
+			n = int64(rv.Uint())
+		default:
+			return mismatchHelper(src, dst)
+		}
+		if dst.OverflowInt(n) {
+			return fmt.Errorf("%w: %d overflows %v", ErrTypeMismatch, n, dst.Type())
+		}
+		dst.SetInt(n)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		rv := reflect.ValueOf(src)
+		var n uint64
+		switch rv.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rv.Int() < 0 {
+				return fmt.Errorf("%w: %d overflows %v", ErrTypeMismatch, rv.Int(), dst.Type())
+			}
+			n = uint64(rv.Int())
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = rv.Uint()
+		default:
+			return mismatchHelper(src, dst)
+		}
+		if dst.OverflowUint(n) {
+			return fmt.Errorf("%w: %d overflows %v", ErrTypeMismatch, n, dst.Type())
+		}
+		dst.SetUint(n)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := src.(float64)
+		if !ok {
+			return mismatchHelper(src, dst)
+		}
+		dst.SetFloat(f)
+		return nil
+
+	case reflect.String:
+		b, ok := src.([]byte)
+		if !ok {
+			return mismatchHelper(src, dst)
+		}
+		if isHex {
+			dst.SetString(hex.EncodeToString(b))
+		} else {
+			dst.SetString(string(b))
+		}
+		return nil
+
+	case reflect.Slice:
+		return assignSliceHelper(src, dst, isHex)
+
+	case reflect.Struct:
+		s, ok := src.(Section)
+		if !ok {
+			return mismatchHelper(src, dst)
+		}
+		for _, f := range fieldsHelper(dst.Type()) {
+			v, ok := s[f.name]
+			if !ok {
+				continue
+			}
+			if err := assignHelper(v, dst.FieldByIndex(f.index), f.hex); err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		s, ok := src.(Section)
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return mismatchHelper(src, dst)
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(dst.Type(), len(s)))
+		}
+		for k, v := range s {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := assignHelper(v, elem, false); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			dst.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+		}
+		return nil
+	}
+
+	return mismatchHelper(src, dst)
+}
+
+func assignSliceHelper(src any, dst reflect.Value, isHex bool) error {
+	elemKind := dst.Type().Elem().Kind()
+
+	if b, ok := src.([]byte); ok {
+		switch {
+		case elemKind == reflect.Uint8:
+			dst.SetBytes(append([]byte{}, b...))
+			return nil
+
+		case elemKind == reflect.String && isHex:
+			if len(b)%HEX_POD_SIZE != 0 {
+				return fmt.Errorf("%w: the blob size %d isn't a multiple of %d", ErrTypeMismatch, len(b), HEX_POD_SIZE)
+			}
+			res := reflect.MakeSlice(dst.Type(), len(b)/HEX_POD_SIZE, len(b)/HEX_POD_SIZE)
+			for i := 0; i < res.Len(); i++ {
+				res.Index(i).SetString(hex.EncodeToString(b[i*HEX_POD_SIZE : (i+1)*HEX_POD_SIZE]))
+			}
+			dst.Set(res)
+			return nil
+		}
+	}
+
+	arr, ok := src.([]any)
+	if !ok {
+		return mismatchHelper(src, dst)
+	}
+
+	res := reflect.MakeSlice(dst.Type(), len(arr), len(arr))
+	for i, v := range arr {
+		if err := assignHelper(v, res.Index(i), isHex); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	dst.Set(res)
+
+	return nil
+}
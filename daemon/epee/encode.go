@@ -0,0 +1,331 @@
+package epee
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// The biggest value that fits into a varint
+const MAX_VARINT uint64 = math.MaxUint64 >> 2
+
+func appendVarintHelper(buf []byte, n uint64) ([]byte, error) {
+	switch {
+	case n <= 0x3f:
+		return append(buf, byte(n<<2)), nil
+	case n <= 0x3fff:
+		return binary.LittleEndian.AppendUint16(buf, uint16(n<<2)|1), nil
+	case n <= 0x3fffffff:
+		return binary.LittleEndian.AppendUint32(buf, uint32(n<<2)|2), nil
+	case n <= MAX_VARINT:
+		return binary.LittleEndian.AppendUint64(buf, n<<2|3), nil
+	default:
+		return nil, fmt.Errorf("epee: %d doesn't fit into a varint", n)
+	}
+}
+
+func appendStringHelper(buf []byte, s []byte) ([]byte, error) {
+	buf, err := appendVarintHelper(buf, uint64(len(s)))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buf, s...), nil
+}
+
+type encoder struct {
+	depth int
+}
+
+// Resolves the pointers, the interfaces and the Marshaler implementations.
+// The returned value is invalid if there is nothing to encode.
+func (e *encoder) resolveHelper(v reflect.Value) (reflect.Value, error) {
+	for v.IsValid() {
+		if v.Type().Implements(marshalerType) {
+			if v.Kind() == reflect.Pointer && v.IsNil() {
+				return reflect.Value{}, nil
+			}
+			res, err := v.Interface().(Marshaler).MarshalEpee()
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v = reflect.ValueOf(res)
+			continue
+		}
+		if v.Kind() != reflect.Pointer && v.CanAddr() && v.Addr().Type().Implements(marshalerType) {
+			v = v.Addr()
+			continue
+		}
+
+		if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			break
+		}
+		if v.IsNil() {
+			return reflect.Value{}, nil
+		}
+		v = v.Elem()
+	}
+
+	return v, nil
+}
+
+// Returns the serialize type of the value and its encoding without the type
+func (e *encoder) value(v reflect.Value, isHex bool) (byte, []byte, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int64:
+		return SERIALIZE_TYPE_INT64, binary.LittleEndian.AppendUint64(nil, uint64(v.Int())), nil
+	case reflect.Int32:
+		return SERIALIZE_TYPE_INT32, binary.LittleEndian.AppendUint32(nil, uint32(v.Int())), nil
+	case reflect.Int16:
+		return SERIALIZE_TYPE_INT16, binary.LittleEndian.AppendUint16(nil, uint16(v.Int())), nil
+	case reflect.Int8:
+		return SERIALIZE_TYPE_INT8, []byte{byte(v.Int())}, nil
+	case reflect.Uint, reflect.Uint64:
+		return SERIALIZE_TYPE_UINT64, binary.LittleEndian.AppendUint64(nil, v.Uint()), nil
+	case reflect.Uint32:
+		return SERIALIZE_TYPE_UINT32, binary.LittleEndian.AppendUint32(nil, uint32(v.Uint())), nil
+	case reflect.Uint16:
+		return SERIALIZE_TYPE_UINT16, binary.LittleEndian.AppendUint16(nil, uint16(v.Uint())), nil
+	case reflect.Uint8:
+		return SERIALIZE_TYPE_UINT8, []byte{byte(v.Uint())}, nil
+	case reflect.Float32, reflect.Float64:
+		return SERIALIZE_TYPE_DOUBLE, binary.LittleEndian.AppendUint64(nil, math.Float64bits(v.Float())), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return SERIALIZE_TYPE_BOOL, []byte{1}, nil
+		}
+		return SERIALIZE_TYPE_BOOL, []byte{0}, nil
+
+	case reflect.String:
+		s := []byte(v.String())
+		if isHex {
+			var err error
+			if s, err = hex.DecodeString(v.String()); err != nil {
+				return 0, nil, err
+			}
+		}
+		b, err := appendStringHelper(nil, s)
+		return SERIALIZE_TYPE_STRING, b, err
+
+	case reflect.Slice, reflect.Array:
+		return e.sliceHelper(v, isHex)
+
+	case reflect.Struct, reflect.Map:
+		b, err := e.section(v)
+		return SERIALIZE_TYPE_OBJECT, b, err
+	}
+
+	return 0, nil, fmt.Errorf("%w: %v", ErrUnsupportedType, v.Type())
+}
+
+func (e *encoder) sliceHelper(v reflect.Value, isHex bool) (byte, []byte, error) {
+	elemType := v.Type().Elem()
+
+	if elemType.Kind() == reflect.Uint8 {
+		s := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(s), v)
+		b, err := appendStringHelper(nil, s)
+		return SERIALIZE_TYPE_STRING, b, err
+	}
+
+	if elemType.Kind() == reflect.String && isHex {
+		s := make([]byte, 0, v.Len()*HEX_POD_SIZE)
+		for i := 0; i < v.Len(); i++ {
+			pod, err := hex.DecodeString(v.Index(i).String())
+			if err != nil {
+				return 0, nil, err
+			}
+			if len(pod) != HEX_POD_SIZE {
+				return 0, nil, fmt.Errorf("epee: %d bytes long value, %d expected", len(pod), HEX_POD_SIZE)
+			}
+			s = append(s, pod...)
+		}
+		b, err := appendStringHelper(nil, s)
+		return SERIALIZE_TYPE_STRING, b, err
+	}
+
+	e.depth++
+	defer func() { e.depth-- }()
+	if e.depth > MAX_DEPTH {
+		return 0, nil, ErrTooDeep
+	}
+
+	var typ byte
+	var payloads [][]byte
+	for i := 0; i < v.Len(); i++ {
+		elem, err := e.resolveHelper(v.Index(i))
+		if err != nil {
+			return 0, nil, err
+		}
+		if !elem.IsValid() {
+			return 0, nil, fmt.Errorf("epee: nil array element %d", i)
+		}
+
+		t, b, err := e.value(elem, isHex)
+		if err != nil {
+			return 0, nil, err
+		}
+		if t&SERIALIZE_FLAG_ARRAY != 0 {
+			// the nested arrays keep their types
+			b = append([]byte{t}, b...)
+			t = SERIALIZE_TYPE_ARRAY
+		}
+		if i > 0 && t != typ {
+			return 0, nil, fmt.Errorf("%w: array elements of different types", ErrTypeMismatch)
+		}
+		typ = t
+		payloads = append(payloads, b)
+	}
+
+	if v.Len() == 0 {
+		typ = e.staticTypeHelper(elemType)
+	}
+
+	res, err := appendVarintHelper(nil, uint64(v.Len()))
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, b := range payloads {
+		res = append(res, b...)
+	}
+
+	return SERIALIZE_FLAG_ARRAY | typ, res, nil
+}
+
+// Returns the serialize type of the empty array elements, which only matters for the readers that check it
+func (e *encoder) staticTypeHelper(t reflect.Type) byte {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int64:
+		return SERIALIZE_TYPE_INT64
+	case reflect.Int32:
+		return SERIALIZE_TYPE_INT32
+	case reflect.Int16:
+		return SERIALIZE_TYPE_INT16
+	case reflect.Int8:
+		return SERIALIZE_TYPE_INT8
+	case reflect.Uint, reflect.Uint64:
+		return SERIALIZE_TYPE_UINT64
+	case reflect.Uint32:
+		return SERIALIZE_TYPE_UINT32
+	case reflect.Uint16:
+		return SERIALIZE_TYPE_UINT16
+	case reflect.Uint8:
+		return SERIALIZE_TYPE_UINT8
+	case reflect.Float32, reflect.Float64:
+		return SERIALIZE_TYPE_DOUBLE
+	case reflect.Bool:
+		return SERIALIZE_TYPE_BOOL
+	case reflect.String:
+		return SERIALIZE_TYPE_STRING
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return SERIALIZE_TYPE_STRING
+		}
+		return SERIALIZE_TYPE_ARRAY
+	default:
+		return SERIALIZE_TYPE_OBJECT
+	}
+}
+
+type entry struct {
+	name  string
+	value reflect.Value
+	hex   bool
+}
+
+func (e *encoder) section(v reflect.Value) ([]byte, error) {
+	e.depth++
+	defer func() { e.depth-- }()
+	if e.depth > MAX_DEPTH {
+		return nil, ErrTooDeep
+	}
+
+	var entries []entry
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range fieldsHelper(v.Type()) {
+			fv := v.FieldByIndex(f.index)
+			if f.omitempty && fv.IsZero() {
+				continue
+			}
+			entries = append(entries, entry{f.name, fv, f.hex})
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, v.Type())
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			entries = append(entries, entry{iter.Key().String(), iter.Value(), false})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedType, v.Type())
+	}
+
+	var body []byte
+	n := 0
+	for _, en := range entries {
+		if len(en.name) > math.MaxUint8 {
+			return nil, fmt.Errorf("epee: the field name %q is too long", en.name)
+		}
+
+		fv, err := e.resolveHelper(en.value)
+		if err != nil {
+			return nil, err
+		}
+		if !fv.IsValid() {
+			continue
+		}
+
+		typ, b, err := e.value(fv, en.hex)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", en.name, err)
+		}
+
+		body = append(body, byte(len(en.name)))
+		body = append(body, en.name...)
+		body = append(body, typ)
+		body = append(body, b...)
+		n++
+	}
+
+	res, err := appendVarintHelper(nil, uint64(n))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(res, body...), nil
+}
+
+// Encodes the value, which must be a struct, a map with string keys or a pointer to one of them, into the portable storage format.
+func Marshal(v any) ([]byte, error) {
+	e := &encoder{}
+
+	rv, err := e.resolveHelper(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("epee: can't marshal %T", v)
+	}
+
+	body, err := e.section(rv)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, 0, PORTABLE_STORAGE_HEADER_SIZE+len(body))
+	res = binary.LittleEndian.AppendUint32(res, PORTABLE_STORAGE_SIGNATUREA)
+	res = binary.LittleEndian.AppendUint32(res, PORTABLE_STORAGE_SIGNATUREB)
+	res = append(res, PORTABLE_STORAGE_FORMAT_VER)
+
+	return append(res, body...), nil
+}
@@ -0,0 +1,162 @@
+// Package epee implements the epee portable storage format, the binary encoding
+// used by monerod for its .bin RPC endpoints and the levin p2p protocol.
+package epee
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	PORTABLE_STORAGE_SIGNATUREA uint32 = 0x01011101
+	PORTABLE_STORAGE_SIGNATUREB uint32 = 0x01020101
+	PORTABLE_STORAGE_FORMAT_VER byte   = 1
+	// two signatures and the format version
+	PORTABLE_STORAGE_HEADER_SIZE int = 9
+
+	SERIALIZE_TYPE_INT64  byte = 1
+	SERIALIZE_TYPE_INT32  byte = 2
+	SERIALIZE_TYPE_INT16  byte = 3
+	SERIALIZE_TYPE_INT8   byte = 4
+	SERIALIZE_TYPE_UINT64 byte = 5
+	SERIALIZE_TYPE_UINT32 byte = 6
+	SERIALIZE_TYPE_UINT16 byte = 7
+	SERIALIZE_TYPE_UINT8  byte = 8
+	SERIALIZE_TYPE_DOUBLE byte = 9
+	SERIALIZE_TYPE_STRING byte = 10
+	SERIALIZE_TYPE_BOOL   byte = 11
+	SERIALIZE_TYPE_OBJECT byte = 12
+	SERIALIZE_TYPE_ARRAY  byte = 13
+	SERIALIZE_FLAG_ARRAY  byte = 0x80
+
+	// the same limit monerod uses for the nesting of objects and arrays
+	MAX_DEPTH int = 100
+	// the size of the values stored by the hex option of []string fields (hashes and keys)
+	HEX_POD_SIZE int = 32
+)
+
+var (
+	ErrUnexpectedEOF    = errors.New("unexpected end of data")
+	ErrInvalidSignature = errors.New("invalid portable storage signature")
+	ErrUnsupportedType  = errors.New("unsupported serialize type")
+	ErrTooDeep          = errors.New("maximum nesting depth exceeded")
+	ErrTrailingData     = errors.New("trailing data after the root section")
+	ErrTypeMismatch     = errors.New("type mismatch")
+)
+
+// ParseError is returned when the data is malformed.
+// Offset is the position of the byte the parsing failed at.
+type ParseError struct {
+	Offset int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid portable storage at byte %d: %v", e.Offset, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Section is a decoded object. The values are of the following types:
+// int64, int32, int16, int8, uint64, uint32, uint16, uint8, float64, bool, []byte (strings), Section and []any (arrays).
+type Section map[string]any
+
+// Marshaler is implemented by the types that need a custom representation.
+// MarshalEpee returns a value that is encoded in place of the receiver.
+type Marshaler interface {
+	MarshalEpee() (any, error)
+}
+
+// Unmarshaler is implemented by the types that need a custom decoding.
+// The value is one of the types listed in the Section description.
+type Unmarshaler interface {
+	UnmarshalEpee(v any) error
+}
+
+var (
+	marshalerType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+)
+
+// Struct fields are mapped by the epee tag: `epee:"name,opt1,opt2"`.
+// The options are:
+//
+//	omitempty - the field isn't encoded if it has the zero value;
+//	hex       - a string field holds the hex form of a binary value (KV_SERIALIZE_VAL_POD_AS_BLOB),
+//	            a []string field is stored as a single string of the concatenated values (KV_SERIALIZE_CONTAINER_POD_AS_BLOB),
+//	            each value must be HEX_POD_SIZE bytes long.
+//
+// The fields without the tag use the field name, the ones tagged with "-" are skipped.
+// The untagged embedded structs are flattened.
+type fieldInfo struct {
+	index     []int
+	name      string
+	omitempty bool
+	hex       bool
+}
+
+var fieldsCache sync.Map
+
+func parseTagHelper(f reflect.StructField) (fieldInfo, bool) {
+	tag, ok := f.Tag.Lookup("epee")
+	if tag == "-" {
+		return fieldInfo{}, false
+	}
+
+	info := fieldInfo{index: f.Index, name: f.Name}
+	if !ok {
+		return info, true
+	}
+
+	opts := strings.Split(tag, ",")
+	if opts[0] != "" {
+		info.name = opts[0]
+	}
+	for _, opt := range opts[1:] {
+		switch opt {
+		case "omitempty":
+			info.omitempty = true
+		case "hex":
+			info.hex = true
+		}
+	}
+
+	return info, true
+}
+
+func appendFieldsHelper(res []fieldInfo, t reflect.Type, index []int) []fieldInfo {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		f.Index = append(append([]int(nil), index...), i)
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("epee") == "" {
+			res = appendFieldsHelper(res, f.Type, f.Index)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if info, ok := parseTagHelper(f); ok {
+			res = append(res, info)
+		}
+	}
+
+	return res
+}
+
+func fieldsHelper(t reflect.Type) []fieldInfo {
+	if v, ok := fieldsCache.Load(t); ok {
+		return v.([]fieldInfo)
+	}
+
+	res := appendFieldsHelper(nil, t, nil)
+	fieldsCache.Store(t, res)
+
+	return res
+}
@@ -0,0 +1,151 @@
+package daemon
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/chekist32/go-monero/utils"
+)
+
+// The error codes of the daemon (CORE_RPC_ERROR_CODE_*) and the JSON RPC 2.0 ones
+const (
+	RPC_ERROR_CODE_WRONG_PARAM           int32 = -1
+	RPC_ERROR_CODE_TOO_BIG_HEIGHT        int32 = -2
+	RPC_ERROR_CODE_TOO_BIG_RESERVE_SIZE  int32 = -3
+	RPC_ERROR_CODE_WRONG_WALLET_ADDRESS  int32 = -4
+	RPC_ERROR_CODE_INTERNAL_ERROR        int32 = -5
+	RPC_ERROR_CODE_WRONG_BLOCKBLOB       int32 = -6
+	RPC_ERROR_CODE_BLOCK_NOT_ACCEPTED    int32 = -7
+	RPC_ERROR_CODE_CORE_BUSY             int32 = -9
+	RPC_ERROR_CODE_WRONG_BLOCKBLOB_SIZE  int32 = -10
+	RPC_ERROR_CODE_UNSUPPORTED_RPC       int32 = -11
+	RPC_ERROR_CODE_MINING_TO_SUBADDRESS  int32 = -12
+	RPC_ERROR_CODE_REGTEST_REQUIRED      int32 = -13
+	RPC_ERROR_CODE_PAYMENT_REQUIRED      int32 = -14
+	RPC_ERROR_CODE_INVALID_CLIENT        int32 = -15
+	RPC_ERROR_CODE_PAYMENT_TOO_LOW       int32 = -16
+	RPC_ERROR_CODE_DUPLICATE_PAYMENT     int32 = -17
+	RPC_ERROR_CODE_STALE_PAYMENT         int32 = -18
+	RPC_ERROR_CODE_RESTRICTED            int32 = -19
+	RPC_ERROR_CODE_UNSUPPORTED_BOOTSTRAP int32 = -20
+	RPC_ERROR_CODE_PAYMENTS_NOT_ENABLED  int32 = -21
+
+	RPC_ERROR_CODE_PARSE_ERROR      int32 = -32700
+	RPC_ERROR_CODE_INVALID_REQUEST  int32 = -32600
+	RPC_ERROR_CODE_METHOD_NOT_FOUND int32 = -32601
+	RPC_ERROR_CODE_INVALID_PARAMS   int32 = -32602
+)
+
+// The sentinels the MoneroRpcError, RpcStatusError and utils.HttpStatusError returned by the client can be matched against with errors.Is.
+// The details (the code, the message, the method, the HTTP status) are available through errors.As.
+var (
+	ErrWrongParam           = errors.New("wrong parameter")
+	ErrTooBigHeight         = errors.New("the height is bigger than the chain height")
+	ErrTooBigReserveSize    = errors.New("the reserve size is too big")
+	ErrWrongWalletAddress   = errors.New("wrong wallet address")
+	ErrInternalError        = errors.New("internal daemon error")
+	ErrWrongBlockblob       = errors.New("wrong block blob")
+	ErrBlockNotAccepted     = errors.New("the block isn't accepted")
+	ErrWrongBlockblobSize   = errors.New("wrong block blob size")
+	ErrUnsupportedRpc       = errors.New("the rpc method isn't supported")
+	ErrMiningToSubaddress   = errors.New("mining to a subaddress isn't supported")
+	ErrRegtestRequired      = errors.New("the method requires regtest")
+	ErrInvalidClient        = errors.New("invalid rpc payment client")
+	ErrPaymentTooLow        = errors.New("the rpc payment is too low")
+	ErrDuplicatePayment     = errors.New("duplicate rpc payment")
+	ErrStalePayment         = errors.New("stale rpc payment")
+	ErrRestricted           = errors.New("the method is restricted")
+	ErrUnsupportedBootstrap = errors.New("the method isn't supported by the bootstrap daemon")
+	ErrPaymentsNotEnabled   = errors.New("rpc payments aren't enabled")
+	ErrParseError           = errors.New("the request can't be parsed")
+	ErrInvalidRequest       = errors.New("invalid request")
+	ErrMethodNotFound       = errors.New("method not found")
+	ErrInvalidParams        = errors.New("invalid params")
+
+	// Matches both the CORE_BUSY code and the BUSY status
+	ErrDaemonBusy = errors.New("the daemon is busy")
+	// Matches both the PAYMENT_REQUIRED code and the PAYMENT REQUIRED status
+	ErrPaymentRequired = errors.New("rpc payment required")
+	ErrNotMining       = errors.New("the daemon isn't mining")
+	// Matches any status other than RPC_STATUS_OK
+	ErrStatusNotOk = errors.New("the status isn't OK")
+	// Matches TOO_BIG_HEIGHT and the internal errors the daemon returns for unknown block hashes
+	ErrBlockNotFound = errors.New("block not found")
+
+	// Match the 401 and 403 HTTP statuses
+	ErrUnauthorized = utils.ErrUnauthorized
+	ErrForbidden    = utils.ErrForbidden
+)
+
+var rpcErrorCodes = map[int32]error{
+	RPC_ERROR_CODE_WRONG_PARAM:           ErrWrongParam,
+	RPC_ERROR_CODE_TOO_BIG_HEIGHT:        ErrTooBigHeight,
+	RPC_ERROR_CODE_TOO_BIG_RESERVE_SIZE:  ErrTooBigReserveSize,
+	RPC_ERROR_CODE_WRONG_WALLET_ADDRESS:  ErrWrongWalletAddress,
+	RPC_ERROR_CODE_INTERNAL_ERROR:        ErrInternalError,
+	RPC_ERROR_CODE_WRONG_BLOCKBLOB:       ErrWrongBlockblob,
+	RPC_ERROR_CODE_BLOCK_NOT_ACCEPTED:    ErrBlockNotAccepted,
+	RPC_ERROR_CODE_CORE_BUSY:             ErrDaemonBusy,
+	RPC_ERROR_CODE_WRONG_BLOCKBLOB_SIZE:  ErrWrongBlockblobSize,
+	RPC_ERROR_CODE_UNSUPPORTED_RPC:       ErrUnsupportedRpc,
+	RPC_ERROR_CODE_MINING_TO_SUBADDRESS:  ErrMiningToSubaddress,
+	RPC_ERROR_CODE_REGTEST_REQUIRED:      ErrRegtestRequired,
+	RPC_ERROR_CODE_PAYMENT_REQUIRED:      ErrPaymentRequired,
+	RPC_ERROR_CODE_INVALID_CLIENT:        ErrInvalidClient,
+	RPC_ERROR_CODE_PAYMENT_TOO_LOW:       ErrPaymentTooLow,
+	RPC_ERROR_CODE_DUPLICATE_PAYMENT:     ErrDuplicatePayment,
+	RPC_ERROR_CODE_STALE_PAYMENT:         ErrStalePayment,
+	RPC_ERROR_CODE_RESTRICTED:            ErrRestricted,
+	RPC_ERROR_CODE_UNSUPPORTED_BOOTSTRAP: ErrUnsupportedBootstrap,
+	RPC_ERROR_CODE_PAYMENTS_NOT_ENABLED:  ErrPaymentsNotEnabled,
+	RPC_ERROR_CODE_PARSE_ERROR:           ErrParseError,
+	RPC_ERROR_CODE_INVALID_REQUEST:       ErrInvalidRequest,
+	RPC_ERROR_CODE_METHOD_NOT_FOUND:      ErrMethodNotFound,
+	RPC_ERROR_CODE_INVALID_PARAMS:        ErrInvalidParams,
+}
+
+var rpcStatuses = map[string]error{
+	RPC_STATUS_BUSY:             ErrDaemonBusy,
+	RPC_STATUS_NOT_MINING:       ErrNotMining,
+	RPC_STATUS_PAYMENT_REQUIRED: ErrPaymentRequired,
+}
+
+func (e *MoneroRpcError) Is(target error) bool {
+	if target == ErrBlockNotFound {
+		// e.g. "Internal error: can't get block by hash. Hash = ..."
+		return e.Code == RPC_ERROR_CODE_TOO_BIG_HEIGHT ||
+			(e.Code == RPC_ERROR_CODE_INTERNAL_ERROR && strings.Contains(e.Message, "can't get block"))
+	}
+
+	return rpcErrorCodes[e.Code] == target
+}
+
+func (e *RpcStatusError) Is(target error) bool {
+	if target == ErrStatusNotOk {
+		return true
+	}
+
+	return rpcStatuses[e.Status] == target
+}
+
+var moneroRpcErrorType = reflect.TypeOf(MoneroRpcError{})
+
+// Attaches the method and the HTTP status to the error object of the response, if there is one
+func setErrorContextHelper(res any, method string, httpStatus int) {
+	v := reflect.ValueOf(res)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	f := v.Elem().FieldByName("Error")
+	if !f.IsValid() || f.Type() != moneroRpcErrorType {
+		return
+	}
+
+	e := f.Addr().Interface().(*MoneroRpcError)
+	if e.Code != 0 {
+		e.Method = method
+		e.HttpStatus = httpStatus
+	}
+}
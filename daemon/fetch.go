@@ -0,0 +1,217 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	// The limits of the restricted rpc nodes (RESTRICTED_BLOCK_COUNT and RESTRICTED_TRANSACTIONS_COUNT)
+	MAX_BLOCKS_PER_REQUEST int = 1000
+	MAX_TXS_PER_REQUEST    int = 100
+
+	DEFAULT_FETCH_WORKERS      int = 4
+	DEFAULT_FETCH_BLOCKS_CHUNK int = 100
+)
+
+var (
+	ErrInvalidRange = errors.New("invalid block range")
+	ErrMissedTx     = errors.New("the daemon didn't return some of the txs")
+)
+
+// Returned by FetchTransactions along with the txs that were found
+type MissedTxError struct {
+	Hashes []string
+}
+
+func (e *MissedTxError) Error() string {
+	return fmt.Sprintf("%v: %d missed", ErrMissedTx, len(e.Hashes))
+}
+
+func (e *MissedTxError) Unwrap() error {
+	return ErrMissedTx
+}
+
+// The zero values of the fields are replaced with the defaults.
+type FetchOptions struct {
+	// The number of the concurrent requests
+	Workers int
+	// The number of the blocks or the txs per request, it is capped by MAX_BLOCKS_PER_REQUEST and MAX_TXS_PER_REQUEST
+	ChunkSize int
+	// Passed to GetTransactions
+	DecodeAsJson bool
+	Prune        bool
+}
+
+// Fetcher fetches big ranges of blocks and big sets of txs by running several requests at once.
+type Fetcher struct {
+	client IDaemonRpcClient
+}
+
+func NewFetcher(client IDaemonRpcClient) *Fetcher {
+	return &Fetcher{client}
+}
+
+func (o *FetchOptions) workersHelper() int {
+	if o == nil || o.Workers <= 0 {
+		return DEFAULT_FETCH_WORKERS
+	}
+	return o.Workers
+}
+
+func (o *FetchOptions) chunkSizeHelper(def int, max int) int {
+	if o == nil || o.ChunkSize <= 0 {
+		return def
+	}
+	if o.ChunkSize > max {
+		return max
+	}
+	return o.ChunkSize
+}
+
+// Splits n items into the chunks and calls fetch for each of them, at most workers at a time.
+// fetch gets the index of the chunk and the [start, end) range of the items.
+// The first error cancels the rest of the chunks.
+func fetchChunksHelper(ctx context.Context, n int, chunkSize int, workers int, fetch func(ctx context.Context, chunk int, start int, end int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make(chan int)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunks {
+				start := chunk * chunkSize
+				end := start + chunkSize
+				if end > n {
+					end = n
+				}
+
+				if err := fetch(ctx, chunk, start, end); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+loop:
+	for chunk := 0; chunk*chunkSize < n; chunk++ {
+		select {
+		case chunks <- chunk:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(chunks)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// Fetches the blocks from the from to the to height (inclusive) with get_blocks_by_height.bin.
+// The blocks are returned in the order of the heights and include the tx blobs,
+// they can be decoded with the go-monero/tx package.
+func (f *Fetcher) FetchBlocks(ctx context.Context, from uint64, to uint64, opts *FetchOptions) ([]BlockCompleteEntry, error) {
+	if from > to {
+		return nil, fmt.Errorf("%w: %d > %d", ErrInvalidRange, from, to)
+	}
+
+	n := int(to - from + 1)
+	chunkSize := opts.chunkSizeHelper(DEFAULT_FETCH_BLOCKS_CHUNK, MAX_BLOCKS_PER_REQUEST)
+	res := make([]BlockCompleteEntry, n)
+
+	err := fetchChunksHelper(ctx, n, chunkSize, opts.workersHelper(), func(ctx context.Context, chunk int, start int, end int) error {
+		heights := make([]uint64, end-start)
+		for i := range heights {
+			heights[i] = from + uint64(start+i)
+		}
+
+		blocks, err := f.client.GetBlocksByHeightBinCtx(ctx, heights)
+		if err != nil {
+			return err
+		}
+		if len(blocks.Blocks) != len(heights) {
+			return fmt.Errorf("get_blocks_by_height.bin: %d blocks returned for %d heights starting from %d", len(blocks.Blocks), len(heights), heights[0])
+		}
+
+		copy(res[start:end], blocks.Blocks)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Fetches the txs with get_transactions. The txs are returned in the order of the hashes.
+// If the daemon doesn't know some of the txs, the rest are returned along with a *MissedTxError.
+func (f *Fetcher) FetchTransactions(ctx context.Context, hashes []string, opts *FetchOptions) ([]MoneroTx1, error) {
+	chunkSize := opts.chunkSizeHelper(MAX_TXS_PER_REQUEST, MAX_TXS_PER_REQUEST)
+	decodeAsJson, prune := opts != nil && opts.DecodeAsJson, opts != nil && opts.Prune
+
+	type chunkResult struct {
+		txs    []MoneroTx1
+		missed []string
+	}
+	results := make([]chunkResult, (len(hashes)+chunkSize-1)/chunkSize)
+
+	err := fetchChunksHelper(ctx, len(hashes), chunkSize, opts.workersHelper(), func(ctx context.Context, chunk int, start int, end int) error {
+		txs, err := f.client.GetTransactionsCtx(ctx, hashes[start:end], decodeAsJson, prune, false)
+		if err != nil {
+			return err
+		}
+
+		/**
+			The daemon returns the found txs in the order of the request,
+			but they are matched by the hash anyway, so the order is preserved no matter what.
+		**/
+		byHash := make(map[string]*MoneroTx1, len(txs.Txs))
+		for i := range txs.Txs {
+			byHash[txs.Txs[i].TxHash] = &txs.Txs[i]
+		}
+		missed := make(map[string]bool, len(txs.MissedTx))
+		for _, v := range txs.MissedTx {
+			missed[v] = true
+		}
+
+		r := &results[chunk]
+		for _, v := range hashes[start:end] {
+			if tx, ok := byHash[v]; ok && !missed[v] {
+				r.txs = append(r.txs, *tx)
+			} else {
+				r.missed = append(r.missed, v)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]MoneroTx1, 0, len(hashes))
+	var missed []string
+	for _, v := range results {
+		res = append(res, v.txs...)
+		missed = append(missed, v.missed...)
+	}
+	if len(missed) > 0 {
+		return res, &MissedTxError{missed}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,884 @@
+package mock
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/daemon/epee"
+	"github.com/icholy/digest"
+)
+
+var okFooter = daemon.JsonRpcFooter{Status: daemon.RPC_STATUS_OK}
+
+type jsonRpcRequest struct {
+	Id     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type jsonRpcResponse struct {
+	Id      json.RawMessage        `json:"id"`
+	Jsonrpc string                 `json:"jsonrpc"`
+	Result  any                    `json:"result,omitempty"`
+	Error   *daemon.MoneroRpcError `json:"error,omitempty"`
+}
+
+type jsonRpcMethod func(d *Daemon, params json.RawMessage) (any, *daemon.MoneroRpcError)
+
+var jsonRpcMethods = map[string]jsonRpcMethod{
+	"get_block_count":            (*Daemon).getBlockCountHelper,
+	"on_get_block_hash":          (*Daemon).onGetBlockHashHelper,
+	"get_last_block_header":      (*Daemon).getLastBlockHeaderHelper,
+	"get_block_header_by_hash":   (*Daemon).getBlockHeaderByHashHelper,
+	"get_block_header_by_height": (*Daemon).getBlockHeaderByHeightHelper,
+	"get_block_headers_range":    (*Daemon).getBlockHeadersRangeHelper,
+	"get_block":                  (*Daemon).getBlockHelper,
+	"get_info":                   (*Daemon).getInfoHelper,
+	"get_version":                (*Daemon).getVersionHelper,
+	"get_fee_estimate":           (*Daemon).getFeeEstimateHelper,
+	"flush_txpool":               (*Daemon).flushTxpoolHelper,
+}
+
+// The other endpoints get the JSON body, the binary ones get the epee one
+type endpoint func(d *Daemon, body []byte) any
+
+var otherEndpoints = map[string]endpoint{
+	"get_height":                  (*Daemon).getHeightHelper,
+	"get_transactions":            (*Daemon).getTransactionsHelper,
+	"get_transaction_pool":        (*Daemon).getTransactionPoolHelper,
+	"get_transaction_pool_hashes": (*Daemon).getTransactionPoolHashesHelper,
+	"send_raw_transaction":        (*Daemon).sendRawTransactionHelper,
+	"is_key_image_spent":          (*Daemon).isKeyImageSpentHelper,
+}
+
+var binEndpoints = map[string]endpoint{
+	"get_blocks.bin":           (*Daemon).getBlocksBinHelper,
+	"get_blocks_by_height.bin": (*Daemon).getBlocksByHeightBinHelper,
+	"get_hashes.bin":           (*Daemon).getHashesBinHelper,
+	"get_o_indexes.bin":        (*Daemon).getOIndexesBinHelper,
+	"get_outs.bin":             (*Daemon).getOutsBinHelper,
+}
+
+// Serves the rpc. Failure.Drop panics with http.ErrAbortHandler, which makes the http.Server close the connection.
+func (d *Daemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !d.authHelper(w, r) {
+		return
+	}
+
+	d.mu.Lock()
+	latency := d.latency
+	d.mu.Unlock()
+	if !sleepHelper(r.Context(), latency) {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if r.URL.Path == daemon.DEFAULT_MONERO_RPC_ENDPOINT {
+		d.serveJsonRpcHelper(w, r, body)
+		return
+	}
+
+	method := strings.TrimPrefix(r.URL.Path, "/")
+	if h, ok := otherEndpoints[method]; ok {
+		f := d.callHelper(method)
+		if !applyFailureHelper(w, r, f) {
+			return
+		}
+
+		switch {
+		case f != nil && f.Code != 0:
+			writeJsonHelper(w, struct {
+				Error daemon.MoneroRpcError `json:"error"`
+			}{daemon.MoneroRpcError{Code: f.Code, Message: f.Message}})
+		case f != nil && f.Status != "":
+			writeJsonHelper(w, daemon.JsonRpcFooter{Status: f.Status})
+		default:
+			d.mu.Lock()
+			res := h(d, body)
+			d.mu.Unlock()
+			writeJsonHelper(w, res)
+		}
+		return
+	}
+
+	if h, ok := binEndpoints[method]; ok {
+		f := d.callHelper(method)
+		if !applyFailureHelper(w, r, f) {
+			return
+		}
+
+		var res any
+		switch {
+		case f != nil && f.Status != "":
+			res = daemon.BinRpcFooter{Status: f.Status}
+		case f != nil && f.Code != 0:
+			res = daemon.BinRpcFooter{Status: daemon.RPC_STATUS_FAILED}
+		default:
+			d.mu.Lock()
+			res = h(d, body)
+			d.mu.Unlock()
+		}
+
+		data, err := epee.Marshal(res)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(data)
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func sleepHelper(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Applies the delay, the drop and the HTTP status of the failure. Returns false if the request has been answered.
+func applyFailureHelper(w http.ResponseWriter, r *http.Request, f *Failure) bool {
+	if f == nil {
+		return true
+	}
+	if !sleepHelper(r.Context(), f.Delay) {
+		return false
+	}
+	if f.Drop {
+		panic(http.ErrAbortHandler)
+	}
+	if f.HttpStatus != 0 {
+		w.WriteHeader(f.HttpStatus)
+		return false
+	}
+
+	return true
+}
+
+func writeJsonHelper(w http.ResponseWriter, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+/**
+	DIGEST AUTHENTICATION
+**/
+
+func (d *Daemon) checkCredentialsHelper(r *http.Request, cred *digest.Credentials) bool {
+	d.mu.Lock()
+	known := d.nonces[cred.Nonce]
+	d.mu.Unlock()
+	if !known || cred.Username != d.config.Username || cred.Realm != DIGEST_REALM || cred.URI != r.URL.RequestURI() {
+		return false
+	}
+
+	chal := &digest.Challenge{Realm: DIGEST_REALM, Nonce: cred.Nonce, Algorithm: cred.Algorithm, QOP: []string{cred.QOP}}
+	expected, err := digest.Digest(chal, digest.Options{
+		Method:   r.Method,
+		URI:      cred.URI,
+		Count:    cred.Nc,
+		Username: d.config.Username,
+		Password: d.config.Password,
+		Cnonce:   cred.Cnonce,
+	})
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected.Response), []byte(cred.Response)) == 1
+}
+
+// Answers with the challenge unless the request is authenticated
+func (d *Daemon) authHelper(w http.ResponseWriter, r *http.Request) bool {
+	if d.config.Username == "" {
+		return true
+	}
+
+	if cred, err := digest.ParseCredentials(r.Header.Get("Authorization")); err == nil && d.checkCredentialsHelper(r, cred) {
+		return true
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return false
+	}
+	chal := &digest.Challenge{Realm: DIGEST_REALM, Nonce: hex.EncodeToString(nonce), Algorithm: "MD5", QOP: []string{"auth"}}
+
+	d.mu.Lock()
+	d.nonces[chal.Nonce] = true
+	d.mu.Unlock()
+
+	w.Header().Set("WWW-Authenticate", chal.String())
+	w.WriteHeader(http.StatusUnauthorized)
+
+	return false
+}
+
+/**
+	JSON RPC METHODS
+**/
+
+func (d *Daemon) serveJsonRpcHelper(w http.ResponseWriter, r *http.Request, body []byte) {
+	trimmed := strings.TrimSpace(string(body))
+	if d.config.Batch && strings.HasPrefix(trimmed, "[") {
+		var reqs []jsonRpcRequest
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			writeJsonHelper(w, &jsonRpcResponse{Id: json.RawMessage("0"), Jsonrpc: "2.0", Error: &daemon.MoneroRpcError{Code: daemon.RPC_ERROR_CODE_PARSE_ERROR, Message: "Parse error"}})
+			return
+		}
+
+		/** the delays, the drops and the HTTP statuses of the calls apply to the whole batch **/
+		failures := make([]*Failure, len(reqs))
+		for i := range reqs {
+			failures[i] = d.callHelper(reqs[i].Method)
+		}
+		for _, f := range failures {
+			if !applyFailureHelper(w, r, f) {
+				return
+			}
+		}
+
+		res := make([]*jsonRpcResponse, len(reqs))
+		for i := range reqs {
+			res[i] = d.jsonRpcCallHelper(&reqs[i], failures[i])
+		}
+		writeJsonHelper(w, res)
+		return
+	}
+
+	// monerod answers the batches with the parse error as well
+	var req jsonRpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJsonHelper(w, &jsonRpcResponse{Id: json.RawMessage("0"), Jsonrpc: "2.0", Error: &daemon.MoneroRpcError{Code: daemon.RPC_ERROR_CODE_PARSE_ERROR, Message: "Parse error"}})
+		return
+	}
+
+	f := d.callHelper(req.Method)
+	if !applyFailureHelper(w, r, f) {
+		return
+	}
+	writeJsonHelper(w, d.jsonRpcCallHelper(&req, f))
+}
+
+func (d *Daemon) jsonRpcCallHelper(req *jsonRpcRequest, f *Failure) *jsonRpcResponse {
+	res := &jsonRpcResponse{Id: req.Id, Jsonrpc: "2.0"}
+
+	switch {
+	case f != nil && f.Code != 0:
+		res.Error = &daemon.MoneroRpcError{Code: f.Code, Message: f.Message}
+	case f != nil && f.Status != "":
+		res.Result = daemon.JsonRpcFooter{Status: f.Status}
+	default:
+		method, ok := jsonRpcMethods[req.Method]
+		if !ok {
+			res.Error = &daemon.MoneroRpcError{Code: daemon.RPC_ERROR_CODE_METHOD_NOT_FOUND, Message: "Method not found"}
+			break
+		}
+
+		d.mu.Lock()
+		res.Result, res.Error = method(d, req.Params)
+		d.mu.Unlock()
+	}
+
+	return res
+}
+
+func paramsHelper[T any](params json.RawMessage) (*T, *daemon.MoneroRpcError) {
+	var res T
+	if len(params) == 0 {
+		return &res, nil
+	}
+	if err := json.Unmarshal(params, &res); err != nil {
+		return nil, &daemon.MoneroRpcError{Code: daemon.RPC_ERROR_CODE_INVALID_PARAMS, Message: "Invalid params"}
+	}
+
+	return &res, nil
+}
+
+func (d *Daemon) topHelper() *Block {
+	return d.blocks[len(d.blocks)-1]
+}
+
+func (d *Daemon) blockByHeightHelper(height uint64) (*Block, *daemon.MoneroRpcError) {
+	if height >= uint64(len(d.blocks)) {
+		return nil, &daemon.MoneroRpcError{
+			Code:    daemon.RPC_ERROR_CODE_TOO_BIG_HEIGHT,
+			Message: fmt.Sprintf("Requested block height: %d greater than current top block height: %d", height, len(d.blocks)-1),
+		}
+	}
+
+	return d.blocks[height], nil
+}
+
+func (d *Daemon) blockByHashHelper(hash string) (*Block, *daemon.MoneroRpcError) {
+	height, ok := d.heights[hash]
+	if !ok {
+		return nil, &daemon.MoneroRpcError{
+			Code:    daemon.RPC_ERROR_CODE_INTERNAL_ERROR,
+			Message: fmt.Sprintf("Internal error: can't get block by hash. Hash = %s.", hash),
+		}
+	}
+
+	return d.blocks[height], nil
+}
+
+func (d *Daemon) blockWeightHelper(b *Block) uint64 {
+	weight := uint64(len(b.Blob))
+	for _, t := range d.txsHelper(b.TxHashes) {
+		weight += uint64(len(t.blob))
+	}
+
+	return weight
+}
+
+func (d *Daemon) blockHeaderHelper(b *Block) daemon.BlockHeader {
+	weight := d.blockWeightHelper(b)
+	cumulative := DEFAULT_DIFFICULTY * (b.Height + 1)
+
+	return daemon.BlockHeader{
+		BlockSize:                weight,
+		BlockWeight:              weight,
+		CumulativeDifficulty:     cumulative,
+		Depth:                    uint64(len(d.blocks)) - 1 - b.Height,
+		Difficulty:               DEFAULT_DIFFICULTY,
+		Hash:                     b.Hash,
+		Height:                   b.Height,
+		LongTermWeight:           weight,
+		MajorVersion:             uint(BLOCK_MAJOR_VERSION),
+		MinerTxHash:              b.MinerTxHash,
+		MinorVersion:             uint(BLOCK_MINOR_VERSION),
+		Nonce:                    uint64(b.Nonce),
+		NumTxes:                  uint(len(b.TxHashes)),
+		PrevHash:                 b.PrevHash,
+		Reward:                   b.Reward,
+		Timestamp:                uint32(b.Timestamp),
+		WideCumulativeDifficulty: fmt.Sprintf("0x%x", cumulative),
+		WideDifficulty:           fmt.Sprintf("0x%x", DEFAULT_DIFFICULTY),
+	}
+}
+
+func (d *Daemon) blockHeaderResultHelper(b *Block) *daemon.GetBlockHeaderResult {
+	return &daemon.GetBlockHeaderResult{BlockHeader: d.blockHeaderHelper(b), JsonRpcFooter: okFooter}
+}
+
+func (d *Daemon) getBlockCountHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	return &daemon.GetBlockCountResult{Count: uint64(len(d.blocks)), JsonRpcFooter: okFooter}, nil
+}
+
+func (d *Daemon) onGetBlockHashHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	p, rpcErr := paramsHelper[[]uint64](params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if len(*p) != 1 {
+		return nil, &daemon.MoneroRpcError{Code: daemon.RPC_ERROR_CODE_WRONG_PARAM, Message: "Wrong parameters, expected height"}
+	}
+
+	b, rpcErr := d.blockByHeightHelper((*p)[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return b.Hash, nil
+}
+
+func (d *Daemon) getLastBlockHeaderHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	return d.blockHeaderResultHelper(d.topHelper()), nil
+}
+
+func (d *Daemon) getBlockHeaderByHashHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	p, rpcErr := paramsHelper[daemon.GetBlockHeaderByHashParams](params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	b, rpcErr := d.blockByHashHelper(p.Hash)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return d.blockHeaderResultHelper(b), nil
+}
+
+func (d *Daemon) getBlockHeaderByHeightHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	p, rpcErr := paramsHelper[daemon.GetBlockHeaderByHeightParams](params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	b, rpcErr := d.blockByHeightHelper(p.Height)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	return d.blockHeaderResultHelper(b), nil
+}
+
+func (d *Daemon) getBlockHeadersRangeHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	p, rpcErr := paramsHelper[daemon.GetBlockHeadersRangeParams](params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if p.StartHeight > p.EndHeight || p.EndHeight >= uint64(len(d.blocks)) {
+		return nil, &daemon.MoneroRpcError{Code: daemon.RPC_ERROR_CODE_TOO_BIG_HEIGHT, Message: "Invalid start/end heights."}
+	}
+
+	res := &daemon.GetBlockHeadersRangeResult{JsonRpcFooter: okFooter}
+	for _, b := range d.blocks[p.StartHeight : p.EndHeight+1] {
+		res.Headers = append(res.Headers, d.blockHeaderHelper(b))
+	}
+
+	return res, nil
+}
+
+// The daemon model has the decoded JSON fields (BlockDetails, TxInfo) that aren't a part of the responses,
+// the nil fields below shadow them, so they aren't written
+type getBlockResult struct {
+	daemon.GetBlockResult
+	BlockDetails *struct{} `json:",omitempty"`
+}
+
+func (d *Daemon) getBlockHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	p, rpcErr := paramsHelper[struct {
+		Hash   string `json:"hash"`
+		Height uint64 `json:"height"`
+	}](params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	var b *Block
+	if p.Hash != "" {
+		b, rpcErr = d.blockByHashHelper(p.Hash)
+	} else {
+		b, rpcErr = d.blockByHeightHelper(p.Height)
+	}
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	details, err := json.Marshal(&daemon.BlockDetails{
+		MajorVersion: uint(BLOCK_MAJOR_VERSION),
+		MinorVersion: uint(BLOCK_MINOR_VERSION),
+		Timestamp:    uint32(b.Timestamp),
+		PrevId:       b.PrevHash,
+		Nonce:        uint64(b.Nonce),
+		MinerTx:      minerTxInfoHelper(b.minerTx.tx),
+		TxHashes:     b.TxHashes,
+	})
+	if err != nil {
+		return nil, &daemon.MoneroRpcError{Code: daemon.RPC_ERROR_CODE_INTERNAL_ERROR, Message: err.Error()}
+	}
+
+	return &getBlockResult{GetBlockResult: daemon.GetBlockResult{
+		Blob:                 hex.EncodeToString(b.Blob),
+		MinerTxHash:          b.MinerTxHash,
+		Json:                 string(details),
+		GetBlockHeaderResult: *d.blockHeaderResultHelper(b),
+	}}, nil
+}
+
+func (d *Daemon) getInfoHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	var txCount uint64
+	for _, b := range d.blocks {
+		txCount += uint64(len(b.TxHashes))
+	}
+	height := uint64(len(d.blocks))
+
+	return &daemon.GetInfoResult{
+		AdjustedTime:             uint64(time.Now().Unix()),
+		CumulativeDifficulty:     DEFAULT_DIFFICULTY * height,
+		Difficulty:               DEFAULT_DIFFICULTY,
+		Height:                   height,
+		HeightWithoutBootstrap:   height,
+		Mainnet:                  true,
+		Nettype:                  "mainnet",
+		StartTime:                uint64(d.config.GenesisTime.Unix()),
+		Synchronized:             true,
+		Target:                   uint32(BLOCK_TIME / time.Second),
+		TargetHeight:             height,
+		TopBlockHash:             d.topHelper().Hash,
+		TxCount:                  txCount,
+		TxPoolSize:               uint32(len(d.pool)),
+		Version:                  "mock",
+		WideCumulativeDifficulty: fmt.Sprintf("0x%x", DEFAULT_DIFFICULTY*height),
+		WideDifficulty:           fmt.Sprintf("0x%x", DEFAULT_DIFFICULTY),
+		JsonRpcFooter:            okFooter,
+	}, nil
+}
+
+func (d *Daemon) getVersionHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	return &daemon.GetVersionResult{Release: true, Version: DEFAULT_RPC_VERSION, JsonRpcFooter: okFooter}, nil
+}
+
+func (d *Daemon) getFeeEstimateHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	fee := d.config.Fee
+	return &daemon.GetFeeEstimateResult{Fee: fee, Fees: []uint64{fee, 5 * fee, 25 * fee, 1000 * fee}, QuantizationMask: 10000, JsonRpcFooter: okFooter}, nil
+}
+
+func (d *Daemon) flushTxpoolHelper(params json.RawMessage) (any, *daemon.MoneroRpcError) {
+	p, rpcErr := paramsHelper[daemon.FlushTxpoolParams](params)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	hashes := p.TxIds
+	if len(hashes) == 0 {
+		hashes = append([]string(nil), d.pool...)
+	}
+	for _, v := range hashes {
+		d.removeFromPoolHelper(v)
+	}
+
+	return &daemon.FlushTxpoolResult{Status: daemon.RPC_STATUS_OK}, nil
+}
+
+/**
+	OTHER RPC METHODS
+**/
+
+func bodyHelper[T any](body []byte) (*T, bool) {
+	var res T
+	if len(body) == 0 {
+		return &res, true
+	}
+
+	return &res, json.Unmarshal(body, &res) == nil
+}
+
+var failedFooter = daemon.JsonRpcFooter{Status: daemon.RPC_STATUS_FAILED}
+
+func feeHelper(t *txEntry) uint64 {
+	if t.tx.RctSignatures == nil {
+		return 0
+	}
+
+	return t.tx.RctSignatures.TxnFee
+}
+
+func (d *Daemon) getHeightHelper(body []byte) any {
+	return &daemon.GetHeightResponse{Hash: d.topHelper().Hash, Height: uint64(len(d.blocks)), JsonRpcFooter: okFooter}
+}
+
+type moneroTx1 struct {
+	daemon.MoneroTx1
+	TxInfo *struct{} `json:",omitempty"`
+}
+
+type getTransactionsResponse struct {
+	daemon.GetTransactionsResponse
+	Txs []moneroTx1 `json:"txs"`
+}
+
+func (d *Daemon) getTransactionsHelper(body []byte) any {
+	req, ok := bodyHelper[daemon.GetTransactionsParams](body)
+	if !ok {
+		return &failedFooter
+	}
+
+	res := &getTransactionsResponse{GetTransactionsResponse: daemon.GetTransactionsResponse{JsonRpcFooter: okFooter}}
+	for _, hash := range req.TxHashes {
+		t, ok := d.txs[hash]
+		if !ok {
+			res.MissedTx = append(res.MissedTx, hash)
+			continue
+		}
+
+		v := daemon.MoneroTx1{AsHex: hex.EncodeToString(t.blob), TxHash: t.hash, InPool: t.inPool, OutputIndices: t.outputIndices}
+		if !t.inPool {
+			v.BlockHeight = t.height
+			v.BlockTimestamp = d.blocks[t.height].Timestamp
+			v.Confirmations = uint64(len(d.blocks)) - t.height
+		}
+		if t.tx.Version == 2 {
+			if prunable, err := t.tx.PrunableHash(); err == nil {
+				v.PrunableHash = prunable.String()
+			}
+		}
+		if req.DecodeAsJson {
+			data, err := json.Marshal(newTxJsonHelper(t.tx))
+			if err != nil {
+				return &failedFooter
+			}
+			v.AsJson = string(data)
+		}
+
+		res.Txs = append(res.Txs, moneroTx1{MoneroTx1: v})
+	}
+
+	return res
+}
+
+type moneroTx struct {
+	daemon.MoneroTx
+	TxInfo *struct{} `json:",omitempty"`
+}
+
+type getTransactionPoolResponse struct {
+	daemon.GetTransactionPoolResponse
+	Transactions []moneroTx `json:"transactions"`
+}
+
+func (d *Daemon) getTransactionPoolHelper(body []byte) any {
+	res := &getTransactionPoolResponse{GetTransactionPoolResponse: daemon.GetTransactionPoolResponse{JsonRpcFooter: okFooter}}
+	for _, t := range d.txsHelper(d.pool) {
+		data, err := json.Marshal(newTxJsonHelper(t.tx))
+		if err != nil {
+			return &failedFooter
+		}
+
+		v := daemon.MoneroTx{
+			BlobSize:           uint64(len(t.blob)),
+			DoNotRelay:         t.doNotRelay,
+			Fee:                feeHelper(t),
+			IdHash:             t.hash,
+			MaxUsedBlockHeight: uint64(len(d.blocks)) - 1,
+			MaxUsedBlockIdHash: d.topHelper().Hash,
+			ReceiveTime:        t.receiveTime,
+			Relayed:            !t.doNotRelay,
+			TxBlob:             hex.EncodeToString(t.blob),
+			TxJson:             string(data),
+			Weight:             uint64(len(t.blob)),
+		}
+		if v.Relayed {
+			v.LastRelayedTime = t.receiveTime
+		}
+		res.Transactions = append(res.Transactions, moneroTx{MoneroTx: v})
+
+		for _, in := range t.tx.Vin {
+			res.SpentKeyImages = append(res.SpentKeyImages, daemon.SpentKeyImage{IdHash: in.Key.KeyImage.String(), TxsHashes: []string{t.hash}})
+		}
+	}
+
+	return res
+}
+
+func (d *Daemon) getTransactionPoolHashesHelper(body []byte) any {
+	return &daemon.GetTransactionPoolHashesResponse{TxHashes: append([]string{}, d.pool...), JsonRpcFooter: okFooter}
+}
+
+func (d *Daemon) sendRawTransactionHelper(body []byte) any {
+	req, ok := bodyHelper[daemon.SendRawTransactionParams](body)
+	if !ok {
+		return &failedFooter
+	}
+
+	res := &daemon.SendRawTransactionResponse{NotRelayed: req.DoNotRelay, JsonRpcFooter: failedFooter}
+	blob, err := hex.DecodeString(req.TxAsHex)
+	if err != nil {
+		res.Reason = "Failed to parse the tx"
+		return res
+	}
+
+	_, err = d.addToPoolHelper(blob, req.DoNotRelay)
+	switch {
+	case errors.Is(err, ErrDoubleSpend):
+		res.DoubleSpend = true
+		res.Reason = "double spend"
+	case errors.Is(err, ErrInvalidTx):
+		res.InvalidInput = true
+		res.Reason = err.Error()
+	case err == nil, errors.Is(err, ErrTxExists):
+		// the known txs are accepted again, so the retried calls succeed
+		res.Status = daemon.RPC_STATUS_OK
+	default:
+		res.Reason = err.Error()
+	}
+
+	return res
+}
+
+func (d *Daemon) isKeyImageSpentHelper(body []byte) any {
+	req, ok := bodyHelper[daemon.IsKeyImageSpentParams](body)
+	if !ok {
+		return &failedFooter
+	}
+
+	res := &daemon.IsKeyImageSpentResponse{SpentStatus: make([]daemon.KeyImageSpentStatus, len(req.KeyImages)), JsonRpcFooter: okFooter}
+	for i, v := range req.KeyImages {
+		if hash, ok := d.keyImages[v]; ok {
+			if d.txs[hash].inPool {
+				res.SpentStatus[i] = daemon.KeyImageSpentInPool
+			} else {
+				res.SpentStatus[i] = daemon.KeyImageSpentInBlockchain
+			}
+		}
+	}
+
+	return res
+}
+
+/**
+	BINARY RPC METHODS
+**/
+
+var failedBinFooter = daemon.BinRpcFooter{Status: daemon.RPC_STATUS_FAILED}
+
+func (d *Daemon) blockEntryHelper(b *Block) daemon.BlockCompleteEntry {
+	res := daemon.BlockCompleteEntry{Block: b.Blob, BlockWeight: d.blockWeightHelper(b)}
+	for _, t := range d.txsHelper(b.TxHashes) {
+		res.Txs = append(res.Txs, daemon.TxBlobEntry{Blob: t.blob})
+	}
+
+	return res
+}
+
+// Returns the height of the first of the block ids that is in the chain, as monerod's find_blockchain_supplement
+func (d *Daemon) splitHeightHelper(blockIds []string, startHeight uint64) (uint64, bool) {
+	if startHeight > 0 {
+		return startHeight, startHeight < uint64(len(d.blocks))
+	}
+
+	for _, v := range blockIds {
+		if height, ok := d.heights[v]; ok {
+			return height, true
+		}
+	}
+
+	return 0, false
+}
+
+func (d *Daemon) getBlocksBinHelper(body []byte) any {
+	var req daemon.GetBlocksBinParams
+	if err := epee.Unmarshal(body, &req); err != nil {
+		return &failedBinFooter
+	}
+
+	start, ok := d.splitHeightHelper(req.BlockIds, req.StartHeight)
+	if !ok {
+		return &failedBinFooter
+	}
+	end := uint64(len(d.blocks))
+	if end-start > uint64(daemon.MAX_BLOCKS_PER_REQUEST) {
+		end = start + uint64(daemon.MAX_BLOCKS_PER_REQUEST)
+	}
+
+	res := &daemon.GetBlocksBinResponse{StartHeight: start, CurrentHeight: uint64(len(d.blocks)), BinRpcFooter: daemon.BinRpcFooter{Status: daemon.RPC_STATUS_OK}}
+	for _, b := range d.blocks[start:end] {
+		res.Blocks = append(res.Blocks, d.blockEntryHelper(b))
+
+		var indices daemon.BlockOutputIndices
+		if !req.NoMinerTx {
+			indices.Indices = append(indices.Indices, daemon.TxOutputIndices{Indices: b.minerTx.outputIndices})
+		}
+		for _, t := range d.txsHelper(b.TxHashes) {
+			indices.Indices = append(indices.Indices, daemon.TxOutputIndices{Indices: t.outputIndices})
+		}
+		res.OutputIndices = append(res.OutputIndices, indices)
+	}
+
+	return res
+}
+
+func (d *Daemon) getBlocksByHeightBinHelper(body []byte) any {
+	var req daemon.GetBlocksByHeightBinParams
+	if err := epee.Unmarshal(body, &req); err != nil {
+		return &failedBinFooter
+	}
+
+	res := &daemon.GetBlocksByHeightBinResponse{BinRpcFooter: daemon.BinRpcFooter{Status: daemon.RPC_STATUS_OK}}
+	for _, v := range req.Heights {
+		if v >= uint64(len(d.blocks)) {
+			return &failedBinFooter
+		}
+		res.Blocks = append(res.Blocks, d.blockEntryHelper(d.blocks[v]))
+	}
+
+	return res
+}
+
+func (d *Daemon) getHashesBinHelper(body []byte) any {
+	var req daemon.GetHashesBinParams
+	if err := epee.Unmarshal(body, &req); err != nil {
+		return &failedBinFooter
+	}
+
+	start, ok := d.splitHeightHelper(req.BlockIds, req.StartHeight)
+	if !ok {
+		return &failedBinFooter
+	}
+
+	res := &daemon.GetHashesBinResponse{StartHeight: start, CurrentHeight: uint64(len(d.blocks)), BinRpcFooter: daemon.BinRpcFooter{Status: daemon.RPC_STATUS_OK}}
+	for _, b := range d.blocks[start:] {
+		res.MBlockIds = append(res.MBlockIds, b.Hash)
+	}
+
+	return res
+}
+
+func (d *Daemon) getOIndexesBinHelper(body []byte) any {
+	var req daemon.GetOIndexesBinParams
+	if err := epee.Unmarshal(body, &req); err != nil {
+		return &failedBinFooter
+	}
+
+	t, ok := d.txs[req.TxId]
+	if !ok || t.inPool {
+		return &failedBinFooter
+	}
+
+	return &daemon.GetOIndexesBinResponse{OIndexes: t.outputIndices, BinRpcFooter: daemon.BinRpcFooter{Status: daemon.RPC_STATUS_OK}}
+}
+
+// All the outputs are RingCT ones, so only the amount 0 is known
+func (d *Daemon) getOutsBinHelper(body []byte) any {
+	var req daemon.GetOutsBinParams
+	if err := epee.Unmarshal(body, &req); err != nil {
+		return &failedBinFooter
+	}
+
+	res := &daemon.GetOutsBinResponse{BinRpcFooter: daemon.BinRpcFooter{Status: daemon.RPC_STATUS_OK}}
+	for _, v := range req.Outputs {
+		if v.Amount != 0 || v.Index >= uint64(len(d.outputs)) {
+			return &failedBinFooter
+		}
+
+		out := d.outputs[v.Index]
+		key := daemon.OutKeyBin{
+			Key:      out.key.String(),
+			Mask:     out.mask.String(),
+			Unlocked: out.height+DEFAULT_TX_SPENDABLE_AGE <= uint64(len(d.blocks)),
+			Height:   out.height,
+		}
+		if req.GetTxId {
+			key.TxId = out.txHash
+		}
+		res.Outs = append(res.Outs, key)
+	}
+
+	return res
+}
@@ -0,0 +1,468 @@
+// Package mock implements an in-process monerod for the tests of the code built on top of daemon.IDaemonRpcClient.
+// Daemon is an http.Handler that serves the JSON RPC, the other and the binary endpoints from an in-memory chain,
+// so it can be run with httptest.NewServer and used with daemon.NewDaemonRpcClient.
+// The blocks and the txs are encoded the way monerod does, so their hashes and blobs are consistent across the endpoints,
+// though the proof of work, the signatures and the proofs aren't checked.
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chekist32/go-monero/tx"
+	"github.com/chekist32/go-monero/utils"
+)
+
+const (
+	DEFAULT_BLOCK_REWARD uint64 = 600000000000
+	DEFAULT_DIFFICULTY   uint64 = 1000
+	// The fee per byte reported by get_fee_estimate
+	DEFAULT_FEE       uint64 = 20000
+	DEFAULT_RING_SIZE int    = 16
+	// The major and the minor rpc versions reported by get_version
+	DEFAULT_RPC_VERSION uint32 = 3<<16 | 14
+
+	BLOCK_TIME                time.Duration = 120 * time.Second
+	BLOCK_MAJOR_VERSION       uint64        = 16
+	BLOCK_MINOR_VERSION       uint64        = 16
+	MINED_MONEY_UNLOCK_WINDOW uint64        = 60
+	// The number of blocks the outputs are locked for
+	DEFAULT_TX_SPENDABLE_AGE uint64 = 10
+	// The realm of the digest authentication, the same as monerod's
+	DIGEST_REALM = "monero-rpc"
+)
+
+var (
+	ErrInvalidTx    = errors.New("invalid tx")
+	ErrTxExists     = errors.New("the tx is already known")
+	ErrDoubleSpend  = errors.New("the key image is already spent")
+	ErrTxNotInPool  = errors.New("the tx isn't in the pool")
+	ErrPopTooDeep   = errors.New("the genesis block can't be popped")
+	ErrBlockInvalid = errors.New("invalid block")
+)
+
+// The zero values are replaced with the defaults
+type Config struct {
+	// If set, the requests must pass the digest authentication, as with monerod's --rpc-login
+	Username string
+	Password string
+	// The delay of every response
+	Latency time.Duration
+	// Answers the JSON RPC 2.0 batches. monerod doesn't support them, so by default they're rejected the way it does.
+	Batch bool
+	// The timestamp of the genesis block, the following blocks are BLOCK_TIME apart. time.Now() by default.
+	GenesisTime time.Time
+	// DEFAULT_BLOCK_REWARD by default
+	BlockReward uint64
+	// DEFAULT_FEE by default
+	Fee uint64
+}
+
+// Failure tells how the mock fails the calls of a method. The zero fields are ignored,
+// e.g. a Failure with Delay only slows the calls down.
+type Failure struct {
+	// Answers with the HTTP status, e.g. http.StatusInternalServerError
+	HttpStatus int
+	// Answers with the error object (the JSON RPC error or the error field of the other endpoints).
+	// The binary endpoints have no error object, they answer with daemon.RPC_STATUS_FAILED instead.
+	Code    int32
+	Message string
+	// Answers with the status only, e.g. daemon.RPC_STATUS_BUSY
+	Status string
+	// Closes the connection without an answer
+	Drop  bool
+	Delay time.Duration
+	// The number of the calls to fail, 0 fails all of them until ClearFailures
+	Times int
+}
+
+type Daemon struct {
+	config Config
+
+	mu        sync.Mutex
+	blocks    []*Block
+	heights   map[string]uint64
+	txs       map[string]*txEntry
+	pool      []string
+	keyImages map[string]string
+	outputs   []outputEntry
+	// the number of the mined blocks, so the blocks mined at the same height after a reorg get other hashes
+	mined uint32
+
+	failures map[string][]*Failure
+	calls    map[string]int
+	latency  time.Duration
+	nonces   map[string]bool
+}
+
+// Creates a mock daemon with the genesis block only
+func New(config Config) *Daemon {
+	if config.GenesisTime.IsZero() {
+		config.GenesisTime = time.Now()
+	}
+	if config.BlockReward == 0 {
+		config.BlockReward = DEFAULT_BLOCK_REWARD
+	}
+	if config.Fee == 0 {
+		config.Fee = DEFAULT_FEE
+	}
+
+	d := &Daemon{
+		config:    config,
+		heights:   map[string]uint64{},
+		txs:       map[string]*txEntry{},
+		keyImages: map[string]string{},
+		failures:  map[string][]*Failure{},
+		calls:     map[string]int{},
+		latency:   config.Latency,
+		nonces:    map[string]bool{},
+	}
+
+	// the default block can't fail
+	d.mineBlockHelper(nil)
+
+	return d
+}
+
+/**
+	CHAIN
+**/
+
+func parseTxHelper(blob []byte) (*tx.Transaction, string, error) {
+	t, err := tx.ParseTransaction(blob)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrInvalidTx, err)
+	}
+	if t.Pruned {
+		return nil, "", fmt.Errorf("%w: the tx is pruned", ErrInvalidTx)
+	}
+
+	hash, err := t.Hash()
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrInvalidTx, err)
+	}
+
+	return t, hash.String(), nil
+}
+
+func (d *Daemon) addToPoolHelper(blob []byte, doNotRelay bool) (string, error) {
+	t, hash, err := parseTxHelper(blob)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := d.txs[hash]; ok {
+		return hash, ErrTxExists
+	}
+	for _, v := range t.Vin {
+		if v.Key == nil {
+			return "", fmt.Errorf("%w: only the key inputs are allowed", ErrInvalidTx)
+		}
+		if _, ok := d.keyImages[v.Key.KeyImage.String()]; ok {
+			return hash, fmt.Errorf("%w: %s", ErrDoubleSpend, v.Key.KeyImage)
+		}
+	}
+
+	for _, v := range t.Vin {
+		d.keyImages[v.Key.KeyImage.String()] = hash
+	}
+	d.txs[hash] = &txEntry{hash: hash, tx: t, blob: blob, receiveTime: uint64(time.Now().Unix()), doNotRelay: doNotRelay, inPool: true}
+	d.pool = append(d.pool, hash)
+
+	return hash, nil
+}
+
+// Adds the tx to the pool, it's mined with the next block. Returns the tx hash.
+func (d *Daemon) AddToPool(t Tx) (string, error) {
+	blob, err := t.Encode()
+	if err != nil {
+		return "", err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.addToPoolHelper(blob, false)
+}
+
+func (d *Daemon) removeFromPoolHelper(hash string) bool {
+	t, ok := d.txs[hash]
+	if !ok || !t.inPool {
+		return false
+	}
+
+	for _, v := range t.tx.Vin {
+		delete(d.keyImages, v.Key.KeyImage.String())
+	}
+	delete(d.txs, hash)
+	for i, v := range d.pool {
+		if v == hash {
+			d.pool = append(d.pool[:i], d.pool[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+// Removes the tx from the pool, as if it had been dropped by the daemon
+func (d *Daemon) RemoveFromPool(hash string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.removeFromPoolHelper(hash) {
+		return ErrTxNotInPool
+	}
+
+	return nil
+}
+
+// Mines a block with all the pool txs. The nil coinbase gets a single output of the block reward.
+func (d *Daemon) mineBlockHelper(coinbase *Tx) (*Block, error) {
+	height := uint64(len(d.blocks))
+	d.mined++
+
+	outputs := []Output{{Amount: d.config.BlockReward}}
+	var extra []byte
+	if coinbase != nil {
+		outputs, extra = coinbase.Outputs, coinbase.Extra
+	} else {
+		/** a unique output key, so the miner txs differ **/
+		key, err := utils.Keccak256Hash([]byte(fmt.Sprintf("mock coinbase %d %d", height, d.mined)))
+		if err != nil {
+			return nil, err
+		}
+		outputs[0].Key = fmt.Sprintf("%x", key)
+		outputs[0].ViewTag = fmt.Sprintf("%02x", key[0])
+	}
+
+	minerBlob, err := encodeMinerTxHelper(height, outputs, extra)
+	if err != nil {
+		return nil, err
+	}
+	minerTx, minerHash, err := parseTxHelper(minerBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	var reward uint64
+	for _, v := range minerTx.Vout {
+		reward += v.Amount
+	}
+
+	b := &Block{
+		Height:      height,
+		Timestamp:   uint64(d.config.GenesisTime.Add(time.Duration(height) * BLOCK_TIME).Unix()),
+		Nonce:       d.mined,
+		Reward:      reward,
+		MinerTxHash: minerHash,
+		TxHashes:    append([]string(nil), d.pool...),
+		minerTx:     &txEntry{hash: minerHash, tx: minerTx, blob: minerBlob, height: height},
+		outputsFrom: uint64(len(d.outputs)),
+	}
+	if height > 0 {
+		b.PrevHash = d.blocks[height-1].Hash
+	}
+
+	if b.Blob, err = encodeBlockHelper(b.Timestamp, b.PrevHash, b.Nonce, minerBlob, b.TxHashes); err != nil {
+		return nil, err
+	}
+	parsed, err := tx.ParseBlock(b.Blob)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBlockInvalid, err)
+	}
+	hash, err := parsed.Hash()
+	if err != nil {
+		return nil, err
+	}
+	b.Hash = hash.String()
+	if b.PrevHash == "" {
+		b.PrevHash = tx.Hash{}.String()
+	}
+
+	/** the global output indices are assigned in the order of the txs, starting with the miner tx **/
+	for _, t := range append([]*txEntry{b.minerTx}, d.txsHelper(b.TxHashes)...) {
+		t.inPool, t.height = false, height
+		t.outputIndices = make([]uint64, len(t.tx.Vout))
+		for i, v := range t.tx.Vout {
+			t.outputIndices[i] = uint64(len(d.outputs))
+
+			out := outputEntry{key: v.Key, txHash: t.hash, height: height}
+			if t.tx.RctSignatures != nil && i < len(t.tx.RctSignatures.OutPk) {
+				out.mask = t.tx.RctSignatures.OutPk[i]
+			}
+			d.outputs = append(d.outputs, out)
+		}
+	}
+
+	d.blocks = append(d.blocks, b)
+	d.heights[b.Hash] = height
+	d.txs[minerHash] = b.minerTx
+	d.pool = nil
+
+	return b, nil
+}
+
+func (d *Daemon) txsHelper(hashes []string) []*txEntry {
+	res := make([]*txEntry, len(hashes))
+	for i, v := range hashes {
+		res[i] = d.txs[v]
+	}
+
+	return res
+}
+
+// Mines a block with all the pool txs. The miner tx gets the outputs and the extra of the coinbase
+// (the rest of its fields are ignored), the nil coinbase gets a single output of the block reward.
+// Returns the block hash.
+func (d *Daemon) MineBlock(coinbase *Tx) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	b, err := d.mineBlockHelper(coinbase)
+	if err != nil {
+		return "", err
+	}
+
+	return b.Hash, nil
+}
+
+// Mines n blocks, the pool txs go to the first one. Returns the block hashes.
+func (d *Daemon) GenerateBlocks(n int) ([]string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	res := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		b, err := d.mineBlockHelper(nil)
+		if err != nil {
+			return res, err
+		}
+		res = append(res, b.Hash)
+	}
+
+	return res, nil
+}
+
+// Removes the top n blocks, their txs are returned to the pool as monerod does.
+// Mining new blocks afterwards simulates a reorg.
+func (d *Daemon) PopBlocks(n int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if n >= len(d.blocks) {
+		return ErrPopTooDeep
+	}
+
+	popped := d.blocks[len(d.blocks)-n:]
+	var txs []string
+	for _, b := range popped {
+		delete(d.heights, b.Hash)
+		delete(d.txs, b.MinerTxHash)
+		txs = append(txs, b.TxHashes...)
+	}
+	for _, t := range d.txsHelper(txs) {
+		t.inPool, t.height, t.outputIndices = true, 0, nil
+	}
+
+	d.blocks = d.blocks[:len(d.blocks)-n]
+	d.outputs = d.outputs[:popped[0].outputsFrom]
+	d.pool = append(txs, d.pool...)
+
+	return nil
+}
+
+// The number of the blocks in the chain
+func (d *Daemon) Height() uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return uint64(len(d.blocks))
+}
+
+// Returns the block at the height
+func (d *Daemon) Block(height uint64) (Block, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if height >= uint64(len(d.blocks)) {
+		return Block{}, false
+	}
+
+	b := *d.blocks[height]
+	b.TxHashes = append([]string(nil), b.TxHashes...)
+	b.Blob = append([]byte(nil), b.Blob...)
+
+	return b, true
+}
+
+// Returns the hashes of the pool txs in the order they've been added
+func (d *Daemon) Pool() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]string(nil), d.pool...)
+}
+
+/**
+	FAILURES
+**/
+
+// Makes the calls of the method fail. The method is the JSON RPC method or the endpoint without the leading slash
+// (e.g. "get_block", "get_transactions" or "get_blocks.bin"), the empty one matches all of them.
+// The failures of a method are applied in the order they've been added.
+func (d *Daemon) Fail(method string, f Failure) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.failures[method] = append(d.failures[method], &f)
+}
+
+func (d *Daemon) ClearFailures() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.failures = map[string][]*Failure{}
+}
+
+// Changes the delay of every response
+func (d *Daemon) SetLatency(latency time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.latency = latency
+}
+
+// The number of the calls of the method the mock has got, including the failed ones
+func (d *Daemon) Calls(method string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.calls[method]
+}
+
+// Counts the call and returns the failure to apply to it, if any
+func (d *Daemon) callHelper(method string) *Failure {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.calls[method]++
+	for _, m := range []string{method, ""} {
+		failures := d.failures[m]
+		if len(failures) == 0 {
+			continue
+		}
+
+		f := failures[0]
+		if f.Times > 0 {
+			if f.Times--; f.Times == 0 {
+				d.failures[m] = failures[1:]
+			}
+		}
+		res := *f
+		return &res
+	}
+
+	return nil
+}
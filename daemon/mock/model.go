@@ -0,0 +1,365 @@
+package mock
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/tx"
+)
+
+// Tx describes a tx added to the mock. Unless Blob is set, the tx is encoded as a version 2 Bulletproofs+/CLSAG tx
+// with zero proofs and signatures, which the decoders (and so the scanners) accept as a real one.
+// The empty keys, commitments and encrypted amounts are encoded as zeros.
+type Tx struct {
+	// The binary tx, e.g. one taken from a real chain. The rest of the fields are ignored if it's set.
+	Blob       []byte
+	UnlockTime uint64
+	Inputs     []Input
+	Outputs    []Output
+	Extra      []byte
+	Fee        uint64
+}
+
+type Input struct {
+	KeyImage string
+	// The relative offsets of the ring members, DEFAULT_RING_SIZE members by default
+	KeyOffsets []uint64
+}
+
+type Output struct {
+	Key string
+	// The tagged outputs have the view tag (1 byte hex), the untagged ones don't
+	ViewTag string
+	// The plain amount, the miner tx outputs only
+	Amount uint64
+	// The encrypted amount (8 bytes hex), the same as EcdhInfo.TruncAmount
+	EncryptedAmount string
+	// The amount commitment (outPk)
+	Commitment string
+}
+
+// Block is a block of the mock chain
+type Block struct {
+	Hash        string
+	PrevHash    string
+	Height      uint64
+	Timestamp   uint64
+	Nonce       uint32
+	Reward      uint64
+	MinerTxHash string
+	// The hashes of the txs of the block, not including the miner tx
+	TxHashes []string
+	Blob     []byte
+
+	minerTx *txEntry
+	// the global index of the first output of the block
+	outputsFrom uint64
+}
+
+type txEntry struct {
+	hash string
+	tx   *tx.Transaction
+	blob []byte
+	// the receive time in the pool
+	receiveTime uint64
+	doNotRelay  bool
+	// set once the tx is mined
+	height        uint64
+	inPool        bool
+	outputIndices []uint64
+}
+
+// A spendable output, indexed by its global index
+type outputEntry struct {
+	key    tx.Key
+	mask   tx.Key
+	txHash string
+	height uint64
+}
+
+func decodeHexHelper(str string, size int) ([]byte, error) {
+	if str == "" {
+		return make([]byte, size), nil
+	}
+
+	res, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTx, err)
+	}
+	if len(res) != size {
+		return nil, fmt.Errorf("%w: %q isn't %d bytes long", ErrInvalidTx, str, size)
+	}
+
+	return res, nil
+}
+
+func appendOutputHelper(blob []byte, out *Output) ([]byte, error) {
+	key, err := decodeHexHelper(out.Key, tx.KEY_SIZE)
+	if err != nil {
+		return nil, err
+	}
+
+	blob = binary.AppendUvarint(blob, out.Amount)
+	if out.ViewTag == "" {
+		blob = append(blob, tx.TXOUT_TO_KEY)
+		return append(blob, key...), nil
+	}
+
+	viewTag, err := decodeHexHelper(out.ViewTag, 1)
+	if err != nil {
+		return nil, err
+	}
+	blob = append(blob, tx.TXOUT_TO_TAGGED_KEY)
+	blob = append(blob, key...)
+
+	return append(blob, viewTag...), nil
+}
+
+func appendExtraHelper(blob []byte, extra []byte) []byte {
+	blob = binary.AppendUvarint(blob, uint64(len(extra)))
+	return append(blob, extra...)
+}
+
+// Returns the binary tx, e.g. to send it with send_raw_transaction
+func (t *Tx) Encode() ([]byte, error) {
+	if t.Blob != nil {
+		return t.Blob, nil
+	}
+
+	/** prefix **/
+	blob := binary.AppendUvarint(nil, 2)
+	blob = binary.AppendUvarint(blob, t.UnlockTime)
+
+	ringSize := DEFAULT_RING_SIZE
+	blob = binary.AppendUvarint(blob, uint64(len(t.Inputs)))
+	for i, in := range t.Inputs {
+		if in.KeyImage == "" {
+			return nil, fmt.Errorf("%w: input %d has no key image", ErrInvalidTx, i)
+		}
+		keyImage, err := decodeHexHelper(in.KeyImage, tx.KEY_SIZE)
+		if err != nil {
+			return nil, err
+		}
+
+		offsets := in.KeyOffsets
+		if len(offsets) == 0 {
+			offsets = make([]uint64, DEFAULT_RING_SIZE)
+			for j := range offsets {
+				offsets[j] = 1
+			}
+		}
+		// all the inputs of a RingCT tx have the same ring size
+		if i == 0 {
+			ringSize = len(offsets)
+		} else if len(offsets) != ringSize {
+			return nil, fmt.Errorf("%w: the inputs have different ring sizes", ErrInvalidTx)
+		}
+
+		blob = append(blob, tx.TXIN_TO_KEY)
+		blob = binary.AppendUvarint(blob, 0)
+		blob = binary.AppendUvarint(blob, uint64(len(offsets)))
+		for _, v := range offsets {
+			blob = binary.AppendUvarint(blob, v)
+		}
+		blob = append(blob, keyImage...)
+	}
+
+	var err error
+	blob = binary.AppendUvarint(blob, uint64(len(t.Outputs)))
+	for i := range t.Outputs {
+		if blob, err = appendOutputHelper(blob, &t.Outputs[i]); err != nil {
+			return nil, err
+		}
+	}
+	blob = appendExtraHelper(blob, t.Extra)
+
+	/** RingCT base **/
+	blob = append(blob, tx.RCT_TYPE_BULLETPROOF_PLUS)
+	blob = binary.AppendUvarint(blob, t.Fee)
+	for _, out := range t.Outputs {
+		amount, err := decodeHexHelper(out.EncryptedAmount, 8)
+		if err != nil {
+			return nil, err
+		}
+		blob = append(blob, amount...)
+	}
+	for _, out := range t.Outputs {
+		commitment, err := decodeHexHelper(out.Commitment, tx.KEY_SIZE)
+		if err != nil {
+			return nil, err
+		}
+		blob = append(blob, commitment...)
+	}
+
+	/** prunable: a single Bulletproof+ with empty L and R, the CLSAGs and the pseudo outs **/
+	blob = binary.AppendUvarint(blob, 1)
+	blob = append(blob, make([]byte, 6*tx.KEY_SIZE)...)
+	blob = append(blob, 0, 0)
+	for range t.Inputs {
+		blob = append(blob, make([]byte, (ringSize+2)*tx.KEY_SIZE)...)
+	}
+	blob = append(blob, make([]byte, len(t.Inputs)*tx.KEY_SIZE)...)
+
+	return blob, nil
+}
+
+// Encodes the miner tx of the block at the height
+func encodeMinerTxHelper(height uint64, outputs []Output, extra []byte) ([]byte, error) {
+	blob := binary.AppendUvarint(nil, 2)
+	blob = binary.AppendUvarint(blob, height+MINED_MONEY_UNLOCK_WINDOW)
+	blob = binary.AppendUvarint(blob, 1)
+	blob = append(blob, tx.TXIN_GEN)
+	blob = binary.AppendUvarint(blob, height)
+
+	var err error
+	blob = binary.AppendUvarint(blob, uint64(len(outputs)))
+	for i := range outputs {
+		if blob, err = appendOutputHelper(blob, &outputs[i]); err != nil {
+			return nil, err
+		}
+	}
+	blob = appendExtraHelper(blob, extra)
+
+	return append(blob, tx.RCT_TYPE_NULL), nil
+}
+
+func encodeBlockHelper(timestamp uint64, prevHash string, nonce uint32, minerTx []byte, txHashes []string) ([]byte, error) {
+	blob := binary.AppendUvarint(nil, BLOCK_MAJOR_VERSION)
+	blob = binary.AppendUvarint(blob, BLOCK_MINOR_VERSION)
+	blob = binary.AppendUvarint(blob, timestamp)
+
+	prev, err := decodeHexHelper(prevHash, tx.HASH_SIZE)
+	if err != nil {
+		return nil, err
+	}
+	blob = append(blob, prev...)
+	blob = binary.LittleEndian.AppendUint32(blob, nonce)
+	blob = append(blob, minerTx...)
+
+	blob = binary.AppendUvarint(blob, uint64(len(txHashes)))
+	for _, v := range txHashes {
+		hash, err := decodeHexHelper(v, tx.HASH_SIZE)
+		if err != nil {
+			return nil, err
+		}
+		blob = append(blob, hash...)
+	}
+
+	return blob, nil
+}
+
+/**
+	CONVERSIONS TO THE DAEMON MODEL
+**/
+
+func voutHelper(t *tx.Transaction) []daemon.Vout1 {
+	res := make([]daemon.Vout1, len(t.Vout))
+	for i, v := range t.Vout {
+		res[i].Amount = v.Amount
+		if v.ViewTag != nil {
+			res[i].Target.TaggedKey = daemon.TaggedKey{Key: v.Key.String(), ViewTag: hex.EncodeToString([]byte{*v.ViewTag})}
+		} else {
+			res[i].Target.Key = v.Key.String()
+		}
+	}
+
+	return res
+}
+
+func keysHelper(keys []tx.Key) []string {
+	res := make([]string, len(keys))
+	for i, v := range keys {
+		res[i] = v.String()
+	}
+
+	return res
+}
+
+func minerTxInfoHelper(t *tx.Transaction) daemon.MinerTx {
+	res := daemon.MinerTx{
+		Version:    uint32(t.Version),
+		UnlockTime: t.UnlockTime,
+		Vout:       voutHelper(t),
+		Extra:      make([]int32, len(t.Extra)),
+	}
+	for _, v := range t.Vin {
+		if v.Gen != nil {
+			res.Vin = append(res.Vin, daemon.Vin1{Gen: daemon.Gen{Height: v.Gen.Height}})
+		}
+	}
+	for i, v := range t.Extra {
+		res.Extra[i] = int32(v)
+	}
+	if t.RctSignatures != nil {
+		res.RctSignatures.Type = uint32(t.RctSignatures.Type)
+	}
+
+	return res
+}
+
+func txInfoHelper(t *tx.Transaction) daemon.MoneroTxInfo {
+	res := daemon.MoneroTxInfo{
+		Version:    uint32(t.Version),
+		UnlockTime: t.UnlockTime,
+		Vout:       voutHelper(t),
+		Extra:      t.Extra,
+	}
+	for _, v := range t.Vin {
+		if v.Key == nil {
+			continue
+		}
+
+		in := daemon.Vin2{Key: daemon.Key{Amount: v.Key.Amount, KeyImage: v.Key.KeyImage.String(), KeyOffsets: make([]int64, len(v.Key.KeyOffsets))}}
+		for i, o := range v.Key.KeyOffsets {
+			in.Key.KeyOffsets[i] = int64(o)
+		}
+		res.Vin = append(res.Vin, in)
+	}
+
+	rct := t.RctSignatures
+	if rct == nil {
+		return res
+	}
+
+	res.RctSignatures = daemon.RctSignature{Type: int32(rct.Type), TxnFee: rct.TxnFee, OutPk: keysHelper(rct.OutPk)}
+	for i, v := range rct.EcdhInfo {
+		if rct.Type >= tx.RCT_TYPE_BULLETPROOF2 {
+			res.RctSignatures.EcdhInfo = append(res.RctSignatures.EcdhInfo, daemon.EcdhInfo{TruncAmount: t.EncryptedAmount(i)})
+		} else {
+			res.RctSignatures.EcdhInfo = append(res.RctSignatures.EcdhInfo, daemon.EcdhInfo{Mask: v.Mask.String(), Amount: v.Amount.String()})
+		}
+	}
+
+	if p := rct.Prunable; p != nil {
+		res.RctsigPrunable.PseudoOuts = keysHelper(p.PseudoOuts)
+		for _, v := range p.CLSAGs {
+			res.RctsigPrunable.CLSAGs = append(res.RctsigPrunable.CLSAGs, daemon.CLSAG{D: v.D.String(), C1: v.C1.String(), S: keysHelper(v.S)})
+		}
+		for _, v := range p.BulletproofsPlus {
+			res.RctsigPrunable.Bpp = append(res.RctsigPrunable.Bpp, daemon.Bpp{
+				A: v.A.String(), A1: v.A1.String(), B: v.B.String(), R1: v.R1.String(), S1: v.S1.String(), D1: v.D1.String(),
+				L: keysHelper(v.L), R: keysHelper(v.R),
+			})
+		}
+		res.RctsigPrunable.Nbp = int32(len(p.BulletproofsPlus))
+	}
+
+	return res
+}
+
+// monerod writes the extra as an array of numbers, while encoding/json would write a []byte as base64
+type txJson struct {
+	daemon.MoneroTxInfo
+	Extra []uint16 `json:"extra"`
+}
+
+func newTxJsonHelper(t *tx.Transaction) *txJson {
+	res := &txJson{MoneroTxInfo: txInfoHelper(t), Extra: make([]uint16, len(t.Extra))}
+	for i, v := range t.Extra {
+		res.Extra[i] = uint16(v)
+	}
+
+	return res
+}
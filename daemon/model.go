@@ -1,17 +1,31 @@
 package daemon
 
-import "encoding/json"
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/chekist32/go-monero/daemon/epee"
+)
 
 const (
 	DEFAULT_MONERO_RPC_ENDPOINT = "/json_rpc"
+
+	RPC_STATUS_OK               = "OK"
+	RPC_STATUS_BUSY             = "BUSY"
+	RPC_STATUS_NOT_MINING       = "NOT MINING"
+	RPC_STATUS_PAYMENT_REQUIRED = "PAYMENT REQUIRED"
+	RPC_STATUS_FAILED           = "Failed"
 )
 
 type MoneroRpcResponse interface {
-	JsonRpcResponse | OtherRpcResponse
+	JsonRpcResponse | OtherRpcResponse | BinRpcResponse
 }
 
 type MoneroRpcRequestBody interface {
-	JsonRpcRequestBody | OtherRpcRequestBody
+	JsonRpcRequestBody | OtherRpcRequestBody | BinRpcRequestBody
 }
 
 type MoneroRpcRequest[T MoneroRpcRequestBody] struct {
@@ -19,16 +33,32 @@ type MoneroRpcRequest[T MoneroRpcRequestBody] struct {
 	Body     *T
 }
 
+// The error object of the JSON RPC responses (and of some of the other endpoints).
+// Method and HttpStatus aren't a part of the response, they are set by the client when the error is returned.
 type MoneroRpcError struct {
-	Code    int32  `json:"code"`
-	Message string `json:"message"`
+	Code       int32  `json:"code"`
+	Message    string `json:"message"`
+	Method     string `json:"-"`
+	HttpStatus int    `json:"-"`
 }
 
 type EmptyMoneroRpcParams struct{}
 
 func (e *MoneroRpcError) Error() string {
-	res, _ := json.Marshal(e)
-	return string(res)
+	if e.Method == "" {
+		return fmt.Sprintf("code %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s: code %d: %s", e.Method, e.Code, e.Message)
+}
+
+// Returned when the other or binary endpoints respond with a status other than RPC_STATUS_OK
+type RpcStatusError struct {
+	Endpoint string
+	Status   string
+}
+
+func (e *RpcStatusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Endpoint, e.Status)
 }
 
 /**
@@ -46,7 +76,21 @@ type JsonRpcRequestParams interface {
 		GetBlockHeaderByHeightParams |
 		GetBlockHeadersRangeParams |
 		GetBlockByHashParams |
-		GetBlockByHeightParams
+		GetBlockByHeightParams |
+		SetBansParams |
+		FlushTxpoolParams |
+		GetOutputHistogramParams |
+		GetCoinbaseTxSumParams |
+		RelayTxParams |
+		GetOutputDistributionParams |
+		CalcPowParams |
+		AddAuxPowParams |
+		PruneBlockchainParams |
+		BannedParams |
+		FlushCacheParams |
+		RpcAccessSubmitNonceParams |
+		RpcAccessPayParams |
+		RpcAccessAccountParams
 }
 
 type JsonRpcRequestBody interface {
@@ -60,7 +104,21 @@ type JsonRpcRequestBody interface {
 		JsonRpcGenericRequestBody[GetBlockByHeightParams] |
 		JsonRpcGenericRequestBody[GetBlockTemplateParams] |
 		JsonRpcGenericRequestBody[SubmitBlockParams] |
-		JsonRpcGenericRequestBody[GetBlockCountParams]
+		JsonRpcGenericRequestBody[GetBlockCountParams] |
+		JsonRpcGenericRequestBody[SetBansParams] |
+		JsonRpcGenericRequestBody[FlushTxpoolParams] |
+		JsonRpcGenericRequestBody[GetOutputHistogramParams] |
+		JsonRpcGenericRequestBody[GetCoinbaseTxSumParams] |
+		JsonRpcGenericRequestBody[RelayTxParams] |
+		JsonRpcGenericRequestBody[GetOutputDistributionParams] |
+		JsonRpcGenericRequestBody[CalcPowParams] |
+		JsonRpcGenericRequestBody[AddAuxPowParams] |
+		JsonRpcGenericRequestBody[PruneBlockchainParams] |
+		JsonRpcGenericRequestBody[BannedParams] |
+		JsonRpcGenericRequestBody[FlushCacheParams] |
+		JsonRpcGenericRequestBody[RpcAccessSubmitNonceParams] |
+		JsonRpcGenericRequestBody[RpcAccessPayParams] |
+		JsonRpcGenericRequestBody[RpcAccessAccountParams]
 }
 
 type JsonRpcResponseResult interface {
@@ -73,7 +131,29 @@ type JsonRpcResponseResult interface {
 		GetBlockResult |
 		GetFeeEstimateResult |
 		GetVersionResult |
-		GetInfoResult
+		GetInfoResult |
+		GetConnectionsResult |
+		GetBansResult |
+		SetBansResult |
+		FlushTxpoolResult |
+		GetOutputHistogramResult |
+		GetCoinbaseTxSumResult |
+		GetAlternateChainsResult |
+		RelayTxResult |
+		SyncInfoResult |
+		GetTxpoolBacklogResult |
+		GetOutputDistributionResult |
+		GetMinerDataResult |
+		CalcPowResult |
+		AddAuxPowResult |
+		PruneBlockchainResult |
+		HardForkInfoResult |
+		BannedResult |
+		FlushCacheResult |
+		RpcAccessInfoResult |
+		RpcAccessSubmitNonceResult |
+		RpcAccessPayResult |
+		RpcAccessAccountResult
 }
 
 type JsonRpcResponse interface {
@@ -86,7 +166,29 @@ type JsonRpcResponse interface {
 		JsonRpcGenericResponse[GetBlockResult] |
 		JsonRpcGenericResponse[GetFeeEstimateResult] |
 		JsonRpcGenericResponse[GetVersionResult] |
-		JsonRpcGenericResponse[GetInfoResult]
+		JsonRpcGenericResponse[GetInfoResult] |
+		JsonRpcGenericResponse[GetConnectionsResult] |
+		JsonRpcGenericResponse[GetBansResult] |
+		JsonRpcGenericResponse[SetBansResult] |
+		JsonRpcGenericResponse[FlushTxpoolResult] |
+		JsonRpcGenericResponse[GetOutputHistogramResult] |
+		JsonRpcGenericResponse[GetCoinbaseTxSumResult] |
+		JsonRpcGenericResponse[GetAlternateChainsResult] |
+		JsonRpcGenericResponse[RelayTxResult] |
+		JsonRpcGenericResponse[SyncInfoResult] |
+		JsonRpcGenericResponse[GetTxpoolBacklogResult] |
+		JsonRpcGenericResponse[GetOutputDistributionResult] |
+		JsonRpcGenericResponse[GetMinerDataResult] |
+		JsonRpcGenericResponse[CalcPowResult] |
+		JsonRpcGenericResponse[AddAuxPowResult] |
+		JsonRpcGenericResponse[PruneBlockchainResult] |
+		JsonRpcGenericResponse[HardForkInfoResult] |
+		JsonRpcGenericResponse[BannedResult] |
+		JsonRpcGenericResponse[FlushCacheResult] |
+		JsonRpcGenericResponse[RpcAccessInfoResult] |
+		JsonRpcGenericResponse[RpcAccessSubmitNonceResult] |
+		JsonRpcGenericResponse[RpcAccessPayResult] |
+		JsonRpcGenericResponse[RpcAccessAccountResult]
 }
 
 type JsonRpcHeader struct {
@@ -322,19 +424,406 @@ type GetInfoResult struct {
 	JsonRpcFooter
 }
 
+// get_connections
+type Connection struct {
+	Address           string `json:"address"`
+	AddressType       uint8  `json:"address_type"`
+	AvgDownload       uint64 `json:"avg_download"`
+	AvgUpload         uint64 `json:"avg_upload"`
+	ConnectionId      string `json:"connection_id"`
+	CurrentDownload   uint64 `json:"current_download"`
+	CurrentUpload     uint64 `json:"current_upload"`
+	Height            uint64 `json:"height"`
+	Host              string `json:"host"`
+	Incoming          bool   `json:"incoming"`
+	Ip                string `json:"ip"`
+	LiveTime          uint64 `json:"live_time"`
+	LocalIp           bool   `json:"local_ip"`
+	Localhost         bool   `json:"localhost"`
+	PeerId            string `json:"peer_id"`
+	Port              string `json:"port"`
+	PruningSeed       uint32 `json:"pruning_seed"`
+	RecvCount         uint64 `json:"recv_count"`
+	RecvIdleTime      uint64 `json:"recv_idle_time"`
+	RpcCreditsPerHash uint32 `json:"rpc_credits_per_hash"`
+	RpcPort           uint16 `json:"rpc_port"`
+	SendCount         uint64 `json:"send_count"`
+	SendIdleTime      uint64 `json:"send_idle_time"`
+	State             string `json:"state"`
+	SupportFlags      uint32 `json:"support_flags"`
+}
+type GetConnectionsResult struct {
+	Connections []Connection `json:"connections"`
+	JsonRpcFooter
+}
+
+// get_bans
+type Ban struct {
+	Host    string `json:"host"`
+	Ip      uint32 `json:"ip"`
+	Seconds uint32 `json:"seconds"`
+}
+type GetBansResult struct {
+	Bans []Ban `json:"bans"`
+	JsonRpcFooter
+}
+
+// set_bans
+// Either Host or Ip (an IPv4 address in the network byte order read as a little endian integer) must be set
+type SetBan struct {
+	Host    string `json:"host,omitempty"`
+	Ip      uint32 `json:"ip,omitempty"`
+	Ban     bool   `json:"ban"`
+	Seconds uint32 `json:"seconds"`
+}
+type SetBansParams struct {
+	Bans []SetBan `json:"bans"`
+}
+type SetBansResult struct {
+	JsonRpcFooter
+}
+
+// flush_txpool
+// All the txs are removed if TxIds is empty
+type FlushTxpoolParams struct {
+	TxIds []string `json:"txids,omitempty"`
+}
+type FlushTxpoolResult struct {
+	Status string `json:"status"`
+}
+
+// get_output_histogram
+type GetOutputHistogramParams struct {
+	Amounts      []uint64 `json:"amounts"`
+	MinCount     uint64   `json:"min_count"`
+	MaxCount     uint64   `json:"max_count"`
+	Unlocked     bool     `json:"unlocked"`
+	RecentCutoff uint64   `json:"recent_cutoff"`
+}
+type HistogramEntry struct {
+	Amount            uint64 `json:"amount"`
+	TotalInstances    uint64 `json:"total_instances"`
+	UnlockedInstances uint64 `json:"unlocked_instances"`
+	RecentInstances   uint64 `json:"recent_instances"`
+}
+type GetOutputHistogramResult struct {
+	Credits   uint64           `json:"credits"`
+	Histogram []HistogramEntry `json:"histogram"`
+	TopHash   string           `json:"top_hash"`
+	JsonRpcFooter
+}
+
+// get_coinbase_tx_sum
+type GetCoinbaseTxSumParams struct {
+	Height uint64 `json:"height"`
+	Count  uint64 `json:"count"`
+}
+type GetCoinbaseTxSumResult struct {
+	Credits             uint64 `json:"credits"`
+	EmissionAmount      uint64 `json:"emission_amount"`
+	EmissionAmountTop64 uint64 `json:"emission_amount_top64"`
+	FeeAmount           uint64 `json:"fee_amount"`
+	FeeAmountTop64      uint64 `json:"fee_amount_top64"`
+	TopHash             string `json:"top_hash"`
+	WideEmissionAmount  string `json:"wide_emission_amount"`
+	WideFeeAmount       string `json:"wide_fee_amount"`
+	JsonRpcFooter
+}
+
+// get_alternate_chains
+type AlternateChain struct {
+	BlockHash            string   `json:"block_hash"`
+	BlockHashes          []string `json:"block_hashes"`
+	Difficulty           uint64   `json:"difficulty"`
+	DifficultyTop64      uint64   `json:"difficulty_top64"`
+	Height               uint64   `json:"height"`
+	Length               uint64   `json:"length"`
+	MainChainParentBlock string   `json:"main_chain_parent_block"`
+	WideDifficulty       string   `json:"wide_difficulty"`
+}
+type GetAlternateChainsResult struct {
+	Chains []AlternateChain `json:"chains"`
+	JsonRpcFooter
+}
+
+// relay_tx
+type RelayTxParams struct {
+	TxIds []string `json:"txids"`
+}
+type RelayTxResult struct {
+	Status string `json:"status"`
+}
+
+// sync_info
+type SyncInfoPeer struct {
+	Info Connection `json:"info"`
+}
+type SyncInfoSpan struct {
+	ConnectionId     string `json:"connection_id"`
+	NBlocks          uint64 `json:"nblocks"`
+	Rate             uint32 `json:"rate"`
+	RemoteAddress    string `json:"remote_address"`
+	Size             uint64 `json:"size"`
+	Speed            uint32 `json:"speed"`
+	StartBlockHeight uint64 `json:"start_block_height"`
+}
+type SyncInfoResult struct {
+	Credits               uint64         `json:"credits"`
+	Height                uint64         `json:"height"`
+	NextNeededPruningSeed uint32         `json:"next_needed_pruning_seed"`
+	Overview              string         `json:"overview"`
+	Peers                 []SyncInfoPeer `json:"peers"`
+	Spans                 []SyncInfoSpan `json:"spans"`
+	TargetHeight          uint64         `json:"target_height"`
+	TopHash               string         `json:"top_hash"`
+	JsonRpcFooter
+}
+
+// get_txpool_backlog
+type TxBacklogEntry struct {
+	Weight     uint64
+	Fee        uint64
+	TimeInPool uint64
+}
+type GetTxpoolBacklogResult struct {
+	// the TxBacklogEntry values as a binary string, see Entries
+	Backlog string `json:"backlog"`
+	JsonRpcFooter
+}
+
+// Decodes the backlog, which the daemon sends as a binary string of little endian (weight, fee, time_in_pool) triples.
+// The daemon doesn't escape the bytes above 0x7f, so the backlog can't be recovered if they don't happen to form valid UTF-8.
+func (r *GetTxpoolBacklogResult) Entries() ([]TxBacklogEntry, error) {
+	if strings.ContainsRune(r.Backlog, utf8.RuneError) {
+		return nil, errors.New("the backlog has been mangled by the JSON encoding")
+	}
+
+	data := []byte(r.Backlog)
+	if len(data)%24 != 0 {
+		return nil, fmt.Errorf("invalid backlog size: %d", len(data))
+	}
+
+	res := make([]TxBacklogEntry, len(data)/24)
+	for i := range res {
+		entry := data[i*24:]
+		res[i] = TxBacklogEntry{
+			Weight:     binary.LittleEndian.Uint64(entry[0:8]),
+			Fee:        binary.LittleEndian.Uint64(entry[8:16]),
+			TimeInPool: binary.LittleEndian.Uint64(entry[16:24]),
+		}
+	}
+
+	return res, nil
+}
+
+// get_output_distribution
+// Binary is always false, the JSON-RPC can't carry the binary distributions
+type GetOutputDistributionParams struct {
+	Amounts    []uint64 `json:"amounts"`
+	Cumulative bool     `json:"cumulative"`
+	FromHeight uint64   `json:"from_height"`
+	ToHeight   uint64   `json:"to_height"`
+	Binary     bool     `json:"binary"`
+}
+type OutputDistribution struct {
+	Amount       uint64   `json:"amount"`
+	Base         uint64   `json:"base"`
+	Distribution []uint64 `json:"distribution"`
+	StartHeight  uint64   `json:"start_height"`
+	Binary       bool     `json:"binary"`
+	Compress     bool     `json:"compress"`
+}
+type GetOutputDistributionResult struct {
+	Credits       uint64               `json:"credits"`
+	Distributions []OutputDistribution `json:"distributions"`
+	TopHash       string               `json:"top_hash"`
+	JsonRpcFooter
+}
+
+// get_miner_data
+type MinerDataTxBacklogEntry struct {
+	Id     string `json:"id"`
+	Weight uint64 `json:"weight"`
+	Fee    uint64 `json:"fee"`
+}
+type GetMinerDataResult struct {
+	MajorVersion          uint8                     `json:"major_version"`
+	Height                uint64                    `json:"height"`
+	PrevId                string                    `json:"prev_id"`
+	SeedHash              string                    `json:"seed_hash"`
+	Difficulty            string                    `json:"difficulty"`
+	MedianWeight          uint64                    `json:"median_weight"`
+	AlreadyGeneratedCoins uint64                    `json:"already_generated_coins"`
+	TxBacklog             []MinerDataTxBacklogEntry `json:"tx_backlog"`
+	JsonRpcFooter
+}
+
+// calc_pow
+type CalcPowParams struct {
+	MajorVersion uint8  `json:"major_version"`
+	Height       uint64 `json:"height"`
+	BlockBlob    string `json:"block_blob"`
+	SeedHash     string `json:"seed_hash"`
+}
+type CalcPowResult string
+
+// add_aux_pow
+type AuxPow struct {
+	Id   string `json:"id"`
+	Hash string `json:"hash"`
+}
+type AddAuxPowParams struct {
+	BlocktemplateBlob string   `json:"blocktemplate_blob"`
+	AuxPow            []AuxPow `json:"aux_pow"`
+}
+type AddAuxPowResult struct {
+	BlocktemplateBlob string   `json:"blocktemplate_blob"`
+	BlockhashingBlob  string   `json:"blockhashing_blob"`
+	MerkleRoot        string   `json:"merkle_root"`
+	MerkleTreeDepth   uint64   `json:"merkle_tree_depth"`
+	AuxPow            []AuxPow `json:"aux_pow"`
+	JsonRpcFooter
+}
+
+// prune_blockchain
+// If Check is set, the daemon only reports whether the blockchain is pruned
+type PruneBlockchainParams struct {
+	Check bool `json:"check"`
+}
+type PruneBlockchainResult struct {
+	Pruned      bool   `json:"pruned"`
+	PruningSeed uint32 `json:"pruning_seed"`
+	JsonRpcFooter
+}
+
+// hard_fork_info
+type HardForkInfoResult struct {
+	Credits        uint64 `json:"credits"`
+	EarliestHeight uint64 `json:"earliest_height"`
+	Enabled        bool   `json:"enabled"`
+	State          uint32 `json:"state"`
+	Threshold      uint32 `json:"threshold"`
+	TopHash        string `json:"top_hash"`
+	Version        uint8  `json:"version"`
+	Votes          uint32 `json:"votes"`
+	Voting         uint8  `json:"voting"`
+	Window         uint32 `json:"window"`
+	JsonRpcFooter
+}
+
+// banned
+type BannedParams struct {
+	Address string `json:"address"`
+}
+type BannedResult struct {
+	Banned  bool   `json:"banned"`
+	Seconds uint32 `json:"seconds"`
+	Status  string `json:"status"`
+}
+
+// flush_cache
+type FlushCacheParams struct {
+	BadTxs    bool `json:"bad_txs"`
+	BadBlocks bool `json:"bad_blocks"`
+}
+type FlushCacheResult struct {
+	JsonRpcFooter
+}
+
+// The signature of the RPC payment methods (the client param) is attached by RpcPaymentClient
+
+// rpc_access_info
+type RpcAccessInfoResult struct {
+	Credits uint64 `json:"credits"`
+	TopHash string `json:"top_hash"`
+	// The block hashing blob to mine, the nonce is at the usual place
+	HashingBlob         string `json:"hashing_blob"`
+	SeedHeight          uint64 `json:"seed_height"`
+	SeedHash            string `json:"seed_hash"`
+	NextSeedHash        string `json:"next_seed_hash"`
+	Cookie              uint32 `json:"cookie"`
+	Diff                uint64 `json:"diff"`
+	CreditsPerHashFound uint64 `json:"credits_per_hash_found"`
+	Height              uint64 `json:"height"`
+	JsonRpcFooter
+}
+
+// rpc_access_submit_nonce
+type RpcAccessSubmitNonceParams struct {
+	Nonce uint32 `json:"nonce"`
+	// The cookie of the rpc_access_info response the hashing blob comes from
+	Cookie uint32 `json:"cookie"`
+}
+type RpcAccessSubmitNonceResult struct {
+	Credits uint64 `json:"credits"`
+	TopHash string `json:"top_hash"`
+	JsonRpcFooter
+}
+
+// rpc_access_pay
+// Spends the credits on the service named by PayingFor
+type RpcAccessPayParams struct {
+	PayingFor string `json:"paying_for"`
+	Payment   uint64 `json:"payment"`
+}
+type RpcAccessPayResult struct {
+	Credits uint64 `json:"credits"`
+	TopHash string `json:"top_hash"`
+	JsonRpcFooter
+}
+
+// rpc_access_account
+// DeltaBalance is added to the credits of the client, zero only queries them. Only available on the unrestricted RPC.
+type RpcAccessAccountParams struct {
+	DeltaBalance int64 `json:"delta_balance"`
+}
+type RpcAccessAccountResult struct {
+	Credits uint64 `json:"credits"`
+	JsonRpcFooter
+}
+
 /**
 	OTHER RPC METHODS
 **/
 
 type OtherRpcRequestBody interface {
 	EmptyMoneroRpcParams |
-		GetTransactionsParams
+		GetTransactionsParams |
+		SendRawTransactionParams |
+		IsKeyImageSpentParams |
+		SetLimitParams |
+		GetPeerListParams |
+		GetPublicNodesParams |
+		OutPeersParams |
+		InPeersParams |
+		StartMiningParams |
+		PopBlocksParams |
+		UpdateParams
 }
 
 type OtherRpcResponse interface {
 	GetHeightResponse |
 		GetTransactionPoolResponse |
-		GetTransactionsResponse
+		GetTransactionsResponse |
+		SendRawTransactionResponse |
+		IsKeyImageSpentResponse |
+		GetAltBlocksHashesResponse |
+		GetTransactionPoolHashesResponse |
+		GetTransactionPoolStatsResponse |
+		GetLimitResponse |
+		SetLimitResponse |
+		GetPeerListResponse |
+		GetPublicNodesResponse |
+		OutPeersResponse |
+		InPeersResponse |
+		StartMiningResponse |
+		StopMiningResponse |
+		MiningStatusResponse |
+		SaveBcResponse |
+		GetNetStatsResponse |
+		PopBlocksResponse |
+		UpdateResponse |
+		StopDaemonResponse
 }
 
 // get_height
@@ -364,7 +853,7 @@ type EcdhInfo struct {
 	TruncAmount string `json:"trunc_amount"`
 }
 type RctSignature struct {
-	Type     int32      `type:"txnFee"`
+	Type     int32      `json:"type"`
 	TxnFee   uint64     `json:"txnFee"`
 	EcdhInfo []EcdhInfo `json:"ecdhInfo"`
 	OutPk    []string   `json:"outPk"`
@@ -458,3 +947,436 @@ type GetTransactionsResponse struct {
 	Error    MoneroRpcError `json:"error"`
 	JsonRpcFooter
 }
+
+// send_raw_transaction
+type SendRawTransactionParams struct {
+	TxAsHex        string `json:"tx_as_hex"`
+	DoNotRelay     bool   `json:"do_not_relay"`
+	DoSanityChecks bool   `json:"do_sanity_checks"`
+}
+type SendRawTransactionResponse struct {
+	Credits           uint64         `json:"credits"`
+	DoubleSpend       bool           `json:"double_spend"`
+	FeeTooLow         bool           `json:"fee_too_low"`
+	InvalidInput      bool           `json:"invalid_input"`
+	InvalidOutput     bool           `json:"invalid_output"`
+	LowMixin          bool           `json:"low_mixin"`
+	NonzeroUnlockTime bool           `json:"nonzero_unlock_time"`
+	NotRct            bool           `json:"not_rct"`
+	NotRelayed        bool           `json:"not_relayed"`
+	Overspend         bool           `json:"overspend"`
+	Reason            string         `json:"reason"`
+	SanityCheckFailed bool           `json:"sanity_check_failed"`
+	TooBig            bool           `json:"too_big"`
+	TooFewOutputs     bool           `json:"too_few_outputs"`
+	TopHash           string         `json:"top_hash"`
+	TxExtraTooBig     bool           `json:"tx_extra_too_big"`
+	Error             MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// is_key_image_spent
+type KeyImageSpentStatus uint32
+
+const (
+	KeyImageUnspent KeyImageSpentStatus = iota
+	KeyImageSpentInBlockchain
+	KeyImageSpentInPool
+)
+
+type IsKeyImageSpentParams struct {
+	KeyImages []string `json:"key_images"`
+}
+type IsKeyImageSpentResponse struct {
+	Credits uint64 `json:"credits"`
+	// an entry for each of the requested key images in the same order
+	SpentStatus []KeyImageSpentStatus `json:"spent_status"`
+	TopHash     string                `json:"top_hash"`
+	Error       MoneroRpcError        `json:"error"`
+	JsonRpcFooter
+}
+
+// get_alt_blocks_hashes
+type GetAltBlocksHashesResponse struct {
+	BlksHashes []string       `json:"blks_hashes"`
+	Credits    uint64         `json:"credits"`
+	TopHash    string         `json:"top_hash"`
+	Error      MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// get_transaction_pool_hashes
+type GetTransactionPoolHashesResponse struct {
+	Credits  uint64         `json:"credits"`
+	TopHash  string         `json:"top_hash"`
+	TxHashes []string       `json:"tx_hashes"`
+	Error    MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// get_transaction_pool_stats
+type TxPoolHisto struct {
+	Txs   uint32 `json:"txs"`
+	Bytes uint64 `json:"bytes"`
+}
+type TxPoolStats struct {
+	BytesMax        uint32        `json:"bytes_max"`
+	BytesMed        uint32        `json:"bytes_med"`
+	BytesMin        uint32        `json:"bytes_min"`
+	BytesTotal      uint64        `json:"bytes_total"`
+	FeeTotal        uint64        `json:"fee_total"`
+	Histo           []TxPoolHisto `json:"histo"`
+	Histo98pc       uint64        `json:"histo_98pc"`
+	Num10m          uint32        `json:"num_10m"`
+	NumDoubleSpends uint32        `json:"num_double_spends"`
+	NumFailing      uint32        `json:"num_failing"`
+	NumNotRelayed   uint32        `json:"num_not_relayed"`
+	Oldest          uint64        `json:"oldest"`
+	TxsTotal        uint32        `json:"txs_total"`
+}
+type GetTransactionPoolStatsResponse struct {
+	Credits   uint64         `json:"credits"`
+	PoolStats TxPoolStats    `json:"pool_stats"`
+	TopHash   string         `json:"top_hash"`
+	Error     MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// get_limit
+// The limits are in kB/s
+type GetLimitResponse struct {
+	LimitDown uint64         `json:"limit_down"`
+	LimitUp   uint64         `json:"limit_up"`
+	Error     MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// set_limit
+// -1 resets a limit to the default value, 0 leaves it unchanged
+type SetLimitParams struct {
+	LimitDown int64 `json:"limit_down"`
+	LimitUp   int64 `json:"limit_up"`
+}
+type SetLimitResponse struct {
+	LimitDown int64          `json:"limit_down"`
+	LimitUp   int64          `json:"limit_up"`
+	Error     MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// get_peer_list
+type GetPeerListParams struct {
+	PublicOnly     bool `json:"public_only"`
+	IncludeBlocked bool `json:"include_blocked"`
+}
+type Peer struct {
+	Host              string `json:"host"`
+	Id                uint64 `json:"id"`
+	Ip                uint32 `json:"ip"`
+	LastSeen          uint64 `json:"last_seen"`
+	Port              uint16 `json:"port"`
+	PruningSeed       uint32 `json:"pruning_seed"`
+	RpcCreditsPerHash uint32 `json:"rpc_credits_per_hash"`
+	RpcPort           uint16 `json:"rpc_port"`
+}
+type GetPeerListResponse struct {
+	GrayList  []Peer         `json:"gray_list"`
+	WhiteList []Peer         `json:"white_list"`
+	Error     MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// get_public_nodes
+type GetPublicNodesParams struct {
+	Gray           bool `json:"gray"`
+	White          bool `json:"white"`
+	IncludeBlocked bool `json:"include_blocked"`
+}
+type PublicNode struct {
+	Host              string `json:"host"`
+	LastSeen          uint64 `json:"last_seen"`
+	RpcCreditsPerHash uint32 `json:"rpc_credits_per_hash"`
+	RpcPort           uint16 `json:"rpc_port"`
+}
+type GetPublicNodesResponse struct {
+	Gray  []PublicNode   `json:"gray"`
+	White []PublicNode   `json:"white"`
+	Error MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// out_peers
+type OutPeersParams struct {
+	Set      bool   `json:"set"`
+	OutPeers uint32 `json:"out_peers"`
+}
+type OutPeersResponse struct {
+	OutPeers uint32         `json:"out_peers"`
+	Error    MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// in_peers
+type InPeersParams struct {
+	Set     bool   `json:"set"`
+	InPeers uint32 `json:"in_peers"`
+}
+type InPeersResponse struct {
+	InPeers uint32         `json:"in_peers"`
+	Error   MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// start_mining
+type StartMiningParams struct {
+	DoBackgroundMining bool   `json:"do_background_mining"`
+	IgnoreBattery      bool   `json:"ignore_battery"`
+	MinerAddress       string `json:"miner_address"`
+	ThreadsCount       uint64 `json:"threads_count"`
+}
+type StartMiningResponse struct {
+	Error MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// stop_mining
+type StopMiningResponse struct {
+	Error MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// mining_status
+type MiningStatusResponse struct {
+	Active                    bool           `json:"active"`
+	Address                   string         `json:"address"`
+	BgIdleThreshold           uint8          `json:"bg_idle_threshold"`
+	BgIgnoreBattery           bool           `json:"bg_ignore_battery"`
+	BgMinIdleSeconds          uint8          `json:"bg_min_idle_seconds"`
+	BgTarget                  uint8          `json:"bg_target"`
+	BlockReward               uint64         `json:"block_reward"`
+	BlockTarget               uint32         `json:"block_target"`
+	Difficulty                uint64         `json:"difficulty"`
+	DifficultyTop64           uint64         `json:"difficulty_top64"`
+	IsBackgroundMiningEnabled bool           `json:"is_background_mining_enabled"`
+	PowAlgorithm              string         `json:"pow_algorithm"`
+	Speed                     uint64         `json:"speed"`
+	ThreadsCount              uint32         `json:"threads_count"`
+	WideDifficulty            string         `json:"wide_difficulty"`
+	Error                     MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// save_bc
+type SaveBcResponse struct {
+	Error MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// get_net_stats
+type GetNetStatsResponse struct {
+	StartTime       uint64         `json:"start_time"`
+	TotalBytesIn    uint64         `json:"total_bytes_in"`
+	TotalBytesOut   uint64         `json:"total_bytes_out"`
+	TotalPacketsIn  uint64         `json:"total_packets_in"`
+	TotalPacketsOut uint64         `json:"total_packets_out"`
+	Error           MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// pop_blocks
+type PopBlocksParams struct {
+	NBlocks uint64 `json:"nblocks"`
+}
+type PopBlocksResponse struct {
+	Height uint64         `json:"height"`
+	Error  MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// update
+// Command is either "check" or "download"
+type UpdateParams struct {
+	Command string `json:"command"`
+	Path    string `json:"path,omitempty"`
+}
+type UpdateResponse struct {
+	AutoUri string         `json:"auto_uri"`
+	Hash    string         `json:"hash"`
+	Path    string         `json:"path"`
+	Update  bool           `json:"update"`
+	UserUri string         `json:"user_uri"`
+	Version string         `json:"version"`
+	Error   MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+// stop_daemon
+type StopDaemonResponse struct {
+	Error MoneroRpcError `json:"error"`
+	JsonRpcFooter
+}
+
+/**
+	BINARY RPC METHODS
+**/
+
+type BinRpcRequestBody interface {
+	GetBlocksBinParams |
+		GetBlocksByHeightBinParams |
+		GetHashesBinParams |
+		GetOIndexesBinParams |
+		GetOutsBinParams
+}
+
+type BinRpcResponse interface {
+	GetBlocksBinResponse |
+		GetBlocksByHeightBinResponse |
+		GetHashesBinResponse |
+		GetOIndexesBinResponse |
+		GetOutsBinResponse
+}
+
+// The signature of the RPC payments, set by RpcPaymentClient (the JSON requests get it as well)
+type RpcAccessRequest struct {
+	Client string `epee:"client,omitempty"`
+}
+
+type BinRpcFooter struct {
+	Credits   uint64 `epee:"credits"`
+	Status    string `epee:"status"`
+	TopHash   string `epee:"top_hash"`
+	Untrusted bool   `epee:"untrusted"`
+}
+
+// get_blocks.bin
+// BlockIds is the chain history the daemon looks for the split point in (the latest ids first, the genesis id last)
+type GetBlocksBinParams struct {
+	BlockIds    []string `epee:"block_ids,hex"`
+	StartHeight uint64   `epee:"start_height"`
+	Prune       bool     `epee:"prune"`
+	NoMinerTx   bool     `epee:"no_miner_tx,omitempty"`
+	RpcAccessRequest
+}
+
+// PrunableHash is only set for the pruned txs
+type TxBlobEntry struct {
+	Blob         []byte `epee:"blob"`
+	PrunableHash string `epee:"prunable_hash,hex"`
+}
+type BlockCompleteEntry struct {
+	Pruned      bool          `epee:"pruned"`
+	Block       []byte        `epee:"block"`
+	BlockWeight uint64        `epee:"block_weight"`
+	Txs         []TxBlobEntry `epee:"txs"`
+}
+
+// The txs of the not pruned blocks are sent as plain blobs, not as TxBlobEntry objects
+type blockCompleteEntryPlain struct {
+	Pruned      bool     `epee:"pruned"`
+	Block       []byte   `epee:"block"`
+	BlockWeight uint64   `epee:"block_weight"`
+	Txs         [][]byte `epee:"txs"`
+}
+type blockCompleteEntryPruned BlockCompleteEntry
+
+func (e BlockCompleteEntry) MarshalEpee() (any, error) {
+	if e.Pruned {
+		return blockCompleteEntryPruned(e), nil
+	}
+
+	res := blockCompleteEntryPlain{Block: e.Block, BlockWeight: e.BlockWeight, Txs: make([][]byte, len(e.Txs))}
+	for i := range e.Txs {
+		res.Txs[i] = e.Txs[i].Blob
+	}
+
+	return res, nil
+}
+
+func (e *BlockCompleteEntry) UnmarshalEpee(v any) error {
+	if s, ok := v.(epee.Section); ok {
+		if pruned, _ := s["pruned"].(bool); pruned {
+			return epee.UnmarshalValue(v, (*blockCompleteEntryPruned)(e))
+		}
+	}
+
+	var res blockCompleteEntryPlain
+	if err := epee.UnmarshalValue(v, &res); err != nil {
+		return err
+	}
+
+	*e = BlockCompleteEntry{Block: res.Block, BlockWeight: res.BlockWeight, Txs: make([]TxBlobEntry, len(res.Txs))}
+	for i := range res.Txs {
+		e.Txs[i].Blob = res.Txs[i]
+	}
+
+	return nil
+}
+
+type TxOutputIndices struct {
+	Indices []uint64 `epee:"indices"`
+}
+type BlockOutputIndices struct {
+	Indices []TxOutputIndices `epee:"indices"`
+}
+type GetBlocksBinResponse struct {
+	Blocks        []BlockCompleteEntry `epee:"blocks"`
+	StartHeight   uint64               `epee:"start_height"`
+	CurrentHeight uint64               `epee:"current_height"`
+	OutputIndices []BlockOutputIndices `epee:"output_indices"`
+	BinRpcFooter
+}
+
+// get_blocks_by_height.bin
+type GetBlocksByHeightBinParams struct {
+	Heights []uint64 `epee:"heights"`
+	RpcAccessRequest
+}
+type GetBlocksByHeightBinResponse struct {
+	Blocks []BlockCompleteEntry `epee:"blocks"`
+	BinRpcFooter
+}
+
+// get_hashes.bin
+type GetHashesBinParams struct {
+	BlockIds    []string `epee:"block_ids,hex"`
+	StartHeight uint64   `epee:"start_height"`
+	RpcAccessRequest
+}
+type GetHashesBinResponse struct {
+	MBlockIds     []string `epee:"m_block_ids,hex"`
+	StartHeight   uint64   `epee:"start_height"`
+	CurrentHeight uint64   `epee:"current_height"`
+	BinRpcFooter
+}
+
+// get_o_indexes.bin
+type GetOIndexesBinParams struct {
+	TxId string `epee:"txid,hex"`
+	RpcAccessRequest
+}
+type GetOIndexesBinResponse struct {
+	OIndexes []uint64 `epee:"o_indexes"`
+	BinRpcFooter
+}
+
+// get_outs.bin
+type GetOutputsOut struct {
+	Amount uint64 `epee:"amount"`
+	Index  uint64 `epee:"index"`
+}
+type GetOutsBinParams struct {
+	Outputs []GetOutputsOut `epee:"outputs"`
+	GetTxId bool            `epee:"get_txid"`
+	RpcAccessRequest
+}
+type OutKeyBin struct {
+	Key      string `epee:"key,hex"`
+	Mask     string `epee:"mask,hex"`
+	Unlocked bool   `epee:"unlocked"`
+	Height   uint64 `epee:"height"`
+	TxId     string `epee:"txid,hex"`
+}
+type GetOutsBinResponse struct {
+	Outs []OutKeyBin `epee:"outs"`
+	BinRpcFooter
+}
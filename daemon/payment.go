@@ -0,0 +1,528 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon/epee"
+	"github.com/chekist32/go-monero/utils"
+)
+
+const (
+	// The client param: the public key, the timestamp and the signature in hex
+	RPC_PAYMENT_TIMESTAMP_SIZE int = 16
+	RPC_PAYMENT_CLIENT_SIZE    int = 2*32 + RPC_PAYMENT_TIMESTAMP_SIZE + 2*64
+	// How far the timestamp of the signature can be from the daemon clock (TIMESTAMP_LEEWAY)
+	RPC_PAYMENT_TIMESTAMP_LEEWAY time.Duration = 60 * time.Second
+
+	DEFAULT_RPC_PAYMENT_MAX_HASHES uint64 = 1 << 20
+)
+
+var (
+	ErrNoRpcPaymentKey    = errors.New("the client has no rpc payment key")
+	ErrNoRpcPaymentFunder = errors.New("the client has no rpc payment funder")
+	ErrNoPowHashFunc      = errors.New("no pow hash function")
+	ErrInvalidHashingBlob = errors.New("invalid hashing blob")
+	// Returned by RpcPaymentMiner when no hash of the required difficulty is found within MaxHashes
+	ErrRpcPaymentNotFunded = errors.New("no hash of the required difficulty was found")
+)
+
+/**
+	SIGNATURE
+**/
+
+func makeRpcPaymentSignatureHelper(key *utils.PrivateKey, ts uint64) (string, error) {
+	/** client - 208 hex chars
+		public key - 32 bytes
+		timestamp - microseconds, 16 hex chars, signed as text
+		signature - 64 bytes
+	**/
+	tsHex := fmt.Sprintf("%016x", ts)
+	hash, err := utils.Keccak256Hash([]byte(tsHex))
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := utils.GenerateSignature(hash, key)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(utils.GetPublicKeyFromPrivate(key).Bytes()) + tsHex + hex.EncodeToString(sig), nil
+}
+
+// Makes the client param of the RPC payment requests, the daemon identifies the account by the public key of the key
+func MakeRpcPaymentSignature(key *utils.PrivateKey, ts time.Time) (string, error) {
+	return makeRpcPaymentSignatureHelper(key, uint64(ts.UnixMicro()))
+}
+
+// Checks the signature of the client param and returns the public key (in hex) and the timestamp.
+// The timestamp isn't checked against the clock.
+func VerifyRpcPaymentSignature(client string) (string, time.Time, error) {
+	if len(client) != RPC_PAYMENT_CLIENT_SIZE {
+		return "", time.Time{}, ErrInvalidClient
+	}
+
+	pubHex := client[:64]
+	tsHex := client[64 : 64+RPC_PAYMENT_TIMESTAMP_SIZE]
+
+	pub, err := utils.NewPublicKey(pubHex)
+	if err != nil {
+		return "", time.Time{}, ErrInvalidClient
+	}
+	ts, err := strconv.ParseUint(tsHex, 16, 64)
+	if err != nil {
+		return "", time.Time{}, ErrInvalidClient
+	}
+	sig, err := hex.DecodeString(client[64+RPC_PAYMENT_TIMESTAMP_SIZE:])
+	if err != nil {
+		return "", time.Time{}, ErrInvalidClient
+	}
+
+	hash, err := utils.Keccak256Hash([]byte(tsHex))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if !utils.CheckSignature(hash, pub, sig) {
+		return "", time.Time{}, ErrInvalidClient
+	}
+
+	return pubHex, time.UnixMicro(int64(ts)), nil
+}
+
+/**
+	CREDITS
+**/
+
+// Tops up the credits of the client when they run low, e.g. RpcPaymentMiner or an out-of-band payment to the node operator.
+// info is a fresh rpc_access_info of the client.
+type RpcPaymentFunder interface {
+	Fund(ctx context.Context, client IDaemonRpcClient, info *RpcAccessInfoResult) error
+}
+
+type RpcPaymentFunderFunc func(ctx context.Context, client IDaemonRpcClient, info *RpcAccessInfoResult) error
+
+func (f RpcPaymentFunderFunc) Fund(ctx context.Context, client IDaemonRpcClient, info *RpcAccessInfoResult) error {
+	return f(ctx, client, info)
+}
+
+type RpcPaymentConfig struct {
+	// The key the requests are signed with, the credits belong to its public key. A random one is generated if nil.
+	Key *utils.PrivateKey
+	// Tops up the credits, the requests are only signed if nil
+	Funder RpcPaymentFunder
+	// The credits are topped up before a request once the balance reported by the daemon drops below it.
+	// Regardless of it, they are topped up when the daemon responds with PAYMENT REQUIRED and the request is repeated once.
+	MinCredits uint64
+}
+
+type rpcPaymentState struct {
+	config RpcPaymentConfig
+	// the timestamps of the signatures must grow, the daemon rejects the reused ones
+	lastTs  atomic.Uint64
+	credits atomic.Uint64
+	// set once the daemon turns out to charge for the requests, the balance is only watched from then on
+	paying atomic.Bool
+	// serializes the top ups
+	mu sync.Mutex
+}
+
+func (s *rpcPaymentState) signHelper() (string, error) {
+	for {
+		last := s.lastTs.Load()
+		ts := uint64(time.Now().UnixMicro())
+		if ts <= last {
+			ts = last + 1
+		}
+		if s.lastTs.CompareAndSwap(last, ts) {
+			return makeRpcPaymentSignatureHelper(s.config.Key, ts)
+		}
+	}
+}
+
+// The JSON RPC methods with the positional params (on_get_block_hash, submit_block) can't carry the client param
+func positionalParamsHelper(data []byte) bool {
+	var body struct {
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return false
+	}
+
+	raw := bytes.TrimSpace(body.Params)
+	return len(raw) > 0 && raw[0] != '{'
+}
+
+// Adds the client param to the JSON body, to the params of the JSON RPC ones.
+// The positional params (e.g. on_get_block_hash) can't carry it, those requests are sent as is, as well as all of them without the payments.
+func (s *rpcPaymentState) signJsonHelper(data []byte, jsonRpc bool) ([]byte, error) {
+	if s == nil {
+		return data, nil
+	}
+
+	body := map[string]json.RawMessage{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, err
+		}
+	}
+
+	target := body
+	if jsonRpc {
+		if positionalParamsHelper(data) {
+			return data, nil
+		}
+
+		raw := bytes.TrimSpace(body["params"])
+		target = map[string]json.RawMessage{}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &target); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	client, err := s.signHelper()
+	if err != nil {
+		return nil, err
+	}
+	target["client"], _ = json.Marshal(client)
+
+	if jsonRpc {
+		params, err := json.Marshal(target)
+		if err != nil {
+			return nil, err
+		}
+		body["params"] = params
+	}
+
+	return json.Marshal(body)
+}
+
+// Implemented by the binary request bodies through the embedded RpcAccessRequest
+type rpcAccessRequest interface {
+	setRpcPaymentClient(client string)
+}
+
+func (r *RpcAccessRequest) setRpcPaymentClient(client string) {
+	r.Client = client
+}
+
+// Sets the client param of the binary body and encodes it again, data is the body encoded without it
+func (s *rpcPaymentState) signBinHelper(body any, data []byte) ([]byte, error) {
+	req, ok := body.(rpcAccessRequest)
+	if s == nil || !ok {
+		return data, nil
+	}
+
+	client, err := s.signHelper()
+	if err != nil {
+		return nil, err
+	}
+	req.setRpcPaymentClient(client)
+
+	return epee.Marshal(body)
+}
+
+// Finds the credits and the PAYMENT REQUIRED error or status of the response,
+// they are either on the top level or in the result of the JSON RPC ones
+func rpcPaymentInfoHelper(res any) (credits uint64, hasCredits bool, paymentRequired bool) {
+	v := reflect.ValueOf(res)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return 0, false, false
+	}
+	v = v.Elem()
+
+	if f := v.FieldByName("Error"); f.IsValid() && f.Type() == moneroRpcErrorType {
+		paymentRequired = f.Interface().(MoneroRpcError).Code == RPC_ERROR_CODE_PAYMENT_REQUIRED
+	}
+	if f := v.FieldByName("Result"); f.IsValid() && f.Kind() == reflect.Struct {
+		v = f
+	}
+	if f := v.FieldByName("Status"); f.IsValid() && f.Kind() == reflect.String && f.String() == RPC_STATUS_PAYMENT_REQUIRED {
+		paymentRequired = true
+	}
+	if f := v.FieldByName("Credits"); f.IsValid() && f.Kind() == reflect.Uint64 {
+		credits, hasCredits = f.Uint(), true
+	}
+
+	return credits, hasCredits, paymentRequired
+}
+
+func (s *rpcPaymentState) updateHelper(res any) {
+	if s == nil {
+		return
+	}
+
+	credits, ok, paymentRequired := rpcPaymentInfoHelper(res)
+	if ok {
+		s.credits.Store(credits)
+	}
+	if paymentRequired || credits > 0 {
+		s.paying.Store(true)
+	}
+}
+
+func (s *rpcPaymentState) lowHelper() bool {
+	return s.paying.Load() && s.credits.Load() < s.config.MinCredits
+}
+
+func (s *rpcPaymentState) resetHelper() {
+	s.credits.Store(0)
+	s.paying.Store(false)
+}
+
+// Runs the funder. Unless forced, it is skipped if the credits have been topped up by another request meanwhile.
+func (s *rpcPaymentState) topUpHelper(ctx context.Context, c *DaemonRpcClient, force bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !force && !s.lowHelper() {
+		return nil
+	}
+	s.paying.Store(true)
+
+	info, err := c.RpcAccessInfoCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if !force && info.Result.Credits >= s.config.MinCredits {
+		return nil
+	}
+
+	return s.config.Funder.Fund(ctx, c, &info.Result)
+}
+
+// Tops up the credits before the request if they are low, and after the PAYMENT REQUIRED response, in which case the request is repeated once.
+// The RPC payment methods themselves are never paid for, neither are the requests that can't be signed,
+// as the credits wouldn't help them anyway.
+func doWithPaymentHelper[R any](ctx context.Context, c *DaemonRpcClient, method string, signable bool, call func() (*R, error)) (*R, error) {
+	s := c.payment
+	if s == nil || s.config.Funder == nil || !signable || strings.HasPrefix(method, "rpc_access_") {
+		return call()
+	}
+
+	if s.lowHelper() {
+		if err := s.topUpHelper(ctx, c, false); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := call()
+	if err != nil {
+		return nil, err
+	}
+	if _, _, paymentRequired := rpcPaymentInfoHelper(res); !paymentRequired {
+		return res, nil
+	}
+
+	if err := s.topUpHelper(ctx, c, true); err != nil {
+		return nil, err
+	}
+
+	return call()
+}
+
+// RpcPaymentClient is a client for the daemons with the RPC payments enabled (--rpc-payment-address).
+// It signs the requests and keeps the credits topped up with RpcPaymentConfig.Funder.
+// OnGetBlockHash and SubmitBlock can't be paid for, as their params are positional and can't carry the signature,
+// they are sent unsigned and fail with PAYMENT REQUIRED if the daemon charges for them.
+// The credits belong to the daemon, so they are reset when the connection is changed.
+type RpcPaymentClient struct {
+	IDaemonRpcClient
+	client *DaemonRpcClient
+}
+
+func NewRpcPaymentClient(connection *RpcConnection, config DaemonRpcClientConfig, payment RpcPaymentConfig) (*RpcPaymentClient, error) {
+	if payment.Key == nil {
+		key, err := utils.NewRandomPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		payment.Key = key
+	}
+
+	client, err := newDaemonRpcClientHelper(connection, config)
+	if err != nil {
+		return nil, err
+	}
+	client.payment = &rpcPaymentState{config: payment}
+
+	return &RpcPaymentClient{client, client}, nil
+}
+
+// The last balance reported by the daemon
+func (c *RpcPaymentClient) Credits() uint64 {
+	return c.client.payment.credits.Load()
+}
+
+// Identifies the account of the client on the daemon
+func (c *RpcPaymentClient) PublicKey() *utils.PublicKey {
+	return utils.GetPublicKeyFromPrivate(c.client.payment.config.Key)
+}
+
+// The batch calls are signed and paid for as well
+func (c *RpcPaymentClient) NewBatch() *Batch {
+	return c.client.NewBatch()
+}
+
+// Runs the funder right away
+func (c *RpcPaymentClient) TopUp(ctx context.Context) error {
+	if c.client.payment.config.Funder == nil {
+		return ErrNoRpcPaymentFunder
+	}
+
+	return c.client.payment.topUpHelper(ctx, c.client, true)
+}
+
+/**
+	MINING
+**/
+
+// Computes the PoW hash of the hashing blob. It is RandomX with info.SeedHash as the key since v12.
+type PowHashFunc func(blob []byte, info *RpcAccessInfoResult) ([]byte, error)
+
+// RpcPaymentMiner is an RpcPaymentFunder that earns the credits by mining the hashing blob of rpc_access_info.
+// There is no RandomX implementation in the library, so the hash function is provided by the caller (e.g. a binding of librandomx).
+type RpcPaymentMiner struct {
+	Hash PowHashFunc
+	// Mining stops once the credits reach it, after the first found hash if zero
+	TargetCredits uint64
+	// The number of the hashes tried per top up, DEFAULT_RPC_PAYMENT_MAX_HASHES if zero
+	MaxHashes uint64
+}
+
+// Tells whether the hash (little endian) meets the difficulty, i.e. hash * difficulty < 2^256
+func CheckPowHash(hash []byte, difficulty uint64) bool {
+	be := make([]byte, len(hash))
+	for i, v := range hash {
+		be[len(hash)-1-i] = v
+	}
+
+	res := new(big.Int).SetBytes(be)
+	res.Mul(res, new(big.Int).SetUint64(difficulty))
+
+	return res.BitLen() <= 256
+}
+
+// The nonce follows the major and the minor versions, the timestamp (varints) and the previous block id
+func blockNonceOffsetHelper(blob []byte) (int, error) {
+	offset := 0
+	for i := 0; i < 3; i++ {
+		_, n := binary.Uvarint(blob[offset:])
+		if n <= 0 {
+			return 0, ErrInvalidHashingBlob
+		}
+		offset += n
+	}
+	offset += 32
+
+	if len(blob) < offset+4 {
+		return 0, ErrInvalidHashingBlob
+	}
+
+	return offset, nil
+}
+
+func parseHashingBlobHelper(info *RpcAccessInfoResult) ([]byte, int, error) {
+	blob, err := hex.DecodeString(info.HashingBlob)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrInvalidHashingBlob, err)
+	}
+
+	offset, err := blockNonceOffsetHelper(blob)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return blob, offset, nil
+}
+
+func (m *RpcPaymentMiner) Fund(ctx context.Context, client IDaemonRpcClient, info *RpcAccessInfoResult) error {
+	if m.Hash == nil {
+		return ErrNoPowHashFunc
+	}
+	maxHashes := m.MaxHashes
+	if maxHashes == 0 {
+		maxHashes = DEFAULT_RPC_PAYMENT_MAX_HASHES
+	}
+
+	blob, offset, err := parseHashingBlobHelper(info)
+	if err != nil {
+		return err
+	}
+	refresh := func() error {
+		res, err := client.RpcAccessInfoCtx(ctx)
+		if err != nil {
+			return err
+		}
+		info = &res.Result
+		blob, offset, err = parseHashingBlobHelper(info)
+		return err
+	}
+
+	// the daemon rejects the nonces submitted twice, the random start keeps the clients with the same key apart
+	var buf [4]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return err
+	}
+	nonce := binary.LittleEndian.Uint32(buf[:])
+
+	found := false
+	for i := uint64(0); i < maxHashes; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		nonce++
+		binary.LittleEndian.PutUint32(blob[offset:], nonce)
+		hash, err := m.Hash(blob, info)
+		if err != nil {
+			return err
+		}
+		if !CheckPowHash(hash, info.Diff) {
+			continue
+		}
+
+		res, err := client.RpcAccessSubmitNonceCtx(ctx, nonce, info.Cookie)
+		if errors.Is(err, ErrStalePayment) {
+			// a new block, the hashing blob has changed
+			if err := refresh(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		found = true
+		if res.Result.Credits >= m.TargetCredits {
+			return nil
+		}
+		if res.Result.TopHash != info.TopHash {
+			if err := refresh(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !found {
+		return ErrRpcPaymentNotFunded
+	}
+
+	return nil
+}
@@ -0,0 +1,1019 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chekist32/go-monero/utils"
+)
+
+const (
+	DEFAULT_POOL_MAX_HEIGHT_LAG        uint64        = 2
+	DEFAULT_POOL_HEALTH_CHECK_INTERVAL time.Duration = 30 * time.Second
+	DEFAULT_POOL_HEALTH_CHECK_TIMEOUT  time.Duration = 10 * time.Second
+	poolLatencySmoothing               int64         = 4
+)
+
+var (
+	ErrEmptyPool       = errors.New("the pool has no connections")
+	ErrAllNodesFailed  = errors.New("all the pool nodes failed")
+	ErrNettypeMismatch = errors.New("the node runs on a different network")
+	ErrNotSynchronized = errors.New("the node isn't synchronized")
+	ErrNodeOffline     = errors.New("the node is offline")
+	ErrNodeLagging     = errors.New("the node is behind the other nodes")
+)
+
+// Defines the order the pool nodes are tried in
+type PoolStrategy int
+
+const (
+	// The nodes are tried in the order they were passed to NewDaemonRpcPool
+	PoolStrategyPriority PoolStrategy = iota
+	// Each call starts from the node next to the one the previous call started from
+	PoolStrategyRoundRobin
+	// The nodes are tried from the fastest to the slowest one, the latency is measured by the health checks and the calls
+	PoolStrategyLowestLatency
+)
+
+type PoolNodeState int
+
+const (
+	// The node hasn't been checked yet, or it has recovered from a failure since the last check
+	PoolNodeUnknown PoolNodeState = iota
+	PoolNodeHealthy
+	// The node is synchronized, but more than MaxHeightLag blocks behind the best node of the pool
+	PoolNodeLagging
+	// The node failed the last health check or call
+	PoolNodeUnavailable
+)
+
+// The zero values of the fields are replaced with the defaults.
+type PoolConfig struct {
+	Strategy PoolStrategy
+	// The network the nodes must run on ("mainnet", "stagenet", "testnet"), any if empty
+	Nettype             string
+	MaxHeightLag        uint64
+	HealthCheckInterval time.Duration
+	HealthCheckTimeout  time.Duration
+	// The options of the node clients
+	Client DaemonRpcClientConfig
+}
+
+type PoolNodeStatus struct {
+	Host    string
+	State   PoolNodeState
+	Height  uint64
+	Latency time.Duration
+	// The reason the node is unavailable or lagging
+	Err error
+}
+
+type poolNode struct {
+	client IDaemonRpcClient
+	host   string
+
+	state   PoolNodeState
+	height  uint64
+	latency time.Duration
+	err     error
+}
+
+// DaemonRpcPool is an IDaemonRpcClient that spreads the calls over several daemons.
+// The unavailable and lagging nodes are routed around, and the calls are retried on the next node when a node fails with a transport error.
+// Only the idempotent methods are retried after a transport error, the others (send_raw_transaction, relay_tx, submit_block, pop_blocks, etc.)
+// might have been executed by the failed node, so its error is returned. The busy nodes are skipped for all the methods.
+// The errors returned by the daemon itself (MoneroRpcError, RpcStatusError) are passed to the caller as is.
+type DaemonRpcPool struct {
+	mu     sync.Mutex
+	nodes  []*poolNode
+	config PoolConfig
+	next   int
+	retry  *utils.RetryPolicy
+}
+
+func NewDaemonRpcPool(connections []*RpcConnection, config PoolConfig) (*DaemonRpcPool, error) {
+	if len(connections) == 0 {
+		return nil, ErrEmptyPool
+	}
+
+	if config.MaxHeightLag == 0 {
+		config.MaxHeightLag = DEFAULT_POOL_MAX_HEIGHT_LAG
+	}
+	if config.HealthCheckInterval == 0 {
+		config.HealthCheckInterval = DEFAULT_POOL_HEALTH_CHECK_INTERVAL
+	}
+	if config.HealthCheckTimeout == 0 {
+		config.HealthCheckTimeout = DEFAULT_POOL_HEALTH_CHECK_TIMEOUT
+	}
+
+	if _, err := config.Client.transportHelper(); err != nil {
+		return nil, err
+	}
+
+	p := &DaemonRpcPool{config: config, retry: config.Client.RetryPolicy}
+	p.setNodesHelper(connections)
+
+	return p, nil
+}
+
+func (p *DaemonRpcPool) setNodesHelper(connections []*RpcConnection) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	nodes := make([]*poolNode, len(connections))
+	for i, v := range connections {
+		// the config has been validated by NewDaemonRpcPool
+		client, _ := NewDaemonRpcClientWithConfig(v, p.config.Client)
+		nodes[i] = &poolNode{client: client, host: v.host.Host}
+		nodes[i].client.SetRetryPolicy(p.retry)
+	}
+	p.nodes = nodes
+	p.next = 0
+}
+
+// Replaces all the pool nodes with the single connection
+func (p *DaemonRpcPool) SetRpcConnection(connection *RpcConnection) {
+	p.setNodesHelper([]*RpcConnection{connection})
+}
+
+// The policy applies to each node separately, so a call is retried on the same node before failing over to the next one
+func (p *DaemonRpcPool) SetRetryPolicy(policy *utils.RetryPolicy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.retry = policy
+	for _, v := range p.nodes {
+		v.client.SetRetryPolicy(policy)
+	}
+}
+
+// Returns the state of the nodes in the order they were passed to NewDaemonRpcPool
+func (p *DaemonRpcPool) Status() []PoolNodeStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	res := make([]PoolNodeStatus, len(p.nodes))
+	for i, v := range p.nodes {
+		res[i] = PoolNodeStatus{v.host, v.state, v.height, v.latency, v.err}
+	}
+
+	return res
+}
+
+// Must be called with the mutex held
+func (p *DaemonRpcPool) updateLatencyHelper(n *poolNode, latency time.Duration) {
+	if n.latency == 0 {
+		n.latency = latency
+		return
+	}
+	n.latency = time.Duration((int64(n.latency)*(poolLatencySmoothing-1) + int64(latency)) / poolLatencySmoothing)
+}
+
+// Queries get_info of all the nodes concurrently and updates their states.
+// The nodes that are unreachable, not synchronized, offline or on another network become unavailable,
+// the ones that are more than MaxHeightLag blocks behind the highest node become lagging.
+func (p *DaemonRpcPool) CheckHealth(ctx context.Context) {
+	p.mu.Lock()
+	nodes := p.nodes
+	p.mu.Unlock()
+
+	type checkResult struct {
+		height  uint64
+		latency time.Duration
+		err     error
+	}
+	results := make([]checkResult, len(nodes))
+
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(i int, n *poolNode) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, p.config.HealthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			res, err := n.client.GetInfoCtx(ctx)
+			results[i].latency = time.Since(start)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+
+			info := &res.Result
+			results[i].height = info.Height
+			switch {
+			case info.Offline:
+				results[i].err = ErrNodeOffline
+			case !info.Synchronized:
+				results[i].err = ErrNotSynchronized
+			case p.config.Nettype != "" && info.Nettype != p.config.Nettype:
+				results[i].err = fmt.Errorf("%w: %s", ErrNettypeMismatch, info.Nettype)
+			}
+		}(i, n)
+	}
+	wg.Wait()
+
+	var best uint64
+	for _, v := range results {
+		if v.err == nil && v.height > best {
+			best = v.height
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, n := range nodes {
+		r := results[i]
+		n.height = r.height
+		n.err = r.err
+
+		switch {
+		case r.err != nil:
+			n.state = PoolNodeUnavailable
+		case r.height+p.config.MaxHeightLag < best:
+			n.state = PoolNodeLagging
+			n.err = fmt.Errorf("%w: height %d, best %d", ErrNodeLagging, r.height, best)
+		default:
+			n.state = PoolNodeHealthy
+		}
+
+		if r.err == nil {
+			p.updateLatencyHelper(n, r.latency)
+		}
+	}
+}
+
+// Runs the health checks every HealthCheckInterval until the ctx is done.
+// The first check is run immediately.
+func (p *DaemonRpcPool) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		p.CheckHealth(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Returns the nodes in the order they should be tried in.
+// The unknown and healthy nodes are ordered by the strategy and are followed by the lagging and then the unavailable ones,
+// so the calls still have a chance to succeed when the states are outdated.
+func (p *DaemonRpcPool) candidatesHelper() []*poolNode {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var usable, lagging, unavailable []*poolNode
+	for _, v := range p.nodes {
+		switch v.state {
+		case PoolNodeLagging:
+			lagging = append(lagging, v)
+		case PoolNodeUnavailable:
+			unavailable = append(unavailable, v)
+		default:
+			usable = append(usable, v)
+		}
+	}
+
+	switch p.config.Strategy {
+	case PoolStrategyRoundRobin:
+		if len(usable) > 0 {
+			start := p.next % len(usable)
+			usable = append(append([]*poolNode(nil), usable[start:]...), usable[:start]...)
+		}
+		p.next++
+	case PoolStrategyLowestLatency:
+		sort.SliceStable(usable, func(i, j int) bool { return usable[i].latency < usable[j].latency })
+	}
+
+	return append(append(usable, lagging...), unavailable...)
+}
+
+// The daemon errors mean the node is alive, so the call isn't repeated on the other nodes, unless the node is busy.
+// The same goes for the 4xx HTTP statuses but 429: wrong credentials or a restricted method are returned to the caller.
+func isFailoverErrorHelper(err error) bool {
+	if errors.Is(err, ErrDaemonBusy) {
+		return true
+	}
+
+	var httpErr *utils.HttpStatusError
+	if errors.As(err, &httpErr) {
+		return utils.IsTransientError(err)
+	}
+
+	var rpcErr *MoneroRpcError
+	var statusErr *RpcStatusError
+	return !errors.As(err, &rpcErr) && !errors.As(err, &statusErr)
+}
+
+// Calls the nodes one by one until one of them answers. Only the idempotent methods are repeated on the next node after a transport error,
+// the others might have been executed by the node that failed, so its error is returned.
+func doPoolHelper[R any](ctx context.Context, p *DaemonRpcPool, method string, call func(c IDaemonRpcClient) (R, error)) (R, error) {
+	var res R
+
+	nodes := p.candidatesHelper()
+	if len(nodes) == 0 {
+		return res, ErrEmptyPool
+	}
+
+	var err error
+	for _, n := range nodes {
+		start := time.Now()
+		res, err = call(n.client)
+		latency := time.Since(start)
+
+		if err == nil || !isFailoverErrorHelper(err) {
+			p.mu.Lock()
+			p.updateLatencyHelper(n, latency)
+			if n.state == PoolNodeUnavailable {
+				n.state = PoolNodeUnknown
+				n.err = nil
+			}
+			p.mu.Unlock()
+
+			return res, err
+		}
+		if ctx.Err() != nil {
+			return res, err
+		}
+
+		p.mu.Lock()
+		n.state = PoolNodeUnavailable
+		n.err = err
+		p.mu.Unlock()
+
+		// a busy node hasn't executed the call
+		if !idempotentMethods[method] && !errors.Is(err, ErrDaemonBusy) {
+			return res, err
+		}
+	}
+
+	return res, fmt.Errorf("%w: %w", ErrAllNodesFailed, err)
+}
+
+/**
+	JSON RPC METHODS
+**/
+
+// get_block_count
+func (p *DaemonRpcPool) GetBlockCount() (*JsonRpcGenericResponse[GetBlockCountResult], error) {
+	return p.GetBlockCountCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetBlockCountCtx(ctx context.Context) (*JsonRpcGenericResponse[GetBlockCountResult], error) {
+	return doPoolHelper(ctx, p, "get_block_count", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBlockCountResult], error) {
+		return c.GetBlockCountCtx(ctx)
+	})
+}
+
+// on_get_block_hash
+func (p *DaemonRpcPool) OnGetBlockHash(height uint64) (*JsonRpcGenericResponse[OnGetBlockHashResult], error) {
+	return p.OnGetBlockHashCtx(context.Background(), height)
+}
+
+func (p *DaemonRpcPool) OnGetBlockHashCtx(ctx context.Context, height uint64) (*JsonRpcGenericResponse[OnGetBlockHashResult], error) {
+	return doPoolHelper(ctx, p, "on_get_block_hash", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[OnGetBlockHashResult], error) {
+		return c.OnGetBlockHashCtx(ctx, height)
+	})
+}
+
+// get_block_template
+func (p *DaemonRpcPool) GetBlockTemplate(wallet string, reverseSize uint64) (*JsonRpcGenericResponse[GetBlockTemplateResult], error) {
+	return p.GetBlockTemplateCtx(context.Background(), wallet, reverseSize)
+}
+
+func (p *DaemonRpcPool) GetBlockTemplateCtx(ctx context.Context, wallet string, reverseSize uint64) (*JsonRpcGenericResponse[GetBlockTemplateResult], error) {
+	return doPoolHelper(ctx, p, "get_block_template", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBlockTemplateResult], error) {
+		return c.GetBlockTemplateCtx(ctx, wallet, reverseSize)
+	})
+}
+
+// submit_block
+func (p *DaemonRpcPool) SubmitBlock(blobData []string) (*JsonRpcGenericResponse[SubmitBlockResult], error) {
+	return p.SubmitBlockCtx(context.Background(), blobData)
+}
+
+func (p *DaemonRpcPool) SubmitBlockCtx(ctx context.Context, blobData []string) (*JsonRpcGenericResponse[SubmitBlockResult], error) {
+	return doPoolHelper(ctx, p, "submit_block", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[SubmitBlockResult], error) {
+		return c.SubmitBlockCtx(ctx, blobData)
+	})
+}
+
+// get_last_block_header
+func (p *DaemonRpcPool) GetLastBlockHeader(fillPowHash bool) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return p.GetLastBlockHeaderCtx(context.Background(), fillPowHash)
+}
+
+func (p *DaemonRpcPool) GetLastBlockHeaderCtx(ctx context.Context, fillPowHash bool) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return doPoolHelper(ctx, p, "get_last_block_header", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+		return c.GetLastBlockHeaderCtx(ctx, fillPowHash)
+	})
+}
+
+// get_block_header_by_hash
+func (p *DaemonRpcPool) GetBlockHeaderByHash(fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return p.GetBlockHeaderByHashCtx(context.Background(), fillPowHash, hash)
+}
+
+func (p *DaemonRpcPool) GetBlockHeaderByHashCtx(ctx context.Context, fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return doPoolHelper(ctx, p, "get_block_header_by_hash", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+		return c.GetBlockHeaderByHashCtx(ctx, fillPowHash, hash)
+	})
+}
+
+// get_block_header_by_height
+func (p *DaemonRpcPool) GetBlockHeaderByHeight(fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return p.GetBlockHeaderByHeightCtx(context.Background(), fillPowHash, height)
+}
+
+func (p *DaemonRpcPool) GetBlockHeaderByHeightCtx(ctx context.Context, fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return doPoolHelper(ctx, p, "get_block_header_by_height", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+		return c.GetBlockHeaderByHeightCtx(ctx, fillPowHash, height)
+	})
+}
+
+// get_block_headers_range
+func (p *DaemonRpcPool) GetBlockHeadersRange(fillPowHash bool, startHeight uint64, endHeight uint64) (*JsonRpcGenericResponse[GetBlockHeadersRangeResult], error) {
+	return p.GetBlockHeadersRangeCtx(context.Background(), fillPowHash, startHeight, endHeight)
+}
+
+func (p *DaemonRpcPool) GetBlockHeadersRangeCtx(ctx context.Context, fillPowHash bool, startHeight uint64, endHeight uint64) (*JsonRpcGenericResponse[GetBlockHeadersRangeResult], error) {
+	return doPoolHelper(ctx, p, "get_block_headers_range", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBlockHeadersRangeResult], error) {
+		return c.GetBlockHeadersRangeCtx(ctx, fillPowHash, startHeight, endHeight)
+	})
+}
+
+// get_block
+func (p *DaemonRpcPool) GetBlockByHeight(fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockResult], error) {
+	return p.GetBlockByHeightCtx(context.Background(), fillPowHash, height)
+}
+
+func (p *DaemonRpcPool) GetBlockByHeightCtx(ctx context.Context, fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockResult], error) {
+	return doPoolHelper(ctx, p, "get_block", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBlockResult], error) {
+		return c.GetBlockByHeightCtx(ctx, fillPowHash, height)
+	})
+}
+
+// get_block
+func (p *DaemonRpcPool) GetBlockByHash(fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockResult], error) {
+	return p.GetBlockByHashCtx(context.Background(), fillPowHash, hash)
+}
+
+func (p *DaemonRpcPool) GetBlockByHashCtx(ctx context.Context, fillPowHash bool, hash string) (*JsonRpcGenericResponse[GetBlockResult], error) {
+	return doPoolHelper(ctx, p, "get_block", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBlockResult], error) {
+		return c.GetBlockByHashCtx(ctx, fillPowHash, hash)
+	})
+}
+
+// get_fee_estimate
+func (p *DaemonRpcPool) GetFeeEstimate() (*JsonRpcGenericResponse[GetFeeEstimateResult], error) {
+	return p.GetFeeEstimateCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetFeeEstimateCtx(ctx context.Context) (*JsonRpcGenericResponse[GetFeeEstimateResult], error) {
+	return doPoolHelper(ctx, p, "get_fee_estimate", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetFeeEstimateResult], error) {
+		return c.GetFeeEstimateCtx(ctx)
+	})
+}
+
+// get_version
+func (p *DaemonRpcPool) GetVersion() (*JsonRpcGenericResponse[GetVersionResult], error) {
+	return p.GetVersionCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetVersionCtx(ctx context.Context) (*JsonRpcGenericResponse[GetVersionResult], error) {
+	return doPoolHelper(ctx, p, "get_version", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetVersionResult], error) {
+		return c.GetVersionCtx(ctx)
+	})
+}
+
+// get_info
+func (p *DaemonRpcPool) GetInfo() (*JsonRpcGenericResponse[GetInfoResult], error) {
+	return p.GetInfoCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[GetInfoResult], error) {
+	return doPoolHelper(ctx, p, "get_info", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetInfoResult], error) { return c.GetInfoCtx(ctx) })
+}
+
+// get_connections
+func (p *DaemonRpcPool) GetConnections() (*JsonRpcGenericResponse[GetConnectionsResult], error) {
+	return p.GetConnectionsCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetConnectionsCtx(ctx context.Context) (*JsonRpcGenericResponse[GetConnectionsResult], error) {
+	return doPoolHelper(ctx, p, "get_connections", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetConnectionsResult], error) {
+		return c.GetConnectionsCtx(ctx)
+	})
+}
+
+// get_bans
+func (p *DaemonRpcPool) GetBans() (*JsonRpcGenericResponse[GetBansResult], error) {
+	return p.GetBansCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetBansCtx(ctx context.Context) (*JsonRpcGenericResponse[GetBansResult], error) {
+	return doPoolHelper(ctx, p, "get_bans", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBansResult], error) { return c.GetBansCtx(ctx) })
+}
+
+// set_bans
+func (p *DaemonRpcPool) SetBans(bans []SetBan) (*JsonRpcGenericResponse[SetBansResult], error) {
+	return p.SetBansCtx(context.Background(), bans)
+}
+
+func (p *DaemonRpcPool) SetBansCtx(ctx context.Context, bans []SetBan) (*JsonRpcGenericResponse[SetBansResult], error) {
+	return doPoolHelper(ctx, p, "set_bans", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[SetBansResult], error) {
+		return c.SetBansCtx(ctx, bans)
+	})
+}
+
+// flush_txpool
+func (p *DaemonRpcPool) FlushTxpool(txIds []string) (*JsonRpcGenericResponse[FlushTxpoolResult], error) {
+	return p.FlushTxpoolCtx(context.Background(), txIds)
+}
+
+func (p *DaemonRpcPool) FlushTxpoolCtx(ctx context.Context, txIds []string) (*JsonRpcGenericResponse[FlushTxpoolResult], error) {
+	return doPoolHelper(ctx, p, "flush_txpool", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[FlushTxpoolResult], error) {
+		return c.FlushTxpoolCtx(ctx, txIds)
+	})
+}
+
+// get_output_histogram
+func (p *DaemonRpcPool) GetOutputHistogram(amounts []uint64, minCount uint64, maxCount uint64, unlocked bool, recentCutoff uint64) (*JsonRpcGenericResponse[GetOutputHistogramResult], error) {
+	return p.GetOutputHistogramCtx(context.Background(), amounts, minCount, maxCount, unlocked, recentCutoff)
+}
+
+func (p *DaemonRpcPool) GetOutputHistogramCtx(ctx context.Context, amounts []uint64, minCount uint64, maxCount uint64, unlocked bool, recentCutoff uint64) (*JsonRpcGenericResponse[GetOutputHistogramResult], error) {
+	return doPoolHelper(ctx, p, "get_output_histogram", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetOutputHistogramResult], error) {
+		return c.GetOutputHistogramCtx(ctx, amounts, minCount, maxCount, unlocked, recentCutoff)
+	})
+}
+
+// get_coinbase_tx_sum
+func (p *DaemonRpcPool) GetCoinbaseTxSum(height uint64, count uint64) (*JsonRpcGenericResponse[GetCoinbaseTxSumResult], error) {
+	return p.GetCoinbaseTxSumCtx(context.Background(), height, count)
+}
+
+func (p *DaemonRpcPool) GetCoinbaseTxSumCtx(ctx context.Context, height uint64, count uint64) (*JsonRpcGenericResponse[GetCoinbaseTxSumResult], error) {
+	return doPoolHelper(ctx, p, "get_coinbase_tx_sum", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetCoinbaseTxSumResult], error) {
+		return c.GetCoinbaseTxSumCtx(ctx, height, count)
+	})
+}
+
+// get_alternate_chains
+func (p *DaemonRpcPool) GetAlternateChains() (*JsonRpcGenericResponse[GetAlternateChainsResult], error) {
+	return p.GetAlternateChainsCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetAlternateChainsCtx(ctx context.Context) (*JsonRpcGenericResponse[GetAlternateChainsResult], error) {
+	return doPoolHelper(ctx, p, "get_alternate_chains", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetAlternateChainsResult], error) {
+		return c.GetAlternateChainsCtx(ctx)
+	})
+}
+
+// relay_tx
+func (p *DaemonRpcPool) RelayTx(txIds []string) (*JsonRpcGenericResponse[RelayTxResult], error) {
+	return p.RelayTxCtx(context.Background(), txIds)
+}
+
+func (p *DaemonRpcPool) RelayTxCtx(ctx context.Context, txIds []string) (*JsonRpcGenericResponse[RelayTxResult], error) {
+	return doPoolHelper(ctx, p, "relay_tx", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[RelayTxResult], error) {
+		return c.RelayTxCtx(ctx, txIds)
+	})
+}
+
+// sync_info
+func (p *DaemonRpcPool) SyncInfo() (*JsonRpcGenericResponse[SyncInfoResult], error) {
+	return p.SyncInfoCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) SyncInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[SyncInfoResult], error) {
+	return doPoolHelper(ctx, p, "sync_info", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[SyncInfoResult], error) { return c.SyncInfoCtx(ctx) })
+}
+
+// get_txpool_backlog
+func (p *DaemonRpcPool) GetTxpoolBacklog() (*JsonRpcGenericResponse[GetTxpoolBacklogResult], error) {
+	return p.GetTxpoolBacklogCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetTxpoolBacklogCtx(ctx context.Context) (*JsonRpcGenericResponse[GetTxpoolBacklogResult], error) {
+	return doPoolHelper(ctx, p, "get_txpool_backlog", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetTxpoolBacklogResult], error) {
+		return c.GetTxpoolBacklogCtx(ctx)
+	})
+}
+
+// get_output_distribution
+func (p *DaemonRpcPool) GetOutputDistribution(amounts []uint64, cumulative bool, fromHeight uint64, toHeight uint64) (*JsonRpcGenericResponse[GetOutputDistributionResult], error) {
+	return p.GetOutputDistributionCtx(context.Background(), amounts, cumulative, fromHeight, toHeight)
+}
+
+func (p *DaemonRpcPool) GetOutputDistributionCtx(ctx context.Context, amounts []uint64, cumulative bool, fromHeight uint64, toHeight uint64) (*JsonRpcGenericResponse[GetOutputDistributionResult], error) {
+	return doPoolHelper(ctx, p, "get_output_distribution", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetOutputDistributionResult], error) {
+		return c.GetOutputDistributionCtx(ctx, amounts, cumulative, fromHeight, toHeight)
+	})
+}
+
+// get_miner_data
+func (p *DaemonRpcPool) GetMinerData() (*JsonRpcGenericResponse[GetMinerDataResult], error) {
+	return p.GetMinerDataCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetMinerDataCtx(ctx context.Context) (*JsonRpcGenericResponse[GetMinerDataResult], error) {
+	return doPoolHelper(ctx, p, "get_miner_data", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[GetMinerDataResult], error) {
+		return c.GetMinerDataCtx(ctx)
+	})
+}
+
+// calc_pow
+func (p *DaemonRpcPool) CalcPow(majorVersion uint8, height uint64, blockBlob string, seedHash string) (*JsonRpcGenericResponse[CalcPowResult], error) {
+	return p.CalcPowCtx(context.Background(), majorVersion, height, blockBlob, seedHash)
+}
+
+func (p *DaemonRpcPool) CalcPowCtx(ctx context.Context, majorVersion uint8, height uint64, blockBlob string, seedHash string) (*JsonRpcGenericResponse[CalcPowResult], error) {
+	return doPoolHelper(ctx, p, "calc_pow", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[CalcPowResult], error) {
+		return c.CalcPowCtx(ctx, majorVersion, height, blockBlob, seedHash)
+	})
+}
+
+// add_aux_pow
+func (p *DaemonRpcPool) AddAuxPow(blocktemplateBlob string, auxPow []AuxPow) (*JsonRpcGenericResponse[AddAuxPowResult], error) {
+	return p.AddAuxPowCtx(context.Background(), blocktemplateBlob, auxPow)
+}
+
+func (p *DaemonRpcPool) AddAuxPowCtx(ctx context.Context, blocktemplateBlob string, auxPow []AuxPow) (*JsonRpcGenericResponse[AddAuxPowResult], error) {
+	return doPoolHelper(ctx, p, "add_aux_pow", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[AddAuxPowResult], error) {
+		return c.AddAuxPowCtx(ctx, blocktemplateBlob, auxPow)
+	})
+}
+
+// prune_blockchain
+func (p *DaemonRpcPool) PruneBlockchain(check bool) (*JsonRpcGenericResponse[PruneBlockchainResult], error) {
+	return p.PruneBlockchainCtx(context.Background(), check)
+}
+
+func (p *DaemonRpcPool) PruneBlockchainCtx(ctx context.Context, check bool) (*JsonRpcGenericResponse[PruneBlockchainResult], error) {
+	return doPoolHelper(ctx, p, "prune_blockchain", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[PruneBlockchainResult], error) {
+		return c.PruneBlockchainCtx(ctx, check)
+	})
+}
+
+// hard_fork_info
+func (p *DaemonRpcPool) HardForkInfo() (*JsonRpcGenericResponse[HardForkInfoResult], error) {
+	return p.HardForkInfoCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) HardForkInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[HardForkInfoResult], error) {
+	return doPoolHelper(ctx, p, "hard_fork_info", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[HardForkInfoResult], error) {
+		return c.HardForkInfoCtx(ctx)
+	})
+}
+
+// banned
+func (p *DaemonRpcPool) Banned(address string) (*JsonRpcGenericResponse[BannedResult], error) {
+	return p.BannedCtx(context.Background(), address)
+}
+
+func (p *DaemonRpcPool) BannedCtx(ctx context.Context, address string) (*JsonRpcGenericResponse[BannedResult], error) {
+	return doPoolHelper(ctx, p, "banned", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[BannedResult], error) {
+		return c.BannedCtx(ctx, address)
+	})
+}
+
+// flush_cache
+func (p *DaemonRpcPool) FlushCache(badTxs bool, badBlocks bool) (*JsonRpcGenericResponse[FlushCacheResult], error) {
+	return p.FlushCacheCtx(context.Background(), badTxs, badBlocks)
+}
+
+func (p *DaemonRpcPool) FlushCacheCtx(ctx context.Context, badTxs bool, badBlocks bool) (*JsonRpcGenericResponse[FlushCacheResult], error) {
+	return doPoolHelper(ctx, p, "flush_cache", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[FlushCacheResult], error) {
+		return c.FlushCacheCtx(ctx, badTxs, badBlocks)
+	})
+}
+
+// rpc_access_info
+func (p *DaemonRpcPool) RpcAccessInfo() (*JsonRpcGenericResponse[RpcAccessInfoResult], error) {
+	return p.RpcAccessInfoCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) RpcAccessInfoCtx(ctx context.Context) (*JsonRpcGenericResponse[RpcAccessInfoResult], error) {
+	return doPoolHelper(ctx, p, "rpc_access_info", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[RpcAccessInfoResult], error) {
+		return c.RpcAccessInfoCtx(ctx)
+	})
+}
+
+// rpc_access_submit_nonce
+func (p *DaemonRpcPool) RpcAccessSubmitNonce(nonce uint32, cookie uint32) (*JsonRpcGenericResponse[RpcAccessSubmitNonceResult], error) {
+	return p.RpcAccessSubmitNonceCtx(context.Background(), nonce, cookie)
+}
+
+func (p *DaemonRpcPool) RpcAccessSubmitNonceCtx(ctx context.Context, nonce uint32, cookie uint32) (*JsonRpcGenericResponse[RpcAccessSubmitNonceResult], error) {
+	return doPoolHelper(ctx, p, "rpc_access_submit_nonce", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[RpcAccessSubmitNonceResult], error) {
+		return c.RpcAccessSubmitNonceCtx(ctx, nonce, cookie)
+	})
+}
+
+// rpc_access_pay
+func (p *DaemonRpcPool) RpcAccessPay(payingFor string, payment uint64) (*JsonRpcGenericResponse[RpcAccessPayResult], error) {
+	return p.RpcAccessPayCtx(context.Background(), payingFor, payment)
+}
+
+func (p *DaemonRpcPool) RpcAccessPayCtx(ctx context.Context, payingFor string, payment uint64) (*JsonRpcGenericResponse[RpcAccessPayResult], error) {
+	return doPoolHelper(ctx, p, "rpc_access_pay", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[RpcAccessPayResult], error) {
+		return c.RpcAccessPayCtx(ctx, payingFor, payment)
+	})
+}
+
+// rpc_access_account
+func (p *DaemonRpcPool) RpcAccessAccount(deltaBalance int64) (*JsonRpcGenericResponse[RpcAccessAccountResult], error) {
+	return p.RpcAccessAccountCtx(context.Background(), deltaBalance)
+}
+
+func (p *DaemonRpcPool) RpcAccessAccountCtx(ctx context.Context, deltaBalance int64) (*JsonRpcGenericResponse[RpcAccessAccountResult], error) {
+	return doPoolHelper(ctx, p, "rpc_access_account", func(c IDaemonRpcClient) (*JsonRpcGenericResponse[RpcAccessAccountResult], error) {
+		return c.RpcAccessAccountCtx(ctx, deltaBalance)
+	})
+}
+
+/**
+	OTHER RPC METHODS
+**/
+
+// get_height
+func (p *DaemonRpcPool) GetCurrentHeight() (*GetHeightResponse, error) {
+	return p.GetCurrentHeightCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetCurrentHeightCtx(ctx context.Context) (*GetHeightResponse, error) {
+	return doPoolHelper(ctx, p, "get_height", func(c IDaemonRpcClient) (*GetHeightResponse, error) { return c.GetCurrentHeightCtx(ctx) })
+}
+
+// get_transaction_pool
+func (p *DaemonRpcPool) GetTransactionPool() (*GetTransactionPoolResponse, error) {
+	return p.GetTransactionPoolCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetTransactionPoolCtx(ctx context.Context) (*GetTransactionPoolResponse, error) {
+	return doPoolHelper(ctx, p, "get_transaction_pool", func(c IDaemonRpcClient) (*GetTransactionPoolResponse, error) { return c.GetTransactionPoolCtx(ctx) })
+}
+
+// get_transactions
+func (p *DaemonRpcPool) GetTransactions(txHashes []string, decodeAsJson bool, prune bool, split bool) (*GetTransactionsResponse, error) {
+	return p.GetTransactionsCtx(context.Background(), txHashes, decodeAsJson, prune, split)
+}
+
+func (p *DaemonRpcPool) GetTransactionsCtx(ctx context.Context, txHashes []string, decodeAsJson bool, prune bool, split bool) (*GetTransactionsResponse, error) {
+	return doPoolHelper(ctx, p, "get_transactions", func(c IDaemonRpcClient) (*GetTransactionsResponse, error) {
+		return c.GetTransactionsCtx(ctx, txHashes, decodeAsJson, prune, split)
+	})
+}
+
+// send_raw_transaction
+func (p *DaemonRpcPool) SendRawTransaction(txAsHex string, doNotRelay bool, doSanityChecks bool) (*SendRawTransactionResponse, error) {
+	return p.SendRawTransactionCtx(context.Background(), txAsHex, doNotRelay, doSanityChecks)
+}
+
+func (p *DaemonRpcPool) SendRawTransactionCtx(ctx context.Context, txAsHex string, doNotRelay bool, doSanityChecks bool) (*SendRawTransactionResponse, error) {
+	return doPoolHelper(ctx, p, "send_raw_transaction", func(c IDaemonRpcClient) (*SendRawTransactionResponse, error) {
+		return c.SendRawTransactionCtx(ctx, txAsHex, doNotRelay, doSanityChecks)
+	})
+}
+
+// is_key_image_spent
+func (p *DaemonRpcPool) IsKeyImageSpent(keyImages []string) (*IsKeyImageSpentResponse, error) {
+	return p.IsKeyImageSpentCtx(context.Background(), keyImages)
+}
+
+func (p *DaemonRpcPool) IsKeyImageSpentCtx(ctx context.Context, keyImages []string) (*IsKeyImageSpentResponse, error) {
+	return doPoolHelper(ctx, p, "is_key_image_spent", func(c IDaemonRpcClient) (*IsKeyImageSpentResponse, error) {
+		return c.IsKeyImageSpentCtx(ctx, keyImages)
+	})
+}
+
+// get_alt_blocks_hashes
+func (p *DaemonRpcPool) GetAltBlocksHashes() (*GetAltBlocksHashesResponse, error) {
+	return p.GetAltBlocksHashesCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetAltBlocksHashesCtx(ctx context.Context) (*GetAltBlocksHashesResponse, error) {
+	return doPoolHelper(ctx, p, "get_alt_blocks_hashes", func(c IDaemonRpcClient) (*GetAltBlocksHashesResponse, error) { return c.GetAltBlocksHashesCtx(ctx) })
+}
+
+// get_transaction_pool_hashes
+func (p *DaemonRpcPool) GetTransactionPoolHashes() (*GetTransactionPoolHashesResponse, error) {
+	return p.GetTransactionPoolHashesCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetTransactionPoolHashesCtx(ctx context.Context) (*GetTransactionPoolHashesResponse, error) {
+	return doPoolHelper(ctx, p, "get_transaction_pool_hashes", func(c IDaemonRpcClient) (*GetTransactionPoolHashesResponse, error) {
+		return c.GetTransactionPoolHashesCtx(ctx)
+	})
+}
+
+// get_transaction_pool_stats
+func (p *DaemonRpcPool) GetTransactionPoolStats() (*GetTransactionPoolStatsResponse, error) {
+	return p.GetTransactionPoolStatsCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetTransactionPoolStatsCtx(ctx context.Context) (*GetTransactionPoolStatsResponse, error) {
+	return doPoolHelper(ctx, p, "get_transaction_pool_stats", func(c IDaemonRpcClient) (*GetTransactionPoolStatsResponse, error) {
+		return c.GetTransactionPoolStatsCtx(ctx)
+	})
+}
+
+// get_limit
+func (p *DaemonRpcPool) GetLimit() (*GetLimitResponse, error) {
+	return p.GetLimitCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetLimitCtx(ctx context.Context) (*GetLimitResponse, error) {
+	return doPoolHelper(ctx, p, "get_limit", func(c IDaemonRpcClient) (*GetLimitResponse, error) { return c.GetLimitCtx(ctx) })
+}
+
+// set_limit
+func (p *DaemonRpcPool) SetLimit(limitDown int64, limitUp int64) (*SetLimitResponse, error) {
+	return p.SetLimitCtx(context.Background(), limitDown, limitUp)
+}
+
+func (p *DaemonRpcPool) SetLimitCtx(ctx context.Context, limitDown int64, limitUp int64) (*SetLimitResponse, error) {
+	return doPoolHelper(ctx, p, "set_limit", func(c IDaemonRpcClient) (*SetLimitResponse, error) { return c.SetLimitCtx(ctx, limitDown, limitUp) })
+}
+
+// get_peer_list
+func (p *DaemonRpcPool) GetPeerList(publicOnly bool, includeBlocked bool) (*GetPeerListResponse, error) {
+	return p.GetPeerListCtx(context.Background(), publicOnly, includeBlocked)
+}
+
+func (p *DaemonRpcPool) GetPeerListCtx(ctx context.Context, publicOnly bool, includeBlocked bool) (*GetPeerListResponse, error) {
+	return doPoolHelper(ctx, p, "get_peer_list", func(c IDaemonRpcClient) (*GetPeerListResponse, error) {
+		return c.GetPeerListCtx(ctx, publicOnly, includeBlocked)
+	})
+}
+
+// get_public_nodes
+func (p *DaemonRpcPool) GetPublicNodes(gray bool, white bool, includeBlocked bool) (*GetPublicNodesResponse, error) {
+	return p.GetPublicNodesCtx(context.Background(), gray, white, includeBlocked)
+}
+
+func (p *DaemonRpcPool) GetPublicNodesCtx(ctx context.Context, gray bool, white bool, includeBlocked bool) (*GetPublicNodesResponse, error) {
+	return doPoolHelper(ctx, p, "get_public_nodes", func(c IDaemonRpcClient) (*GetPublicNodesResponse, error) {
+		return c.GetPublicNodesCtx(ctx, gray, white, includeBlocked)
+	})
+}
+
+// out_peers
+func (p *DaemonRpcPool) OutPeers(outPeers uint32) (*OutPeersResponse, error) {
+	return p.OutPeersCtx(context.Background(), outPeers)
+}
+
+func (p *DaemonRpcPool) OutPeersCtx(ctx context.Context, outPeers uint32) (*OutPeersResponse, error) {
+	return doPoolHelper(ctx, p, "out_peers", func(c IDaemonRpcClient) (*OutPeersResponse, error) { return c.OutPeersCtx(ctx, outPeers) })
+}
+
+// in_peers
+func (p *DaemonRpcPool) InPeers(inPeers uint32) (*InPeersResponse, error) {
+	return p.InPeersCtx(context.Background(), inPeers)
+}
+
+func (p *DaemonRpcPool) InPeersCtx(ctx context.Context, inPeers uint32) (*InPeersResponse, error) {
+	return doPoolHelper(ctx, p, "in_peers", func(c IDaemonRpcClient) (*InPeersResponse, error) { return c.InPeersCtx(ctx, inPeers) })
+}
+
+// start_mining
+func (p *DaemonRpcPool) StartMining(minerAddress string, threadsCount uint64, doBackgroundMining bool, ignoreBattery bool) (*StartMiningResponse, error) {
+	return p.StartMiningCtx(context.Background(), minerAddress, threadsCount, doBackgroundMining, ignoreBattery)
+}
+
+func (p *DaemonRpcPool) StartMiningCtx(ctx context.Context, minerAddress string, threadsCount uint64, doBackgroundMining bool, ignoreBattery bool) (*StartMiningResponse, error) {
+	return doPoolHelper(ctx, p, "start_mining", func(c IDaemonRpcClient) (*StartMiningResponse, error) {
+		return c.StartMiningCtx(ctx, minerAddress, threadsCount, doBackgroundMining, ignoreBattery)
+	})
+}
+
+// stop_mining
+func (p *DaemonRpcPool) StopMining() (*StopMiningResponse, error) {
+	return p.StopMiningCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) StopMiningCtx(ctx context.Context) (*StopMiningResponse, error) {
+	return doPoolHelper(ctx, p, "stop_mining", func(c IDaemonRpcClient) (*StopMiningResponse, error) { return c.StopMiningCtx(ctx) })
+}
+
+// mining_status
+func (p *DaemonRpcPool) MiningStatus() (*MiningStatusResponse, error) {
+	return p.MiningStatusCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) MiningStatusCtx(ctx context.Context) (*MiningStatusResponse, error) {
+	return doPoolHelper(ctx, p, "mining_status", func(c IDaemonRpcClient) (*MiningStatusResponse, error) { return c.MiningStatusCtx(ctx) })
+}
+
+// save_bc
+func (p *DaemonRpcPool) SaveBc() (*SaveBcResponse, error) {
+	return p.SaveBcCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) SaveBcCtx(ctx context.Context) (*SaveBcResponse, error) {
+	return doPoolHelper(ctx, p, "save_bc", func(c IDaemonRpcClient) (*SaveBcResponse, error) { return c.SaveBcCtx(ctx) })
+}
+
+// get_net_stats
+func (p *DaemonRpcPool) GetNetStats() (*GetNetStatsResponse, error) {
+	return p.GetNetStatsCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) GetNetStatsCtx(ctx context.Context) (*GetNetStatsResponse, error) {
+	return doPoolHelper(ctx, p, "get_net_stats", func(c IDaemonRpcClient) (*GetNetStatsResponse, error) { return c.GetNetStatsCtx(ctx) })
+}
+
+// pop_blocks
+func (p *DaemonRpcPool) PopBlocks(nBlocks uint64) (*PopBlocksResponse, error) {
+	return p.PopBlocksCtx(context.Background(), nBlocks)
+}
+
+func (p *DaemonRpcPool) PopBlocksCtx(ctx context.Context, nBlocks uint64) (*PopBlocksResponse, error) {
+	return doPoolHelper(ctx, p, "pop_blocks", func(c IDaemonRpcClient) (*PopBlocksResponse, error) { return c.PopBlocksCtx(ctx, nBlocks) })
+}
+
+// update
+func (p *DaemonRpcPool) Update(command string, path string) (*UpdateResponse, error) {
+	return p.UpdateCtx(context.Background(), command, path)
+}
+
+func (p *DaemonRpcPool) UpdateCtx(ctx context.Context, command string, path string) (*UpdateResponse, error) {
+	return doPoolHelper(ctx, p, "update", func(c IDaemonRpcClient) (*UpdateResponse, error) { return c.UpdateCtx(ctx, command, path) })
+}
+
+// stop_daemon
+func (p *DaemonRpcPool) StopDaemon() (*StopDaemonResponse, error) {
+	return p.StopDaemonCtx(context.Background())
+}
+
+func (p *DaemonRpcPool) StopDaemonCtx(ctx context.Context) (*StopDaemonResponse, error) {
+	return doPoolHelper(ctx, p, "stop_daemon", func(c IDaemonRpcClient) (*StopDaemonResponse, error) { return c.StopDaemonCtx(ctx) })
+}
+
+/**
+	BINARY RPC METHODS
+**/
+
+// get_blocks.bin
+func (p *DaemonRpcPool) GetBlocksBin(blockIds []string, startHeight uint64, prune bool, noMinerTx bool) (*GetBlocksBinResponse, error) {
+	return p.GetBlocksBinCtx(context.Background(), blockIds, startHeight, prune, noMinerTx)
+}
+
+func (p *DaemonRpcPool) GetBlocksBinCtx(ctx context.Context, blockIds []string, startHeight uint64, prune bool, noMinerTx bool) (*GetBlocksBinResponse, error) {
+	return doPoolHelper(ctx, p, "get_blocks.bin", func(c IDaemonRpcClient) (*GetBlocksBinResponse, error) {
+		return c.GetBlocksBinCtx(ctx, blockIds, startHeight, prune, noMinerTx)
+	})
+}
+
+// get_blocks_by_height.bin
+func (p *DaemonRpcPool) GetBlocksByHeightBin(heights []uint64) (*GetBlocksByHeightBinResponse, error) {
+	return p.GetBlocksByHeightBinCtx(context.Background(), heights)
+}
+
+func (p *DaemonRpcPool) GetBlocksByHeightBinCtx(ctx context.Context, heights []uint64) (*GetBlocksByHeightBinResponse, error) {
+	return doPoolHelper(ctx, p, "get_blocks_by_height.bin", func(c IDaemonRpcClient) (*GetBlocksByHeightBinResponse, error) {
+		return c.GetBlocksByHeightBinCtx(ctx, heights)
+	})
+}
+
+// get_hashes.bin
+func (p *DaemonRpcPool) GetHashesBin(blockIds []string, startHeight uint64) (*GetHashesBinResponse, error) {
+	return p.GetHashesBinCtx(context.Background(), blockIds, startHeight)
+}
+
+func (p *DaemonRpcPool) GetHashesBinCtx(ctx context.Context, blockIds []string, startHeight uint64) (*GetHashesBinResponse, error) {
+	return doPoolHelper(ctx, p, "get_hashes.bin", func(c IDaemonRpcClient) (*GetHashesBinResponse, error) {
+		return c.GetHashesBinCtx(ctx, blockIds, startHeight)
+	})
+}
+
+// get_o_indexes.bin
+func (p *DaemonRpcPool) GetOIndexesBin(txId string) (*GetOIndexesBinResponse, error) {
+	return p.GetOIndexesBinCtx(context.Background(), txId)
+}
+
+func (p *DaemonRpcPool) GetOIndexesBinCtx(ctx context.Context, txId string) (*GetOIndexesBinResponse, error) {
+	return doPoolHelper(ctx, p, "get_o_indexes.bin", func(c IDaemonRpcClient) (*GetOIndexesBinResponse, error) { return c.GetOIndexesBinCtx(ctx, txId) })
+}
+
+// get_outs.bin
+func (p *DaemonRpcPool) GetOutsBin(outputs []GetOutputsOut, getTxId bool) (*GetOutsBinResponse, error) {
+	return p.GetOutsBinCtx(context.Background(), outputs, getTxId)
+}
+
+func (p *DaemonRpcPool) GetOutsBinCtx(ctx context.Context, outputs []GetOutputsOut, getTxId bool) (*GetOutsBinResponse, error) {
+	return doPoolHelper(ctx, p, "get_outs.bin", func(c IDaemonRpcClient) (*GetOutsBinResponse, error) { return c.GetOutsBinCtx(ctx, outputs, getTxId) })
+}
@@ -0,0 +1,306 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chekist32/go-monero/utils"
+)
+
+const (
+	quorumTxMissedValue = "missed"
+	quorumTxInPoolValue = "pool"
+)
+
+var (
+	ErrNoQuorum            = errors.New("the nodes don't agree")
+	ErrInvalidQuorumConfig = errors.New("invalid quorum config")
+	ErrUntrustedAnswer     = errors.New("the node returned untrusted data")
+	// The call to the node has been canceled, as the quorum has been decided without its answer
+	ErrQuorumNodeSkipped = errors.New("the quorum has been decided without the node")
+)
+
+type QuorumConfig struct {
+	// The number of nodes that must agree, the majority of the nodes if 0
+	Threshold int
+	// How many blocks the heights returned by GetCurrentHeight may differ by and still agree
+	MaxHeightDiff uint64
+	// Count the answers that are marked as untrusted (the ones a node proxies from its bootstrap daemon)
+	AllowUntrusted bool
+	// The deadline of the calls to the nodes, no deadline if 0. Without it a node that stalls
+	// can block the checked calls until the threshold is reached by the others.
+	Timeout time.Duration
+	// Called when the quorum is reached, but some of the nodes that have answered disagree or fail
+	OnDisagreement func(report *QuorumReport)
+}
+
+type QuorumNodeAnswer struct {
+	// The index of the node in the clients passed to NewDaemonRpcQuorum
+	Node int
+	// The compared value: the block hash, the tx heights or the chain height
+	Value     string
+	Agrees    bool
+	Untrusted bool
+	Err       error
+}
+
+type QuorumReport struct {
+	Method   string
+	Agreeing int
+	Required int
+	Answers  []QuorumNodeAnswer
+}
+
+// Returned when fewer nodes than the threshold agree on the answer
+type QuorumError struct {
+	Report *QuorumReport
+}
+
+func (e *QuorumError) Error() string {
+	return fmt.Sprintf("%s: %v, only %d of %d nodes agree, %d required", e.Report.Method, ErrNoQuorum, e.Report.Agreeing, len(e.Report.Answers), e.Report.Required)
+}
+
+func (e *QuorumError) Unwrap() error {
+	return ErrNoQuorum
+}
+
+// DaemonRpcQuorum is an IDaemonRpcClient that sends the calls an untrusted node could lie about
+// (GetBlockHeaderByHeight, GetTransactions and GetCurrentHeight) to all the nodes and compares the answers.
+// The rest of the calls go to the first node only and aren't checked, e.g. SendRawTransaction, IsKeyImageSpent,
+// GetTransactionPool, GetBlockHeaderByHash, GetLastBlockHeader, GetBlockByHeight, GetBlockByHash, GetInfo,
+// the binary methods (GetBlocksBin, GetOutsBin, etc.) and the admin ones.
+// SetRpcConnection is a no-op, see NewDaemonRpcQuorum.
+type DaemonRpcQuorum struct {
+	IDaemonRpcClient
+	clients []IDaemonRpcClient
+	config  QuorumConfig
+}
+
+// Creates a DaemonRpcQuorum of the clients, the first one also serves the unchecked calls.
+// SetRpcConnection isn't supported: it does nothing, the connections have to be changed on the clients themselves.
+func NewDaemonRpcQuorum(clients []IDaemonRpcClient, config QuorumConfig) (*DaemonRpcQuorum, error) {
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("%w: no clients", ErrInvalidQuorumConfig)
+	}
+
+	if config.Threshold == 0 {
+		config.Threshold = len(clients)/2 + 1
+	}
+	if config.Threshold < 0 || config.Threshold > len(clients) {
+		return nil, fmt.Errorf("%w: the threshold %d is out of the [1, %d] range", ErrInvalidQuorumConfig, config.Threshold, len(clients))
+	}
+
+	return &DaemonRpcQuorum{clients[0], clients, config}, nil
+}
+
+// The nodes are the clients passed to NewDaemonRpcQuorum, so it does nothing,
+// as replacing the connection of the first one would leave its calls without the quorum.
+// The connections of the clients themselves can still be changed.
+func (q *DaemonRpcQuorum) SetRpcConnection(connection *RpcConnection) {}
+
+// Sets the policy of all the clients
+func (q *DaemonRpcQuorum) SetRetryPolicy(policy *utils.RetryPolicy) {
+	for _, v := range q.clients {
+		v.SetRetryPolicy(policy)
+	}
+}
+
+type quorumAnswer[R any] struct {
+	res       R
+	untrusted bool
+	err       error
+}
+
+type quorumNodeAnswer[R any] struct {
+	node   int
+	answer quorumAnswer[R]
+}
+
+// Returns the answer the most nodes agree with and the number of them,
+// the ties are resolved in favor of the node that comes first.
+func quorumReferenceHelper[R any](answers []quorumAnswer[R], done []bool, agree func(a R, b R) bool) (int, int) {
+	ref, refCount := -1, 0
+	for i, a := range answers {
+		if !done[i] || a.err != nil {
+			continue
+		}
+
+		count := 0
+		for j, b := range answers {
+			if done[j] && b.err == nil && agree(a.res, b.res) {
+				count++
+			}
+		}
+		if count > refCount {
+			ref, refCount = i, count
+		}
+	}
+
+	return ref, refCount
+}
+
+// Sends the call to all the nodes and returns the answer most of them agree on.
+// value describes the answer for the report, untrusted tells whether it is marked as untrusted,
+// and agree tells whether two answers agree.
+// The calls still in progress are canceled as soon as the threshold is reached or can't be reached anymore.
+func doQuorumHelper[R any](
+	ctx context.Context,
+	q *DaemonRpcQuorum,
+	method string,
+	call func(ctx context.Context, c IDaemonRpcClient) (R, error),
+	value func(res R) string,
+	untrusted func(res R) bool,
+	agree func(a R, b R) bool,
+) (R, error) {
+	var callCtx context.Context
+	var cancel context.CancelFunc
+	if q.config.Timeout > 0 {
+		callCtx, cancel = context.WithTimeout(ctx, q.config.Timeout)
+	} else {
+		callCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// buffered, so the calls that are still in progress when the quorum is decided don't block
+	results := make(chan quorumNodeAnswer[R], len(q.clients))
+	for i, c := range q.clients {
+		go func(i int, c IDaemonRpcClient) {
+			res, err := call(callCtx, c)
+			a := quorumAnswer[R]{res: res, err: err}
+			if err == nil && untrusted(res) {
+				a.untrusted = true
+				if !q.config.AllowUntrusted {
+					a.err = ErrUntrustedAnswer
+				}
+			}
+			results <- quorumNodeAnswer[R]{i, a}
+		}(i, c)
+	}
+
+	answers := make([]quorumAnswer[R], len(q.clients))
+	done := make([]bool, len(q.clients))
+	ref, refCount := -1, 0
+	for pending := len(q.clients); pending > 0; {
+		v := <-results
+		answers[v.node], done[v.node] = v.answer, true
+		pending--
+
+		ref, refCount = quorumReferenceHelper(answers, done, agree)
+		if refCount >= q.config.Threshold || refCount+pending < q.config.Threshold {
+			break
+		}
+	}
+	cancel()
+
+	var res R
+	if err := ctx.Err(); err != nil {
+		return res, err
+	}
+
+	disagreement := false
+	report := &QuorumReport{Method: method, Agreeing: refCount, Required: q.config.Threshold, Answers: make([]QuorumNodeAnswer, len(answers))}
+	for i, a := range answers {
+		if !done[i] {
+			report.Answers[i] = QuorumNodeAnswer{Node: i, Err: ErrQuorumNodeSkipped}
+			continue
+		}
+
+		report.Answers[i] = QuorumNodeAnswer{Node: i, Untrusted: a.untrusted, Err: a.err}
+		if a.err == nil || a.untrusted {
+			report.Answers[i].Value = value(a.res)
+		}
+		report.Answers[i].Agrees = ref >= 0 && a.err == nil && agree(answers[ref].res, a.res)
+		disagreement = disagreement || !report.Answers[i].Agrees
+	}
+
+	if refCount < q.config.Threshold {
+		return res, &QuorumError{report}
+	}
+	if disagreement && q.config.OnDisagreement != nil {
+		q.config.OnDisagreement(report)
+	}
+
+	return answers[ref].res, nil
+}
+
+// get_block_header_by_height
+// The nodes agree if they return the same block hash.
+func (q *DaemonRpcQuorum) GetBlockHeaderByHeight(fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return q.GetBlockHeaderByHeightCtx(context.Background(), fillPowHash, height)
+}
+
+func (q *DaemonRpcQuorum) GetBlockHeaderByHeightCtx(ctx context.Context, fillPowHash bool, height uint64) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+	return doQuorumHelper(ctx, q, "get_block_header_by_height",
+		func(ctx context.Context, c IDaemonRpcClient) (*JsonRpcGenericResponse[GetBlockHeaderResult], error) {
+			return c.GetBlockHeaderByHeightCtx(ctx, fillPowHash, height)
+		},
+		func(res *JsonRpcGenericResponse[GetBlockHeaderResult]) string { return res.Result.BlockHeader.Hash },
+		func(res *JsonRpcGenericResponse[GetBlockHeaderResult]) bool { return res.Result.Untrusted },
+		func(a, b *JsonRpcGenericResponse[GetBlockHeaderResult]) bool {
+			return a.Result.BlockHeader.Hash == b.Result.BlockHeader.Hash
+		},
+	)
+}
+
+// Describes where the txs are: "<hash>:<block height>", "<hash>:pool" or "<hash>:missed", sorted by the hash
+func quorumTxsValueHelper(res *GetTransactionsResponse) string {
+	entries := make([]string, 0, len(res.Txs)+len(res.MissedTx))
+	for _, v := range res.Txs {
+		if v.InPool {
+			entries = append(entries, v.TxHash+":"+quorumTxInPoolValue)
+		} else {
+			entries = append(entries, v.TxHash+":"+strconv.FormatUint(v.BlockHeight, 10))
+		}
+	}
+	for _, v := range res.MissedTx {
+		entries = append(entries, v+":"+quorumTxMissedValue)
+	}
+	sort.Strings(entries)
+
+	return strings.Join(entries, ",")
+}
+
+// get_transactions
+// The nodes agree if they report the same block heights for the txs (or the same txs as being in the pool or missed).
+// The confirmations aren't compared, as they depend on the current height of the node.
+func (q *DaemonRpcQuorum) GetTransactions(txHashes []string, decodeAsJson bool, prune bool, split bool) (*GetTransactionsResponse, error) {
+	return q.GetTransactionsCtx(context.Background(), txHashes, decodeAsJson, prune, split)
+}
+
+func (q *DaemonRpcQuorum) GetTransactionsCtx(ctx context.Context, txHashes []string, decodeAsJson bool, prune bool, split bool) (*GetTransactionsResponse, error) {
+	return doQuorumHelper(ctx, q, "get_transactions",
+		func(ctx context.Context, c IDaemonRpcClient) (*GetTransactionsResponse, error) {
+			return c.GetTransactionsCtx(ctx, txHashes, decodeAsJson, prune, split)
+		},
+		quorumTxsValueHelper,
+		func(res *GetTransactionsResponse) bool { return res.Untrusted },
+		func(a, b *GetTransactionsResponse) bool { return quorumTxsValueHelper(a) == quorumTxsValueHelper(b) },
+	)
+}
+
+// get_height
+// The nodes agree if their heights differ by no more than MaxHeightDiff blocks.
+func (q *DaemonRpcQuorum) GetCurrentHeight() (*GetHeightResponse, error) {
+	return q.GetCurrentHeightCtx(context.Background())
+}
+
+func (q *DaemonRpcQuorum) GetCurrentHeightCtx(ctx context.Context) (*GetHeightResponse, error) {
+	return doQuorumHelper(ctx, q, "get_height",
+		func(ctx context.Context, c IDaemonRpcClient) (*GetHeightResponse, error) {
+			return c.GetCurrentHeightCtx(ctx)
+		},
+		func(res *GetHeightResponse) string { return strconv.FormatUint(res.Height, 10) },
+		func(res *GetHeightResponse) bool { return res.Untrusted },
+		func(a, b *GetHeightResponse) bool {
+			if a.Height > b.Height {
+				return a.Height-b.Height <= q.config.MaxHeightDiff
+			}
+			return b.Height-a.Height <= q.config.MaxHeightDiff
+		},
+	)
+}
@@ -0,0 +1,137 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/chekist32/go-monero/utils"
+)
+
+// The methods that don't change the state of the daemon, so they are safe to retry.
+// The rest (submit_block, relay_tx, send_raw_transaction, etc.) are only retried if they are listed in utils.RetryPolicy.AlsoRetry.
+var idempotentMethods = map[string]bool{
+	"get_block_count":            true,
+	"on_get_block_hash":          true,
+	"get_block_template":         true,
+	"get_last_block_header":      true,
+	"get_block_header_by_hash":   true,
+	"get_block_header_by_height": true,
+	"get_block_headers_range":    true,
+	"get_block":                  true,
+	"get_fee_estimate":           true,
+	"get_version":                true,
+	"get_info":                   true,
+	"get_connections":            true,
+	"get_bans":                   true,
+	"get_output_histogram":       true,
+	"get_coinbase_tx_sum":        true,
+	"get_alternate_chains":       true,
+	"sync_info":                  true,
+	"get_txpool_backlog":         true,
+	"get_output_distribution":    true,
+	"get_miner_data":             true,
+	"calc_pow":                   true,
+	"hard_fork_info":             true,
+	"banned":                     true,
+
+	"get_height":                  true,
+	"get_transaction_pool":        true,
+	"get_transactions":            true,
+	"is_key_image_spent":          true,
+	"get_alt_blocks_hashes":       true,
+	"get_transaction_pool_hashes": true,
+	"get_transaction_pool_stats":  true,
+	"get_limit":                   true,
+	"get_peer_list":               true,
+	"get_public_nodes":            true,
+	"mining_status":               true,
+	"get_net_stats":               true,
+
+	"get_blocks.bin":           true,
+	"get_blocks_by_height.bin": true,
+	"get_hashes.bin":           true,
+	"get_o_indexes.bin":        true,
+	"get_outs.bin":             true,
+}
+
+// Implemented by the JSON RPC request bodies, the method of the other endpoints is the endpoint itself
+type jsonRpcMethod interface {
+	jsonRpcMethod() string
+}
+
+func (b *JsonRpcGenericRequestBody[T]) jsonRpcMethod() string {
+	return b.Method
+}
+
+// Implemented by the responses that can carry the BUSY status
+type busyResponse interface {
+	busy() bool
+}
+
+func (f *JsonRpcFooter) busy() bool {
+	return f.Status == RPC_STATUS_BUSY
+}
+
+func (f *BinRpcFooter) busy() bool {
+	return f.Status == RPC_STATUS_BUSY
+}
+
+// Tells whether the result of the JSON RPC response has the BUSY status, there is no error object then
+func (r *JsonRpcGenericResponse[T]) busyResult() bool {
+	b, ok := any(&r.Result).(busyResponse)
+	return r.Error.Code == 0 && ok && b.busy()
+}
+
+func (r *JsonRpcGenericResponse[T]) busy() bool {
+	if r.Error.Code == RPC_ERROR_CODE_CORE_BUSY {
+		return true
+	}
+	if b, ok := any(&r.Result).(busyResponse); ok {
+		return b.busy()
+	}
+
+	return false
+}
+
+func rpcMethodHelper[B MoneroRpcRequestBody](req *MoneroRpcRequest[B]) string {
+	if m, ok := any(req.Body).(jsonRpcMethod); ok {
+		return m.jsonRpcMethod()
+	}
+
+	return strings.TrimPrefix(req.Endpoint, "/")
+}
+
+// Tells whether the error is worth retrying: the transport errors, the 5xx and 429 HTTP statuses and the busy daemon.
+// ErrDaemonBusy is also passed to utils.RetryPolicy.Retryable when a response has the BUSY status,
+// if the attempts are exhausted, the caller gets the busy response itself, as without the retry policy.
+func IsRetryableError(err error) bool {
+	return errors.Is(err, ErrDaemonBusy) || utils.IsTransientError(err)
+}
+
+// Retries the call according to the retry policy of the client. The busy responses are retried as well,
+// the last one is returned to the caller without an error, so the method reports it the usual way.
+func doWithRetryHelper[R any](ctx context.Context, c *DaemonRpcClient, method string, call func() (*R, error)) (*R, error) {
+	var res *R
+	err := c.retry.Do(ctx, method, idempotentMethods[method], IsRetryableError, func() error {
+		var err error
+		res, err = call()
+		if err != nil {
+			return err
+		}
+
+		if b, ok := any(res).(busyResponse); ok && b.busy() {
+			return ErrDaemonBusy
+		}
+
+		return nil
+	})
+	if errors.Is(err, ErrDaemonBusy) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,377 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DEFAULT_WATCHER_INTERVAL        time.Duration = 10 * time.Second
+	DEFAULT_WATCHER_MAX_REORG_DEPTH int           = 100
+	DEFAULT_WATCHER_BUFFER_SIZE     int           = 64
+
+	// The number of the headers fetched at once while looking for the fork point
+	watcherReorgStep uint64 = 10
+)
+
+var (
+	ErrWatcherStarted = errors.New("the watcher has already been started")
+	// The fork point is deeper than MaxReorgDepth, all the remembered blocks are reported as detached
+	ErrReorgTooDeep = errors.New("the reorg is deeper than the remembered blocks")
+	// The headers returned by the daemon don't form a chain, e.g. a reorg happened while they were fetched
+	ErrBrokenChain = errors.New("the block headers don't form a chain")
+)
+
+// WatcherEvent is one of NewBlockEvent, ReorgEvent, TxAddedToPoolEvent, TxRemovedFromPoolEvent and TxConfirmedEvent
+type WatcherEvent interface {
+	watcherEvent()
+}
+
+// A block added to the main chain. The blocks are reported in the order of the heights.
+type NewBlockEvent struct {
+	Header BlockHeader
+}
+
+// The chain was reorganized. It is followed by NewBlockEvent for each of the attached blocks.
+type ReorgEvent struct {
+	Detached []BlockHeader
+	Attached []BlockHeader
+}
+
+type TxAddedToPoolEvent struct {
+	Tx MoneroTx
+}
+
+// The tx left the pool without being mined (e.g. it was replaced by a double spend or expired)
+type TxRemovedFromPoolEvent struct {
+	TxHash string
+}
+
+// The tx that was in the pool has been mined
+type TxConfirmedEvent struct {
+	TxHash string
+	Height uint64
+}
+
+func (NewBlockEvent) watcherEvent()          {}
+func (ReorgEvent) watcherEvent()             {}
+func (TxAddedToPoolEvent) watcherEvent()     {}
+func (TxRemovedFromPoolEvent) watcherEvent() {}
+func (TxConfirmedEvent) watcherEvent()       {}
+
+// The zero values of the fields are replaced with the defaults.
+type WatcherConfig struct {
+	Interval time.Duration
+	// The number of the last blocks remembered to find the fork point of a reorg
+	MaxReorgDepth int
+	// The capacity of the channels
+	BufferSize int
+	// Don't poll the tx pool, only the block events are reported
+	IgnorePool bool
+}
+
+// Watcher is the polling alternative to the ZMQ notifications for the nodes that don't expose ZMQ.
+// Every Interval it compares the top block with the remembered ones (get_last_block_header and get_block_headers_range)
+// and the pool with its previous state (get_transaction_pool). The txs that left the pool are looked up with get_transactions
+// to tell the confirmed ones from the removed ones.
+// The state at the first poll is the starting point and isn't reported.
+type Watcher struct {
+	client  IDaemonRpcClient
+	config  WatcherConfig
+	started atomic.Bool
+
+	// the last blocks of the main chain ordered by height
+	headers []BlockHeader
+	pool    map[string]bool
+
+	events chan WatcherEvent
+	errs   chan error
+}
+
+func NewWatcher(client IDaemonRpcClient, config WatcherConfig) *Watcher {
+	if config.Interval <= 0 {
+		config.Interval = DEFAULT_WATCHER_INTERVAL
+	}
+	if config.MaxReorgDepth <= 0 {
+		config.MaxReorgDepth = DEFAULT_WATCHER_MAX_REORG_DEPTH
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = DEFAULT_WATCHER_BUFFER_SIZE
+	}
+
+	return &Watcher{
+		client: client,
+		config: config,
+		events: make(chan WatcherEvent, config.BufferSize),
+		errs:   make(chan error, config.BufferSize),
+	}
+}
+
+func (w *Watcher) Events() <-chan WatcherEvent {
+	return w.events
+}
+
+// The polling errors. They are dropped if the channel is full.
+func (w *Watcher) Errors() <-chan error {
+	return w.errs
+}
+
+// Polls the daemon every Interval until the ctx is done. The first poll is run immediately.
+// Both channels are closed when it returns. A Watcher can only be run once.
+func (w *Watcher) Run(ctx context.Context) error {
+	if !w.started.CompareAndSwap(false, true) {
+		return ErrWatcherStarted
+	}
+	defer func() {
+		close(w.events)
+		close(w.errs)
+	}()
+
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.pollHelper(ctx); err != nil && ctx.Err() == nil {
+			select {
+			case w.errs <- err:
+			default:
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *Watcher) emitHelper(ctx context.Context, e WatcherEvent) error {
+	select {
+	case w.events <- e:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Watcher) pollHelper(ctx context.Context) error {
+	if err := w.pollBlocksHelper(ctx); err != nil {
+		return err
+	}
+	if w.config.IgnorePool {
+		return nil
+	}
+
+	return w.pollPoolHelper(ctx)
+}
+
+// Returns the remembered header at the height
+func (w *Watcher) headerAtHelper(height uint64) (*BlockHeader, bool) {
+	if len(w.headers) == 0 || height < w.headers[0].Height || height > w.headers[len(w.headers)-1].Height {
+		return nil, false
+	}
+
+	return &w.headers[height-w.headers[0].Height], true
+}
+
+// get_block_headers_range split within the limit of the restricted rpc
+func (w *Watcher) headersRangeHelper(ctx context.Context, start uint64, end uint64) ([]BlockHeader, error) {
+	var res []BlockHeader
+	for from := start; from <= end; from += uint64(MAX_BLOCKS_PER_REQUEST) {
+		to := from + uint64(MAX_BLOCKS_PER_REQUEST) - 1
+		if to > end {
+			to = end
+		}
+
+		headers, err := w.client.GetBlockHeadersRangeCtx(ctx, false, from, to)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, headers.Result.Headers...)
+	}
+
+	for i := 1; i < len(res); i++ {
+		if res[i].PrevHash != res[i-1].Hash || res[i].Height != res[i-1].Height+1 {
+			return nil, fmt.Errorf("%w: at height %d", ErrBrokenChain, res[i].Height)
+		}
+	}
+
+	return res, nil
+}
+
+func (w *Watcher) pollBlocksHelper(ctx context.Context) error {
+	last, err := w.client.GetLastBlockHeaderCtx(ctx, false)
+	if err != nil {
+		return err
+	}
+	top := last.Result.BlockHeader
+
+	if len(w.headers) == 0 {
+		var start uint64
+		if top.Height >= uint64(w.config.MaxReorgDepth) {
+			start = top.Height - uint64(w.config.MaxReorgDepth) + 1
+		}
+		w.headers, err = w.headersRangeHelper(ctx, start, top.Height)
+		return err
+	}
+	tip := w.headers[len(w.headers)-1]
+	if top.Hash == tip.Hash {
+		return nil
+	}
+
+	attached := []BlockHeader{top}
+	if top.Height > tip.Height {
+		if attached, err = w.headersRangeHelper(ctx, tip.Height+1, top.Height); err != nil {
+			return err
+		}
+	}
+
+	/**
+		Walks back from the new blocks until they connect to a remembered one.
+		If they don't, the whole remembered chain is detached.
+	**/
+	var tooDeep bool
+	for attached[0].Height > 0 {
+		first := attached[0]
+		known, ok := w.headerAtHelper(first.Height - 1)
+		if !ok {
+			tooDeep = true
+			break
+		}
+		if known.Hash == first.PrevHash {
+			break
+		}
+
+		start := w.headers[0].Height
+		if first.Height > start+watcherReorgStep {
+			start = first.Height - watcherReorgStep
+		}
+		more, err := w.headersRangeHelper(ctx, start, first.Height-1)
+		if err != nil {
+			return err
+		}
+		if more[len(more)-1].Hash != first.PrevHash {
+			return fmt.Errorf("%w: at height %d", ErrBrokenChain, first.Height)
+		}
+
+		// the headers shared with the remembered chain aren't attached
+		i := 0
+		for ; i < len(more); i++ {
+			if known, ok := w.headerAtHelper(more[i].Height); !ok || known.Hash != more[i].Hash {
+				break
+			}
+		}
+		attached = append(more[i:], attached...)
+	}
+
+	var detached []BlockHeader
+	if tooDeep {
+		detached, w.headers = w.headers, nil
+	} else if known, ok := w.headerAtHelper(attached[0].Height); ok {
+		i := int(known.Height - w.headers[0].Height)
+		detached = append(detached, w.headers[i:]...)
+		w.headers = w.headers[:i]
+	}
+
+	w.headers = append(w.headers, attached...)
+	if len(w.headers) > w.config.MaxReorgDepth {
+		w.headers = append([]BlockHeader(nil), w.headers[len(w.headers)-w.config.MaxReorgDepth:]...)
+	}
+
+	if len(detached) > 0 {
+		if err := w.emitHelper(ctx, ReorgEvent{Detached: detached, Attached: attached}); err != nil {
+			return err
+		}
+	}
+	for _, v := range attached {
+		if err := w.emitHelper(ctx, NewBlockEvent{Header: v}); err != nil {
+			return err
+		}
+	}
+
+	if tooDeep {
+		return fmt.Errorf("%w: %d blocks", ErrReorgTooDeep, len(detached))
+	}
+	return nil
+}
+
+func (w *Watcher) pollPoolHelper(ctx context.Context) error {
+	res, err := w.client.GetTransactionPoolCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]bool, len(res.Transactions))
+	for _, v := range res.Transactions {
+		current[v.IdHash] = true
+	}
+	if w.pool == nil {
+		w.pool = current
+		return nil
+	}
+
+	var added []MoneroTx
+	for _, v := range res.Transactions {
+		if !w.pool[v.IdHash] {
+			added = append(added, v)
+		}
+	}
+	var gone []string
+	for k := range w.pool {
+		if !current[k] {
+			gone = append(gone, k)
+		}
+	}
+	sort.Strings(gone)
+
+	confirmed, err := w.confirmedHelper(ctx, gone)
+	if err != nil {
+		return err
+	}
+	w.pool = current
+
+	for _, v := range added {
+		if err := w.emitHelper(ctx, TxAddedToPoolEvent{Tx: v}); err != nil {
+			return err
+		}
+	}
+	for _, v := range gone {
+		var e WatcherEvent = TxRemovedFromPoolEvent{TxHash: v}
+		if height, ok := confirmed[v]; ok {
+			e = TxConfirmedEvent{TxHash: v, Height: height}
+		}
+		if err := w.emitHelper(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the heights of the mined txs among the given ones
+func (w *Watcher) confirmedHelper(ctx context.Context, hashes []string) (map[string]uint64, error) {
+	res := make(map[string]uint64)
+	for start := 0; start < len(hashes); start += MAX_TXS_PER_REQUEST {
+		end := start + MAX_TXS_PER_REQUEST
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+
+		txs, err := w.client.GetTransactionsCtx(ctx, hashes[start:end], false, false, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range txs.Txs {
+			if !v.InPool && v.BlockHeight > 0 {
+				res[v.TxHash] = v.BlockHeight
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,208 @@
+package zmq
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/tx"
+)
+
+type Topic string
+
+const (
+	TOPIC_JSON_MINIMAL_CHAIN_MAIN Topic = "json-minimal-chain_main"
+	TOPIC_JSON_FULL_CHAIN_MAIN    Topic = "json-full-chain_main"
+	TOPIC_JSON_MINIMAL_TXPOOL_ADD Topic = "json-minimal-txpool_add"
+	TOPIC_JSON_FULL_TXPOOL_ADD    Topic = "json-full-txpool_add"
+)
+
+// json-minimal-chain_main, the blocks added to the main chain.
+// After a reorg FirstHeight is lower than the previous height.
+type MinimalChainMain struct {
+	FirstHeight uint64   `json:"first_height"`
+	FirstPrevId string   `json:"first_prev_id"`
+	Ids         []string `json:"ids"`
+}
+
+// json-minimal-txpool_add
+type MinimalTxPoolAdd struct {
+	Id       string `json:"id"`
+	BlobSize uint64 `json:"blob_size"`
+	Weight   uint64 `json:"weight"`
+	Fee      uint64 `json:"fee"`
+}
+
+/**
+	The json-full topics use the ZMQ JSON format of monerod (src/serialization/json_object.cpp),
+	which differs from the as_json of the RPC. The types below follow it and are converted to the RPC ones.
+**/
+
+// The byte vectors are either hex strings or arrays of numbers
+type zmqBytes []byte
+
+func (b *zmqBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		res, err := hex.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		*b = res
+		return nil
+	}
+
+	var res []byte
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	*b = res
+	return nil
+}
+
+type zmqInput struct {
+	Gen *struct {
+		Height uint64 `json:"height"`
+	} `json:"gen"`
+	ToKey *struct {
+		Amount     uint64  `json:"amount"`
+		KeyOffsets []int64 `json:"key_offsets"`
+		KeyImage   string  `json:"key_image"`
+	} `json:"to_key"`
+}
+
+type zmqOutput struct {
+	Amount uint64 `json:"amount"`
+	ToKey  *struct {
+		Key string `json:"key"`
+	} `json:"to_key"`
+	ToTaggedKey *struct {
+		Key     string `json:"key"`
+		ViewTag string `json:"view_tag"`
+	} `json:"to_tagged_key"`
+}
+
+type zmqRctSignatures struct {
+	Type      int32 `json:"type"`
+	Encrypted []struct {
+		Mask   string `json:"mask"`
+		Amount string `json:"amount"`
+	} `json:"encrypted"`
+	Commitments []string `json:"commitments"`
+	Fee         uint64   `json:"fee"`
+	Prunable    *struct {
+		BulletproofsPlus []daemon.Bpp   `json:"bulletproofs_plus"`
+		Clsags           []daemon.CLSAG `json:"clsags"`
+		PseudoOuts       []string       `json:"pseudo_outs"`
+	} `json:"prunable"`
+}
+
+type zmqTx struct {
+	Version    uint32           `json:"version"`
+	UnlockTime uint64           `json:"unlock_time"`
+	Inputs     []zmqInput       `json:"inputs"`
+	Outputs    []zmqOutput      `json:"outputs"`
+	Extra      zmqBytes         `json:"extra"`
+	Ringct     zmqRctSignatures `json:"ringct"`
+}
+
+type zmqBlock struct {
+	MajorVersion uint     `json:"major_version"`
+	MinorVersion uint     `json:"minor_version"`
+	Timestamp    uint32   `json:"timestamp"`
+	PrevId       string   `json:"prev_id"`
+	Nonce        uint64   `json:"nonce"`
+	MinerTx      zmqTx    `json:"miner_tx"`
+	TxHashes     []string `json:"tx_hashes"`
+}
+
+func (o *zmqOutput) toVoutHelper() daemon.Vout1 {
+	res := daemon.Vout1{Amount: o.Amount}
+	if o.ToTaggedKey != nil {
+		res.Target.TaggedKey = daemon.TaggedKey{Key: o.ToTaggedKey.Key, ViewTag: o.ToTaggedKey.ViewTag}
+	}
+	if o.ToKey != nil {
+		res.Target.Key = o.ToKey.Key
+	}
+	return res
+}
+
+func (t *zmqTx) toTxInfoHelper() daemon.MoneroTxInfo {
+	res := daemon.MoneroTxInfo{
+		Version:    t.Version,
+		UnlockTime: t.UnlockTime,
+		Extra:      t.Extra,
+		RctSignatures: daemon.RctSignature{
+			Type:   t.Ringct.Type,
+			TxnFee: t.Ringct.Fee,
+			OutPk:  t.Ringct.Commitments,
+		},
+	}
+
+	for _, v := range t.Inputs {
+		if v.ToKey != nil {
+			res.Vin = append(res.Vin, daemon.Vin2{Key: daemon.Key{Amount: v.ToKey.Amount, KeyOffsets: v.ToKey.KeyOffsets, KeyImage: v.ToKey.KeyImage}})
+		}
+	}
+	for i := range t.Outputs {
+		res.Vout = append(res.Vout, t.Outputs[i].toVoutHelper())
+	}
+
+	// the RPC only shows the first 8 bytes of the compact amounts as trunc_amount
+	for _, v := range t.Ringct.Encrypted {
+		if t.Ringct.Type >= int32(tx.RCT_TYPE_BULLETPROOF2) {
+			amount := v.Amount
+			if len(amount) > 16 {
+				amount = amount[:16]
+			}
+			res.RctSignatures.EcdhInfo = append(res.RctSignatures.EcdhInfo, daemon.EcdhInfo{TruncAmount: amount})
+		} else {
+			res.RctSignatures.EcdhInfo = append(res.RctSignatures.EcdhInfo, daemon.EcdhInfo{Mask: v.Mask, Amount: v.Amount})
+		}
+	}
+
+	if p := t.Ringct.Prunable; p != nil {
+		res.RctsigPrunable = daemon.RctsigPrunable{
+			CLSAGs:     p.Clsags,
+			Bpp:        p.BulletproofsPlus,
+			Nbp:        int32(len(p.BulletproofsPlus)),
+			PseudoOuts: p.PseudoOuts,
+		}
+	}
+
+	return res
+}
+
+func (t *zmqTx) toMinerTxHelper() daemon.MinerTx {
+	res := daemon.MinerTx{
+		Version:       t.Version,
+		UnlockTime:    t.UnlockTime,
+		RctSignatures: daemon.RctSignatures{Type: uint32(t.Ringct.Type)},
+	}
+
+	for _, v := range t.Inputs {
+		if v.Gen != nil {
+			res.Vin = append(res.Vin, daemon.Vin1{Gen: daemon.Gen{Height: v.Gen.Height}})
+		}
+	}
+	for i := range t.Outputs {
+		res.Vout = append(res.Vout, t.Outputs[i].toVoutHelper())
+	}
+	for _, v := range t.Extra {
+		res.Extra = append(res.Extra, int32(v))
+	}
+
+	return res
+}
+
+func (b *zmqBlock) toBlockDetailsHelper() daemon.BlockDetails {
+	return daemon.BlockDetails{
+		MajorVersion: b.MajorVersion,
+		MinorVersion: b.MinorVersion,
+		Timestamp:    b.Timestamp,
+		PrevId:       b.PrevId,
+		Nonce:        b.Nonce,
+		MinerTx:      b.MinerTx.toMinerTxHelper(),
+		TxHashes:     b.TxHashes,
+	}
+}
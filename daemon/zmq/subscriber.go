@@ -0,0 +1,278 @@
+package zmq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+)
+
+const (
+	DEFAULT_ZMQ_RECONNECT_INTERVAL time.Duration = 5 * time.Second
+	DEFAULT_ZMQ_DIAL_TIMEOUT       time.Duration = 10 * time.Second
+	DEFAULT_ZMQ_BUFFER_SIZE        int           = 16
+)
+
+var (
+	ErrUnsupportedAddress = errors.New("zmq: unsupported address")
+	ErrUnknownTopic       = errors.New("zmq: unknown topic")
+	ErrSubscriberStarted  = errors.New("zmq: the subscriber has already been started")
+)
+
+var knownTopics = map[Topic]bool{
+	TOPIC_JSON_MINIMAL_CHAIN_MAIN: true,
+	TOPIC_JSON_FULL_CHAIN_MAIN:    true,
+	TOPIC_JSON_MINIMAL_TXPOOL_ADD: true,
+	TOPIC_JSON_FULL_TXPOOL_ADD:    true,
+}
+
+// The zero values of the fields are replaced with the defaults.
+type Config struct {
+	// The --zmq-pub address of monerod: tcp://host:port, ipc:///path or host:port
+	Address string
+	// All the json topics if empty
+	Topics            []Topic
+	ReconnectInterval time.Duration
+	// Limits both the dialing and the handshake
+	DialTimeout time.Duration
+	// The capacity of the channels
+	BufferSize int
+}
+
+// Subscriber receives the notifications published by monerod over ZMQ (--zmq-pub)
+// and delivers them on the channels of the subscribed topics.
+// The connection is reestablished after ReconnectInterval whenever it fails.
+// The channels aren't dropped from, so a slow reader delays the reading of the following notifications.
+type Subscriber struct {
+	network string
+	address string
+	config  Config
+	started atomic.Bool
+
+	chainMain     chan *MinimalChainMain
+	fullChainMain chan []daemon.BlockDetails
+	txPoolAdd     chan []MinimalTxPoolAdd
+	fullTxPoolAdd chan []daemon.MoneroTxInfo
+	errs          chan error
+}
+
+func parseAddressHelper(addr string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(addr, "tcp://"):
+		return "tcp", strings.TrimPrefix(addr, "tcp://"), nil
+	case strings.HasPrefix(addr, "ipc://"):
+		return "unix", strings.TrimPrefix(addr, "ipc://"), nil
+	case strings.Contains(addr, "://") || addr == "":
+		return "", "", fmt.Errorf("%w: %q", ErrUnsupportedAddress, addr)
+	default:
+		return "tcp", addr, nil
+	}
+}
+
+func NewSubscriber(config Config) (*Subscriber, error) {
+	network, address, err := parseAddressHelper(config.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.Topics) == 0 {
+		config.Topics = []Topic{TOPIC_JSON_MINIMAL_CHAIN_MAIN, TOPIC_JSON_FULL_CHAIN_MAIN, TOPIC_JSON_MINIMAL_TXPOOL_ADD, TOPIC_JSON_FULL_TXPOOL_ADD}
+	}
+	for _, v := range config.Topics {
+		if !knownTopics[v] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTopic, v)
+		}
+	}
+	if config.ReconnectInterval <= 0 {
+		config.ReconnectInterval = DEFAULT_ZMQ_RECONNECT_INTERVAL
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = DEFAULT_ZMQ_DIAL_TIMEOUT
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = DEFAULT_ZMQ_BUFFER_SIZE
+	}
+
+	return &Subscriber{
+		network:       network,
+		address:       address,
+		config:        config,
+		chainMain:     make(chan *MinimalChainMain, config.BufferSize),
+		fullChainMain: make(chan []daemon.BlockDetails, config.BufferSize),
+		txPoolAdd:     make(chan []MinimalTxPoolAdd, config.BufferSize),
+		fullTxPoolAdd: make(chan []daemon.MoneroTxInfo, config.BufferSize),
+		errs:          make(chan error, config.BufferSize),
+	}, nil
+}
+
+// json-minimal-chain_main
+func (s *Subscriber) ChainMain() <-chan *MinimalChainMain {
+	return s.chainMain
+}
+
+// json-full-chain_main, the blocks added to the main chain
+func (s *Subscriber) FullChainMain() <-chan []daemon.BlockDetails {
+	return s.fullChainMain
+}
+
+// json-minimal-txpool_add
+func (s *Subscriber) TxPoolAdd() <-chan []MinimalTxPoolAdd {
+	return s.txPoolAdd
+}
+
+// json-full-txpool_add, the txs added to the pool. monerod doesn't publish their hashes with this topic.
+func (s *Subscriber) FullTxPoolAdd() <-chan []daemon.MoneroTxInfo {
+	return s.fullTxPoolAdd
+}
+
+// The connection and decoding errors. They are dropped if the channel is full.
+func (s *Subscriber) Errors() <-chan error {
+	return s.errs
+}
+
+// Connects to the publisher and delivers the notifications until the ctx is done, reconnecting on failures.
+// All the channels are closed when it returns. A Subscriber can only be run once.
+func (s *Subscriber) Run(ctx context.Context) error {
+	if !s.started.CompareAndSwap(false, true) {
+		return ErrSubscriberStarted
+	}
+	defer func() {
+		close(s.chainMain)
+		close(s.fullChainMain)
+		close(s.txPoolAdd)
+		close(s.fullTxPoolAdd)
+		close(s.errs)
+	}()
+
+	for {
+		if err := s.runConnectionHelper(ctx); err != nil && ctx.Err() == nil {
+			s.reportHelper(err)
+		}
+
+		select {
+		case <-time.After(s.config.ReconnectInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Subscriber) reportHelper(err error) {
+	select {
+	case s.errs <- err:
+	default:
+	}
+}
+
+func (s *Subscriber) connectHelper(ctx context.Context) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: s.config.DialTimeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(s.config.DialTimeout))
+	if err := handshakeHelper(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	for _, v := range s.config.Topics {
+		if err := subscribeHelper(conn, string(v)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	conn.SetDeadline(time.Time{})
+
+	return conn, nil
+}
+
+// Reads the messages until the connection fails or the ctx is done
+func (s *Subscriber) runConnectionHelper(ctx context.Context) error {
+	conn, err := s.connectHelper(ctx)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	for {
+		msg, err := readMessageHelper(conn)
+		if err != nil {
+			return err
+		}
+
+		if err := s.dispatchHelper(ctx, msg); err != nil && ctx.Err() == nil {
+			s.reportHelper(err)
+		}
+	}
+}
+
+// monerod publishes the messages as "topic:json"
+func (s *Subscriber) dispatchHelper(ctx context.Context, msg []byte) error {
+	topic, data, ok := bytes.Cut(msg, []byte{':'})
+	if !ok {
+		return fmt.Errorf("zmq: malformed message: %.64q", msg)
+	}
+
+	switch Topic(topic) {
+	case TOPIC_JSON_MINIMAL_CHAIN_MAIN:
+		res := &MinimalChainMain{}
+		if err := json.Unmarshal(data, res); err != nil {
+			return fmt.Errorf("zmq: %s: %w", topic, err)
+		}
+		return sendHelper(ctx, s.chainMain, res)
+	case TOPIC_JSON_FULL_CHAIN_MAIN:
+		var blocks []zmqBlock
+		if err := json.Unmarshal(data, &blocks); err != nil {
+			return fmt.Errorf("zmq: %s: %w", topic, err)
+		}
+		res := make([]daemon.BlockDetails, len(blocks))
+		for i := range blocks {
+			res[i] = blocks[i].toBlockDetailsHelper()
+		}
+		return sendHelper(ctx, s.fullChainMain, res)
+	case TOPIC_JSON_MINIMAL_TXPOOL_ADD:
+		var res []MinimalTxPoolAdd
+		if err := json.Unmarshal(data, &res); err != nil {
+			return fmt.Errorf("zmq: %s: %w", topic, err)
+		}
+		return sendHelper(ctx, s.txPoolAdd, res)
+	case TOPIC_JSON_FULL_TXPOOL_ADD:
+		var txs []zmqTx
+		if err := json.Unmarshal(data, &txs); err != nil {
+			return fmt.Errorf("zmq: %s: %w", topic, err)
+		}
+		res := make([]daemon.MoneroTxInfo, len(txs))
+		for i := range txs {
+			res[i] = txs[i].toTxInfoHelper()
+		}
+		return sendHelper(ctx, s.fullTxPoolAdd, res)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownTopic, topic)
+	}
+}
+
+func sendHelper[T any](ctx context.Context, ch chan<- T, v T) error {
+	select {
+	case ch <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
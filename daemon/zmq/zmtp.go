@@ -0,0 +1,199 @@
+package zmq
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+/**
+	A minimal ZMTP 3.0 (https://rfc.zeromq.org/spec/23/) SUB socket: the NULL security mechanism,
+	a single peer and no heartbeats. That is all it takes to talk to the monerod ZMQ publisher.
+	The version 3.0 is announced on purpose, so the publisher accepts the subscriptions sent as messages.
+**/
+
+const (
+	ZMTP_GREETING_SIZE int = 64
+	// The biggest frame accepted from the publisher
+	MAX_ZMQ_FRAME_SIZE uint64 = 128 << 20
+
+	frameFlagMore    byte = 0x01
+	frameFlagLong    byte = 0x02
+	frameFlagCommand byte = 0x04
+)
+
+var (
+	ErrInvalidGreeting    = errors.New("zmq: invalid greeting")
+	ErrUnsupportedVersion = errors.New("zmq: unsupported ZMTP version")
+	ErrUnsupportedMech    = errors.New("zmq: unsupported security mechanism")
+	ErrInvalidHandshake   = errors.New("zmq: invalid handshake")
+	ErrFrameTooBig        = errors.New("zmq: frame too big")
+)
+
+func greetingHelper() []byte {
+	g := make([]byte, ZMTP_GREETING_SIZE)
+	g[0] = 0xff
+	g[9] = 0x7f
+	g[10], g[11] = 3, 0
+	copy(g[12:32], "NULL")
+	return g
+}
+
+func readGreetingHelper(r io.Reader) error {
+	g := make([]byte, ZMTP_GREETING_SIZE)
+	if _, err := io.ReadFull(r, g); err != nil {
+		return err
+	}
+
+	if g[0] != 0xff || g[9] != 0x7f {
+		return ErrInvalidGreeting
+	}
+	if g[10] < 3 {
+		return fmt.Errorf("%w: %d.%d", ErrUnsupportedVersion, g[10], g[11])
+	}
+	if mech := string(bytes.TrimRight(g[12:32], "\x00")); mech != "NULL" {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMech, mech)
+	}
+
+	return nil
+}
+
+func writeFrameHelper(w io.Writer, flags byte, body []byte) error {
+	var header []byte
+	if len(body) > 255 {
+		header = binary.BigEndian.AppendUint64([]byte{flags | frameFlagLong}, uint64(len(body)))
+	} else {
+		header = []byte{flags, byte(len(body))}
+	}
+
+	if _, err := w.Write(append(header, body...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Returns the flags and the body of the next frame
+func readFrameHelper(r io.Reader) (byte, []byte, error) {
+	var flags [1]byte
+	if _, err := io.ReadFull(r, flags[:]); err != nil {
+		return 0, nil, err
+	}
+
+	var size uint64
+	if flags[0]&frameFlagLong != 0 {
+		var b [8]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, nil, err
+		}
+		size = binary.BigEndian.Uint64(b[:])
+	} else {
+		var b [1]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, nil, err
+		}
+		size = uint64(b[0])
+	}
+	if size > MAX_ZMQ_FRAME_SIZE {
+		return 0, nil, fmt.Errorf("%w: %d bytes", ErrFrameTooBig, size)
+	}
+
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+
+	return flags[0], body, nil
+}
+
+// The READY command with the Socket-Type property
+func readyCommandHelper(socketType string) []byte {
+	cmd := append([]byte{5}, "READY"...)
+	cmd = append(cmd, byte(len("Socket-Type")))
+	cmd = append(cmd, "Socket-Type"...)
+	cmd = binary.BigEndian.AppendUint32(cmd, uint32(len(socketType)))
+	return append(cmd, socketType...)
+}
+
+// Parses the READY command and returns its properties
+func parseReadyCommandHelper(cmd []byte) (map[string]string, error) {
+	if len(cmd) < 6 || cmd[0] != 5 || string(cmd[1:6]) != "READY" {
+		return nil, fmt.Errorf("%w: READY expected", ErrInvalidHandshake)
+	}
+
+	props := make(map[string]string)
+	for rest := cmd[6:]; len(rest) > 0; {
+		nameLen := int(rest[0])
+		if len(rest) < 1+nameLen+4 {
+			return nil, fmt.Errorf("%w: malformed property", ErrInvalidHandshake)
+		}
+		name := string(rest[1 : 1+nameLen])
+		rest = rest[1+nameLen:]
+
+		valueLen := binary.BigEndian.Uint32(rest)
+		rest = rest[4:]
+		if uint64(len(rest)) < uint64(valueLen) {
+			return nil, fmt.Errorf("%w: malformed property", ErrInvalidHandshake)
+		}
+		props[name] = string(rest[:valueLen])
+		rest = rest[valueLen:]
+	}
+
+	return props, nil
+}
+
+// Exchanges the greetings and the READY commands as a SUB socket
+func handshakeHelper(rw io.ReadWriter) error {
+	if _, err := rw.Write(greetingHelper()); err != nil {
+		return err
+	}
+	if err := readGreetingHelper(rw); err != nil {
+		return err
+	}
+
+	if err := writeFrameHelper(rw, frameFlagCommand, readyCommandHelper("SUB")); err != nil {
+		return err
+	}
+	flags, cmd, err := readFrameHelper(rw)
+	if err != nil {
+		return err
+	}
+	if flags&frameFlagCommand == 0 {
+		return fmt.Errorf("%w: command expected", ErrInvalidHandshake)
+	}
+
+	props, err := parseReadyCommandHelper(cmd)
+	if err != nil {
+		return err
+	}
+	if t := props["Socket-Type"]; t != "PUB" && t != "XPUB" {
+		return fmt.Errorf("%w: incompatible socket type %q", ErrInvalidHandshake, t)
+	}
+
+	return nil
+}
+
+// ZMTP 3.0 subscriptions are messages starting with 1
+func subscribeHelper(w io.Writer, topic string) error {
+	return writeFrameHelper(w, 0, append([]byte{1}, topic...))
+}
+
+// Reads the frames up to the end of the next message, the commands are skipped
+func readMessageHelper(r io.Reader) ([]byte, error) {
+	var msg []byte
+	for {
+		flags, body, err := readFrameHelper(r)
+		if err != nil {
+			return nil, err
+		}
+		if flags&frameFlagCommand != 0 {
+			continue
+		}
+
+		msg = append(msg, body...)
+		if flags&frameFlagMore == 0 {
+			return msg, nil
+		}
+	}
+}
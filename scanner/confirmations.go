@@ -0,0 +1,344 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/chekist32/go-monero/daemon"
+)
+
+type ConfirmationStatus uint8
+
+const (
+	// The tx hasn't been seen by the daemon yet
+	TxUnseen ConfirmationStatus = iota
+	TxInPool
+	// The tx is in a main chain block but doesn't have enough confirmations yet
+	TxMined
+	TxConfirmed
+	// The tx has left the pool (or its block has been reorganized) and the daemon doesn't know it anymore
+	TxDropped
+)
+
+var ErrTxNotTracked = errors.New("the tx isn't tracked")
+
+// TrackedTx is the confirmation state of a tracked tx.
+// Height and BlockHash are the block the tx has been mined in (empty if it's not in the main chain).
+type TrackedTx struct {
+	TxHash                string             `json:"tx_hash"`
+	RequiredConfirmations uint64             `json:"required_confirmations"`
+	Status                ConfirmationStatus `json:"status"`
+	Height                uint64             `json:"height"`
+	BlockHash             string             `json:"block_hash"`
+	Confirmations         uint64             `json:"confirmations"`
+	DoubleSpendSeen       bool               `json:"double_spend_seen"`
+}
+
+func (t *TrackedTx) minedHelper() bool {
+	return t.Status == TxMined || t.Status == TxConfirmed
+}
+
+// ConfirmationStore persists the state of the ConfirmationTracker, so it survives restarts.
+type ConfirmationStore interface {
+	Load() ([]TrackedTx, error)
+	Save(tx TrackedTx) error
+	Delete(txHash string) error
+}
+
+// MemoryConfirmationStore keeps the state in memory only, it is the default store.
+type MemoryConfirmationStore struct {
+	txs map[string]TrackedTx
+	mu  sync.Mutex
+}
+
+func NewMemoryConfirmationStore() *MemoryConfirmationStore {
+	return &MemoryConfirmationStore{txs: make(map[string]TrackedTx)}
+}
+
+func (s *MemoryConfirmationStore) Load() ([]TrackedTx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]TrackedTx, 0, len(s.txs))
+	for _, v := range s.txs {
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func (s *MemoryConfirmationStore) Save(tx TrackedTx) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.txs[tx.TxHash] = tx
+	return nil
+}
+
+func (s *MemoryConfirmationStore) Delete(txHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.txs, txHash)
+	return nil
+}
+
+// The callbacks are called from Update after the state has been saved, the nil ones are skipped.
+type ConfirmationCallbacks struct {
+	// The tx has got the required number of confirmations (again, if it has been reorganized out before)
+	OnConfirmed func(tx TrackedTx)
+	// The block the tx has been mined in has left the main chain. The tx is back in the pool, dropped or mined in another block.
+	OnReorgedOut func(tx TrackedTx)
+	// The daemon has seen a double spend of the tx
+	OnDoubleSpendSeen func(tx TrackedTx)
+	// The tx has left the pool without being mined
+	OnDropped func(tx TrackedTx)
+}
+
+// ConfirmationTracker gives the "N confirmations and still in the main chain" semantics to the incoming payments.
+// Unlike MoneroTx1.Confirmations, the state is kept between the updates, so the txs that have been confirmed
+// and then reorganized out are reported. The confirmed txs stay tracked until they are untracked.
+type ConfirmationTracker struct {
+	client    daemon.IDaemonRpcClient
+	store     ConfirmationStore
+	callbacks ConfirmationCallbacks
+	txs       map[string]*TrackedTx
+	// serializes the updates, mu guards txs
+	updateMu sync.Mutex
+	mu       sync.RWMutex
+}
+
+// Creates a ConfirmationTracker and restores the tracked txs from the store (a MemoryConfirmationStore if nil).
+func NewConfirmationTracker(client daemon.IDaemonRpcClient, store ConfirmationStore, callbacks ConfirmationCallbacks) (*ConfirmationTracker, error) {
+	if store == nil {
+		store = NewMemoryConfirmationStore()
+	}
+
+	txs, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &ConfirmationTracker{client: client, store: store, callbacks: callbacks, txs: make(map[string]*TrackedTx, len(txs))}
+	for i := range txs {
+		t.txs[txs[i].TxHash] = &txs[i]
+	}
+
+	return t, nil
+}
+
+// Starts tracking the tx. Tracking the same tx again only changes the required confirmations.
+func (t *ConfirmationTracker) Track(txHash string, confirmations uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	v, ok := t.txs[txHash]
+	if !ok {
+		v = &TrackedTx{TxHash: txHash}
+	}
+	res := *v
+	res.RequiredConfirmations = confirmations
+	if err := t.store.Save(res); err != nil {
+		return err
+	}
+
+	t.txs[txHash] = &res
+	return nil
+}
+
+func (t *ConfirmationTracker) Untrack(txHash string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.txs[txHash]; !ok {
+		return ErrTxNotTracked
+	}
+	if err := t.store.Delete(txHash); err != nil {
+		return err
+	}
+
+	delete(t.txs, txHash)
+	return nil
+}
+
+func (t *ConfirmationTracker) Get(txHash string) (TrackedTx, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	v, ok := t.txs[txHash]
+	if !ok {
+		return TrackedTx{}, false
+	}
+
+	return *v, true
+}
+
+// Returns all the tracked txs.
+func (t *ConfirmationTracker) Txs() []TrackedTx {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	res := make([]TrackedTx, 0, len(t.txs))
+	for _, v := range t.txs {
+		res = append(res, *v)
+	}
+
+	return res
+}
+
+type confirmationEventHelper struct {
+	callback func(tx TrackedTx)
+	tx       TrackedTx
+}
+
+type confirmationUpdateHelper struct {
+	old TrackedTx
+	tx  TrackedTx
+}
+
+// Queries the daemon for the tracked txs and the chain tip, updates the states and calls the callbacks.
+// Should be called on every new block (e.g. on daemon.NewBlockEvent) or periodically.
+// If a state can't be saved, the callbacks of the already saved txs are still called before the error is returned.
+func (t *ConfirmationTracker) Update(ctx context.Context) error {
+	t.updateMu.Lock()
+	defer t.updateMu.Unlock()
+
+	t.mu.RLock()
+	hashes := make([]string, 0, len(t.txs))
+	for k := range t.txs {
+		hashes = append(hashes, k)
+	}
+	t.mu.RUnlock()
+
+	found := make(map[string]*daemon.MoneroTx1, len(hashes))
+	for start := 0; start < len(hashes); start += daemon.MAX_TXS_PER_REQUEST {
+		end := start + daemon.MAX_TXS_PER_REQUEST
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+
+		txs, err := t.client.GetTransactionsCtx(ctx, hashes[start:end], false, false, false)
+		if err != nil {
+			return err
+		}
+		for i := range txs.Txs {
+			found[txs.Txs[i].TxHash] = &txs.Txs[i]
+		}
+	}
+
+	// the tip is requested after the txs, so they can't be mined above it
+	last, err := t.client.GetLastBlockHeaderCtx(ctx, false)
+	if err != nil {
+		return err
+	}
+	top := last.Result.BlockHeader.Height
+
+	blockHashes := make(map[uint64]string)
+	blockHashHelper := func(height uint64) (string, error) {
+		if h, ok := blockHashes[height]; ok {
+			return h, nil
+		}
+		header, err := t.client.GetBlockHeaderByHeightCtx(ctx, false, height)
+		if err != nil {
+			return "", err
+		}
+		blockHashes[height] = header.Result.BlockHeader.Hash
+		return header.Result.BlockHeader.Hash, nil
+	}
+
+	// all the new states are computed before anything is saved, so a failed lookup doesn't lose the callbacks
+	var updates []confirmationUpdateHelper
+	for _, hash := range hashes {
+		t.mu.RLock()
+		old, ok := t.txs[hash]
+		t.mu.RUnlock()
+		if !ok {
+			// untracked during the update
+			continue
+		}
+
+		v := *old
+		v.Height, v.BlockHash, v.Confirmations = 0, "", 0
+
+		tx, ok := found[hash]
+		switch {
+		case !ok:
+			if old.Status != TxUnseen {
+				v.Status = TxDropped
+			}
+		case tx.InPool:
+			v.Status = TxInPool
+			v.DoubleSpendSeen = v.DoubleSpendSeen || tx.DoubleSpendSeen
+		default:
+			blockHash, err := blockHashHelper(tx.BlockHeight)
+			if err != nil {
+				return err
+			}
+
+			v.Height, v.BlockHash = tx.BlockHeight, blockHash
+			if top >= tx.BlockHeight {
+				v.Confirmations = top - tx.BlockHeight + 1
+			}
+			v.Status = TxMined
+			if v.Confirmations >= v.RequiredConfirmations {
+				v.Status = TxConfirmed
+			}
+			v.DoubleSpendSeen = v.DoubleSpendSeen || tx.DoubleSpendSeen
+		}
+
+		if v != *old {
+			updates = append(updates, confirmationUpdateHelper{old: *old, tx: v})
+		}
+	}
+
+	// the callbacks of the saved txs are called even if a later save fails
+	var events []confirmationEventHelper
+	emitHelper := func(callback func(tx TrackedTx), tx TrackedTx) {
+		if callback != nil {
+			events = append(events, confirmationEventHelper{callback, tx})
+		}
+	}
+
+	for _, u := range updates {
+		old, v := u.old, u.tx
+
+		t.mu.Lock()
+		cur, ok := t.txs[v.TxHash]
+		if ok {
+			// keeps the confirmations required by a concurrent Track, they are taken into account on the next update
+			v.RequiredConfirmations = cur.RequiredConfirmations
+			err = t.store.Save(v)
+			if err == nil {
+				*cur = v
+			}
+		}
+		t.mu.Unlock()
+
+		if err != nil {
+			break
+		}
+		if !ok {
+			continue
+		}
+
+		// the block the tx was in isn't in the main chain anymore
+		if old.minedHelper() && (!v.minedHelper() || v.Height != old.Height || v.BlockHash != old.BlockHash) {
+			emitHelper(t.callbacks.OnReorgedOut, v)
+		}
+		if v.Status == TxConfirmed && (old.Status != TxConfirmed || v.BlockHash != old.BlockHash) {
+			emitHelper(t.callbacks.OnConfirmed, v)
+		}
+		if v.Status == TxDropped && old.Status != TxDropped {
+			emitHelper(t.callbacks.OnDropped, v)
+		}
+		if v.DoubleSpendSeen && !old.DoubleSpendSeen {
+			emitHelper(t.callbacks.OnDoubleSpendSeen, v)
+		}
+	}
+
+	for _, v := range events {
+		v.callback(v.tx)
+	}
+
+	return err
+}
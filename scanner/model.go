@@ -0,0 +1,28 @@
+package scanner
+
+import "github.com/chekist32/go-monero/utils"
+
+type SubaddressIndex = utils.SubaddressIndex
+
+// Describes the scanning progress. Height is the next block to be scanned
+// and Hash is the id of the last scanned block (empty if nothing has been scanned yet).
+type Checkpoint struct {
+	Height uint64 `json:"height"`
+	Hash   string `json:"hash"`
+}
+
+type OwnedOutput struct {
+	TxHash          string          `json:"tx_hash"`
+	TxPubKey        string          `json:"tx_pub_key"`
+	BlockHash       string          `json:"block_hash"`
+	Height          uint64          `json:"height"`
+	OutputIndex     uint32          `json:"output_index"`
+	GlobalIndex     uint64          `json:"global_index"`
+	PublicKey       string          `json:"public_key"`
+	Amount          uint64          `json:"amount"`
+	UnlockTime      uint64          `json:"unlock_time"`
+	Coinbase        bool            `json:"coinbase"`
+	SubaddressIndex SubaddressIndex `json:"subaddress_index"`
+	// The plaintext (decrypted) payment id in hex, empty if the tx doesn't have one
+	PaymentID string `json:"payment_id"`
+}
@@ -0,0 +1,314 @@
+package scanner
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/utils"
+)
+
+var (
+	ErrCheckpointMismatch = errors.New("checkpoint doesn't match the chain, the block has been reorganized")
+	ErrMissedTxs          = errors.New("the daemon didn't return some of the block txs")
+)
+
+// Scanner walks the blockchain block by block and looks for the outputs
+// that belong to the given view key and the set of subaddresses.
+type Scanner struct {
+	client     daemon.IDaemonRpcClient
+	table      *utils.SubaddressTable
+	checkpoint Checkpoint
+	tracker    *SpentTracker
+}
+
+func newSubaddressTableHelper(keys *utils.ViewOnlyKeyPair, subaddrs []SubaddressIndex) (*utils.SubaddressTable, error) {
+	if len(subaddrs) == 0 {
+		subaddrs = []SubaddressIndex{{Major: 0, Minor: 0}}
+	}
+
+	table, err := utils.NewSubaddressTable(keys, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range subaddrs {
+		if err := table.Add(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return table, nil
+}
+
+// Creates a Scanner that starts from the startHeight block.
+// If subaddrs is empty, only the primary address (0, 0) is looked for.
+func NewScanner(client daemon.IDaemonRpcClient, keys *utils.ViewOnlyKeyPair, subaddrs []SubaddressIndex, startHeight uint64) (*Scanner, error) {
+	return NewScannerFromCheckpoint(client, keys, subaddrs, Checkpoint{Height: startHeight})
+}
+
+// Creates a Scanner that resumes scanning from the previously saved checkpoint.
+func NewScannerFromCheckpoint(client daemon.IDaemonRpcClient, keys *utils.ViewOnlyKeyPair, subaddrs []SubaddressIndex, checkpoint Checkpoint) (*Scanner, error) {
+	table, err := newSubaddressTableHelper(keys, subaddrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewScannerWithSubaddressTable(client, table, checkpoint), nil
+}
+
+// Creates a Scanner that looks for the outputs of all the (sub)addresses in the table.
+// The table can be grown while scanning.
+func NewScannerWithSubaddressTable(client daemon.IDaemonRpcClient, table *utils.SubaddressTable, checkpoint Checkpoint) *Scanner {
+	return &Scanner{client: client, table: table, checkpoint: checkpoint}
+}
+
+// Makes the scanner feed the found outputs and the inputs of the scanned txs to the tracker,
+// so their spent status is kept up to date while scanning.
+func (s *Scanner) TrackSpent(tracker *SpentTracker) {
+	s.tracker = tracker
+}
+
+// Returns the current scanning progress, which can be persisted and passed to NewScannerFromCheckpoint later.
+func (s *Scanner) Checkpoint() Checkpoint {
+	return s.checkpoint
+}
+
+// Scans the blocks from the current checkpoint up to the current chain height and sends the owned outputs to the outputs channel.
+// The checkpoint is advanced only after all the outputs of a block have been sent, so it is safe to persist it at any time.
+func (s *Scanner) Scan(ctx context.Context, outputs chan<- OwnedOutput) error {
+	res, err := s.client.GetCurrentHeightCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	for s.checkpoint.Height < res.Height {
+		owned, hash, err := s.scanBlock(ctx, s.checkpoint.Height)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range owned {
+			select {
+			case outputs <- v:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		s.checkpoint = Checkpoint{Height: s.checkpoint.Height + 1, Hash: hash}
+	}
+
+	return nil
+}
+
+// Scans a single block and returns the owned outputs together with the block hash.
+func (s *Scanner) scanBlock(ctx context.Context, height uint64) ([]OwnedOutput, string, error) {
+	block, err := s.client.GetBlockByHeightCtx(ctx, false, height)
+	if err != nil {
+		return nil, "", err
+	}
+
+	details := &block.Result.BlockDetails
+	hash := block.Result.BlockHeader.Hash
+	if s.checkpoint.Hash != "" && details.PrevId != s.checkpoint.Hash {
+		return nil, "", fmt.Errorf("%w: block %v has prev_id %v, expected %v", ErrCheckpointMismatch, height, details.PrevId, s.checkpoint.Hash)
+	}
+
+	owned, err := s.scanMinerTx(ctx, block.Result.MinerTxHash, hash, height, &details.MinerTx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for start := 0; start < len(details.TxHashes); start += daemon.MAX_TXS_PER_REQUEST {
+		end := start + daemon.MAX_TXS_PER_REQUEST
+		if end > len(details.TxHashes) {
+			end = len(details.TxHashes)
+		}
+
+		txs, err := s.client.GetTransactionsCtx(ctx, details.TxHashes[start:end], true, false, false)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(txs.MissedTx) != 0 {
+			return nil, "", fmt.Errorf("%w: %v", ErrMissedTxs, txs.MissedTx)
+		}
+		if len(txs.Txs) != end-start {
+			return nil, "", fmt.Errorf("%w: got %d txs instead of %d", ErrMissedTxs, len(txs.Txs), end-start)
+		}
+
+		for i := range txs.Txs {
+			res, err := s.scanTx(&txs.Txs[i], hash, height)
+			if err != nil {
+				return nil, "", err
+			}
+			owned = append(owned, res...)
+
+			if s.tracker != nil {
+				s.tracker.ProcessTx(txs.Txs[i].TxHash, &txs.Txs[i].TxInfo, height)
+			}
+		}
+	}
+
+	if s.tracker != nil {
+		// the outputs can't be spent in the same block they've been received in
+		for _, v := range owned {
+			if _, err := s.tracker.Add(v); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	return owned, hash, nil
+}
+
+func int32SliceToBytesHelper(s []int32) []byte {
+	res := make([]byte, len(s))
+	for i, v := range s {
+		res[i] = byte(v)
+	}
+
+	return res
+}
+
+func (s *Scanner) scanMinerTx(ctx context.Context, txHash string, blockHash string, height uint64, tx *daemon.MinerTx) ([]OwnedOutput, error) {
+	txPub, additionalKeys, payId := utils.ParseExtraWithDecryptedPaymentID(int32SliceToBytesHelper(tx.Extra), s.table.Keys().ViewKeyPair().PrivateKey())
+	if txPub == nil && len(additionalKeys) == 0 {
+		// the miner tx has nothing we can scan
+		return nil, nil
+	}
+
+	var owned []OwnedOutput
+	for i, out := range tx.Vout {
+		// coinbase amounts are never encrypted
+		index, key, ok, _, err := s.checkOutputHelper(&out, uint32(i), "", txPub, additionalKeys)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		owned = append(owned, OwnedOutput{
+			TxHash:          txHash,
+			TxPubKey:        hex.EncodeToString(key.Bytes()),
+			BlockHash:       blockHash,
+			Height:          height,
+			OutputIndex:     uint32(i),
+			PublicKey:       outputKeyHelper(&out),
+			Amount:          out.Amount,
+			UnlockTime:      tx.UnlockTime,
+			Coinbase:        true,
+			SubaddressIndex: index,
+			PaymentID:       hex.EncodeToString(payId),
+		})
+	}
+	if len(owned) == 0 {
+		return nil, nil
+	}
+
+	// get_block doesn't have the global indices of the miner tx outputs
+	indices, err := s.client.GetOIndexesBinCtx(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	for i := range owned {
+		if int(owned[i].OutputIndex) < len(indices.OIndexes) {
+			owned[i].GlobalIndex = indices.OIndexes[owned[i].OutputIndex]
+		}
+	}
+
+	return owned, nil
+}
+
+func (s *Scanner) scanTx(tx *daemon.MoneroTx1, blockHash string, height uint64) ([]OwnedOutput, error) {
+	txPub, additionalKeys, payId := utils.ParseExtraWithDecryptedPaymentID(tx.TxInfo.Extra, s.table.Keys().ViewKeyPair().PrivateKey())
+	if txPub == nil && len(additionalKeys) == 0 {
+		// no tx pub keys, no way to find out whether the outputs are ours
+		return nil, nil
+	}
+
+	var owned []OwnedOutput
+	for i, out := range tx.TxInfo.Vout {
+		ecdh := ""
+		if i < len(tx.TxInfo.RctSignatures.EcdhInfo) {
+			ecdh = encryptedAmountHelper(&tx.TxInfo.RctSignatures.EcdhInfo[i])
+		}
+
+		index, key, ok, amount, err := s.checkOutputHelper(&out, uint32(i), ecdh, txPub, additionalKeys)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		var globalIndex uint64
+		if i < len(tx.OutputIndices) {
+			globalIndex = tx.OutputIndices[i]
+		}
+
+		owned = append(owned, OwnedOutput{
+			TxHash:          tx.TxHash,
+			TxPubKey:        hex.EncodeToString(key.Bytes()),
+			BlockHash:       blockHash,
+			Height:          height,
+			OutputIndex:     uint32(i),
+			GlobalIndex:     globalIndex,
+			PublicKey:       outputKeyHelper(&out),
+			Amount:          amount,
+			UnlockTime:      tx.TxInfo.UnlockTime,
+			SubaddressIndex: index,
+			PaymentID:       hex.EncodeToString(payId),
+		})
+	}
+
+	return owned, nil
+}
+
+func outputKeyHelper(out *daemon.Vout1) string {
+	if out.Target.TaggedKey.Key != "" {
+		return out.Target.TaggedKey.Key
+	}
+	return out.Target.Key
+}
+
+// The RCT types before Bulletproofs2 have the whole 32 byte amount instead of the truncated one, the decryption handles both
+func encryptedAmountHelper(info *daemon.EcdhInfo) string {
+	if info.TruncAmount != "" {
+		return info.TruncAmount
+	}
+	return info.Amount
+}
+
+// Checks whether the output belongs to any of the scanned subaddresses and returns the tx pub key it has been derived from.
+// If ecdh is empty, the cleartext out.Amount is returned.
+func (s *Scanner) checkOutputHelper(out *daemon.Vout1, outIndex uint32, ecdh string, txPub *utils.PublicKey, additionalKeys []*utils.PublicKey) (SubaddressIndex, *utils.PublicKey, bool, uint64, error) {
+	if len(out.Target.TaggedKey.ViewTag) == 2 {
+		res, err := utils.OutputBelongsViewTagAdditional(out.Target.TaggedKey.ViewTag, outIndex, txPub, additionalKeys, s.table.Keys().ViewKeyPair().PrivateKey())
+		if err != nil {
+			return SubaddressIndex{}, nil, false, 0, err
+		}
+		if !res {
+			return SubaddressIndex{}, nil, false, 0, nil
+		}
+	}
+
+	keyStr := outputKeyHelper(out)
+	if keyStr == "" {
+		return SubaddressIndex{}, nil, false, 0, nil
+	}
+	outKey, err := utils.NewPublicKey(keyStr)
+	if err != nil {
+		return SubaddressIndex{}, nil, false, 0, err
+	}
+
+	if ecdh == "" {
+		index, key, ok, err := s.table.OutputBelongsAdditional(outIndex, outKey, txPub, additionalKeys)
+		return index, key, ok, out.Amount, err
+	}
+
+	return s.table.DecryptOutputAdditional(outIndex, outKey, ecdh, txPub, additionalKeys)
+}
@@ -0,0 +1,239 @@
+package scanner
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/utils"
+)
+
+type SpentStatus uint8
+
+const (
+	// The key image hasn't been seen anywhere
+	Unspent SpentStatus = iota
+	// The key image has been seen in a single mempool tx
+	PendingSpent
+	// The key image has been seen in several mempool txs or the daemon flagged the spending tx as a double spend
+	DoubleSpendSeen
+	// The key image has been seen in a block
+	Spent
+)
+
+// TrackedOutput is an owned output together with its key image and spent status.
+// SpentTxHash is the tx the key image has been seen in (empty if Unspent),
+// SpentHeight is the height of the block that tx has been mined in (0 if not Spent).
+type TrackedOutput struct {
+	OwnedOutput
+	KeyImage    string      `json:"key_image"`
+	Status      SpentStatus `json:"status"`
+	SpentTxHash string      `json:"spent_tx_hash"`
+	SpentHeight uint64      `json:"spent_height"`
+}
+
+// SpentTracker computes the key images of the owned outputs and matches them against
+// the inputs of the block txs and the mempool, so the balance can be known without wallet-rpc.
+type SpentTracker struct {
+	client  daemon.IDaemonRpcClient
+	keys    *utils.FullKeyPair
+	outputs map[string]*TrackedOutput
+	mu      sync.RWMutex
+}
+
+// Creates a SpentTracker. The full key pair is needed to compute the key images.
+func NewSpentTracker(client daemon.IDaemonRpcClient, keys *utils.FullKeyPair) *SpentTracker {
+	return &SpentTracker{client: client, keys: keys, outputs: make(map[string]*TrackedOutput)}
+}
+
+// Computes the key image of the output and starts tracking it. Adding the same output twice is a no-op.
+func (t *SpentTracker) Add(out OwnedOutput) (*TrackedOutput, error) {
+	txPub, err := utils.NewPublicKey(out.TxPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ki, err := utils.ComputeKeyImage(t.keys, txPub, out.OutputIndex, out.SubaddressIndex.Major, out.SubaddressIndex.Minor)
+	if err != nil {
+		return nil, err
+	}
+	kiStr := hex.EncodeToString(ki.Bytes())
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if v, ok := t.outputs[kiStr]; ok {
+		res := *v
+		return &res, nil
+	}
+
+	v := &TrackedOutput{OwnedOutput: out, KeyImage: kiStr}
+	t.outputs[kiStr] = v
+
+	res := *v
+	return &res, nil
+}
+
+// Marks the owned outputs spent by the tx inputs as Spent.
+func (t *SpentTracker) ProcessTx(txHash string, tx *daemon.MoneroTxInfo, height uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, in := range tx.Vin {
+		v, ok := t.outputs[in.Key.KeyImage]
+		if !ok {
+			continue
+		}
+
+		v.Status = Spent
+		v.SpentTxHash = txHash
+		v.SpentHeight = height
+	}
+}
+
+// Fetches the block with the given height and its txs and processes their inputs.
+// Returns ErrMissedTxs if the daemon doesn't return all the txs of the block.
+func (t *SpentTracker) ProcessBlock(ctx context.Context, height uint64) error {
+	block, err := t.client.GetBlockByHeightCtx(ctx, false, height)
+	if err != nil {
+		return err
+	}
+
+	hashes := block.Result.BlockDetails.TxHashes
+	for start := 0; start < len(hashes); start += daemon.MAX_TXS_PER_REQUEST {
+		end := start + daemon.MAX_TXS_PER_REQUEST
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+
+		txs, err := t.client.GetTransactionsCtx(ctx, hashes[start:end], true, false, false)
+		if err != nil {
+			return err
+		}
+		// the key images of the missed txs would never be seen
+		if len(txs.MissedTx) != 0 {
+			return fmt.Errorf("%w: %v", ErrMissedTxs, txs.MissedTx)
+		}
+		if len(txs.Txs) != end-start {
+			return fmt.Errorf("%w: got %d txs instead of %d", ErrMissedTxs, len(txs.Txs), end-start)
+		}
+
+		for i := range txs.Txs {
+			t.ProcessTx(txs.Txs[i].TxHash, &txs.Txs[i].TxInfo, height)
+		}
+	}
+
+	return nil
+}
+
+// Matches the key images of the not yet mined owned outputs against the mempool.
+// The outputs whose spending txs have left the mempool without being mined go back to Unspent.
+func (t *SpentTracker) UpdatePool(ctx context.Context) error {
+	pool, err := t.client.GetTransactionPoolCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	doubleSpends := make(map[string]bool)
+	for _, tx := range pool.Transactions {
+		if tx.DoubleSpendSeen {
+			doubleSpends[tx.IdHash] = true
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seen := make(map[string]bool)
+	for _, ki := range pool.SpentKeyImages {
+		v, ok := t.outputs[ki.IdHash]
+		if !ok || v.Status == Spent || len(ki.TxsHashes) == 0 {
+			continue
+		}
+		seen[ki.IdHash] = true
+
+		v.Status = PendingSpent
+		v.SpentTxHash = ki.TxsHashes[0]
+		if len(ki.TxsHashes) > 1 {
+			v.Status = DoubleSpendSeen
+		}
+		for _, h := range ki.TxsHashes {
+			if doubleSpends[h] {
+				v.Status = DoubleSpendSeen
+			}
+		}
+	}
+
+	for ki, v := range t.outputs {
+		if (v.Status == PendingSpent || v.Status == DoubleSpendSeen) && !seen[ki] {
+			v.Status = Unspent
+			v.SpentTxHash = ""
+		}
+	}
+
+	return nil
+}
+
+// Forgets everything that has happened at the given height and above.
+// Should be called after a reorg, before the new blocks are processed.
+func (t *SpentTracker) Rewind(height uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for ki, v := range t.outputs {
+		if v.Height >= height {
+			delete(t.outputs, ki)
+			continue
+		}
+		if v.Status == Spent && v.SpentHeight >= height {
+			v.Status = Unspent
+			v.SpentTxHash = ""
+			v.SpentHeight = 0
+		}
+	}
+}
+
+// Returns the tracked output by its key image.
+func (t *SpentTracker) Get(keyImage string) (TrackedOutput, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	v, ok := t.outputs[keyImage]
+	if !ok {
+		return TrackedOutput{}, false
+	}
+
+	return *v, true
+}
+
+// Returns all the tracked outputs.
+func (t *SpentTracker) Outputs() []TrackedOutput {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	res := make([]TrackedOutput, 0, len(t.outputs))
+	for _, v := range t.outputs {
+		res = append(res, *v)
+	}
+
+	return res
+}
+
+// Returns the sum of the Unspent outputs and the sum of the outputs being spent by the mempool txs.
+func (t *SpentTracker) Balance() (unspent uint64, pending uint64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, v := range t.outputs {
+		switch v.Status {
+		case Unspent:
+			unspent += v.Amount
+		case PendingSpent, DoubleSpendSeen:
+			pending += v.Amount
+		}
+	}
+
+	return unspent, pending
+}
@@ -96,3 +96,67 @@ func BenchmarkBlockchainScanningPerformance(b *testing.B) {
 		}
 	}
 }
+
+// 1619000 - 1620200 Height
+// 91 Txs
+func BenchmarkBlockchainScanningPerformanceSubaddressTable(b *testing.B) {
+	viewKey, err := utils.NewPrivateKey("8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009")
+	if err != nil {
+		b.Fatal(err)
+	}
+	spendKey, err := utils.NewPublicKey("38e9908d33d034de0ba1281aa7afe3907b795cea14852b3d8fe276e8931cb130")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// 10 subaddresses, the same amount as in BenchmarkBlockchainScanningPerformance
+	table, err := utils.NewSubaddressTable(utils.NewViewOnlyKeyPair(viewKey, spendKey), 2, 5)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	data, err := os.ReadFile("txs.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var txs []daemon.MoneroTx1
+	if err := json.Unmarshal(data, &txs); err != nil {
+		log.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for _, v := range txs {
+		for i := range v.TxInfo.Vout {
+			out := v.TxInfo.Vout[i]
+			am := v.TxInfo.RctSignatures.EcdhInfo[i].Amount
+			txPub, err := utils.GetTxPublicKeyFromExtra(v.TxInfo.Extra)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if len(out.Target.TaggedKey.ViewTag) == 2 {
+				res, err := utils.OutputBelongsViewTag(out.Target.TaggedKey.ViewTag, uint32(i), txPub, viewKey)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if !res {
+					continue
+				}
+			}
+
+			if out.Target.TaggedKey.Key == "" {
+				continue
+			}
+
+			outKey, err := utils.NewPublicKey(out.Target.TaggedKey.Key)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if _, _, _, err := table.DecryptOutput(uint32(i), outKey, am, txPub); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/daemon/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type batchTestRequest struct {
+	Id     string `json:"id"`
+	Method string `json:"method"`
+	Params struct {
+		Height uint64 `json:"height"`
+	} `json:"params"`
+}
+
+// Answers get_block_header_by_height with the hash derived from the height, the heights above 1000 are too big.
+// If batches are disabled, the array requests get a parse error, as monerod does.
+func getBatchTestServer(requests *atomic.Int32, batches bool) *httptest.Server {
+	answer := func(req *batchTestRequest) string {
+		if req.Params.Height > 1000 {
+			return fmt.Sprintf(`{"id": "%s", "jsonrpc": "2.0", "error": {"code": -2, "message": "Requested block height: %d greater than current top block height: 1000"}}`, req.Id, req.Params.Height)
+		}
+		return fmt.Sprintf(`{"id": "%s", "jsonrpc": "2.0", "result": {"block_header": {"hash": "hash%d", "height": %d}, "status": "OK", "untrusted": false}}`, req.Id, req.Params.Height, req.Params.Height)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var batch []batchTestRequest
+		if err := json.Unmarshal(body, &batch); err == nil {
+			if !batches {
+				w.Write([]byte(`{"id": 0, "jsonrpc": "2.0", "error": {"code": -32700, "message": "Parse error"}}`))
+				return
+			}
+
+			// the responses don't have to keep the order of the calls
+			res := "["
+			for i := len(batch) - 1; i >= 0; i-- {
+				res += answer(&batch[i])
+				if i > 0 {
+					res += ","
+				}
+			}
+			w.Write([]byte(res + "]"))
+			return
+		}
+
+		var req batchTestRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(answer(&req)))
+	}))
+}
+
+func createTestDaemonRpcBatchClient(t *testing.T, u string) *daemon.DaemonRpcClient {
+	client, err := createTestDaemonRpcClient(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client.(*daemon.DaemonRpcClient)
+}
+
+func TestBatch(t *testing.T) {
+	var requests atomic.Int32
+	server := getBatchTestServer(&requests, true)
+	defer server.Close()
+
+	b := createTestDaemonRpcBatchClient(t, server.URL).NewBatch()
+	b.MaxSize = 4
+
+	var calls []*daemon.BatchCall[daemon.GetBlockHeaderResult]
+	for h := uint64(995); h < 1005; h++ {
+		calls = append(calls, b.GetBlockHeaderByHeight(false, h))
+	}
+	assert.Equal(t, 10, b.Len())
+
+	_, err := calls[0].Result()
+	assert.ErrorIs(t, err, daemon.ErrBatchNotExecuted)
+
+	if err := b.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(3), requests.Load())
+
+	for i, v := range calls {
+		h := uint64(995 + i)
+		res, err := v.Result()
+		if h > 1000 {
+			assert.ErrorIs(t, err, daemon.ErrTooBigHeight)
+			continue
+		}
+		if assert.NoError(t, err) {
+			assert.Equal(t, fmt.Sprintf("hash%d", h), res.Result.BlockHeader.Hash)
+			assert.Equal(t, h, res.Result.BlockHeader.Height)
+		}
+	}
+}
+
+func TestBatchSequentialFallback(t *testing.T) {
+	var requests atomic.Int32
+	server := getBatchTestServer(&requests, false)
+	defer server.Close()
+
+	client := createTestDaemonRpcBatchClient(t, server.URL)
+
+	b := client.NewBatch()
+	c1 := b.GetBlockHeaderByHeight(false, 1)
+	c2 := b.GetBlockHeaderByHeight(false, 2000)
+	if err := b.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// the rejected batch and the two single calls
+	assert.Equal(t, int32(3), requests.Load())
+
+	res, err := c1.Result()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "hash1", res.Result.BlockHeader.Hash)
+	}
+	_, err = c2.Result()
+	assert.ErrorIs(t, err, daemon.ErrTooBigHeight)
+
+	// the client doesn't try batches anymore
+	b = client.NewBatch()
+	b.GetBlockHeaderByHeight(false, 3)
+	b.GetBlockHeaderByHeight(false, 4)
+	if err := b.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(5), requests.Load())
+}
+
+func TestBatchBlockDetails(t *testing.T) {
+	d := mock.New(mock.Config{Batch: true})
+	server := httptest.NewServer(d)
+	defer server.Close()
+
+	hashes, err := d.GenerateBlocks(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := createTestDaemonRpcBatchClient(t, server.URL).NewBatch()
+	byHeight := b.GetBlockByHeight(false, 1)
+	byHash := b.GetBlockByHash(false, hashes[1])
+	if err := b.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// BlockDetails is decoded from the json field, as by the DaemonRpcClient methods
+	res, err := byHeight.Result()
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(1), res.Result.BlockDetails.MinerTx.Vin[0].Gen.Height)
+	}
+	res, err = byHash.Result()
+	if assert.NoError(t, err) {
+		assert.Equal(t, hashes[0], res.Result.BlockDetails.PrevId)
+		assert.Equal(t, uint64(2), res.Result.BlockDetails.MinerTx.Vin[0].Gen.Height)
+	}
+}
+
+func TestBatchMissingResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": "1", "jsonrpc": "2.0", "result": {"count": 1000, "status": "OK", "untrusted": false}}]`))
+	}))
+	defer server.Close()
+
+	b := createTestDaemonRpcBatchClient(t, server.URL).NewBatch()
+	c1 := b.GetBlockCount()
+	c2 := b.GetBlockCount()
+	if err := b.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := c1.Result()
+	assert.ErrorIs(t, err, daemon.ErrBatchMissingResult)
+	res, err := c2.Result()
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(1000), res.Result.Count)
+	}
+}
+
+func TestBatchTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	b := createTestDaemonRpcBatchClient(t, server.URL).NewBatch()
+	c1 := b.GetInfo()
+	b.GetVersion()
+
+	err := b.Execute(context.Background())
+	assert.ErrorIs(t, err, daemon.ErrUnauthorized)
+	_, err = c1.Result()
+	assert.ErrorIs(t, err, daemon.ErrUnauthorized)
+}
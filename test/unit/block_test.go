@@ -0,0 +1,115 @@
+package test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/chekist32/go-monero/tx"
+	"github.com/chekist32/go-monero/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// block 2751506
+	blockTestBlob = "1010c58bab9b06b27bdecfc6cd0a46172d136c08831cf67660377ba992332363228b1b722781e7807e07f502cef8a70101ff92f8a7010180e0a596bb1103d7cbf826b665d7a532c316982dc8dbc24f285cbc18bbcc27c7164cd9b3277a85d034019f629d8b36bd16a2bfce3ea80c31dc4d8762c67165aec21845494e32b7582fe00211000000297a787a000000000000000000000000"
+	// block template with 5 txs
+	blockTestTemplateBlob        = "0e0ed286da8006ecdc1aab3033cf1716c52f13f9d8ae0051615a2453643de94643b550d543becd0000000002abc78b0101ffefc68b0101fcfcf0d4b422025014bb4a1eade6622fd781cb1063381cad396efa69719b41aa28b4fce8c7ad4b5f019ce1dc670456b24a5e03c2d9058a2df10fec779e2579753b1847b74ee644f16b023c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000051399a1bc46a846474f5b33db24eae173a26393b976054ee14f9feefe99925233802867097564c9db7a36af5bb5ed33ab46e63092bd8d32cef121608c3258edd55562812e21cc7e3ac73045745a72f7d74581d9a0849d6f30e8b2923171253e864f4e9ddea3acb5bc755f1c4a878130a70c26297540bc0b7a57affb6b35c1f03d8dbd54ece8457531f8cba15bb74516779c01193e212050423020e45aa2c15dcb"
+	blockTestTemplateHashingBlob = "0e0ed286da8006ecdc1aab3033cf1716c52f13f9d8ae0051615a2453643de94643b550d543becd00000000d130d22cf308b308498bbc16e2e955e7dbd691e6a8fab805f98ad82e6faa8bcc06"
+)
+
+func TestParseBlock(t *testing.T) {
+	b, err := tx.ParseBlockHex(blockTestBlob)
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(16), b.MajorVersion)
+	assert.Equal(t, uint64(16), b.MinorVersion)
+	assert.Equal(t, uint64(1667941829), b.Timestamp)
+	assert.Equal(t, "b27bdecfc6cd0a46172d136c08831cf67660377ba992332363228b1b722781e7", b.PrevId.String())
+	assert.Equal(t, uint32(4110909056), b.Nonce)
+	assert.Empty(t, b.TxHashes)
+
+	height, err := b.Height()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2751506), height)
+
+	minerTxHash, err := b.MinerTx.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "e49b854c5f339d7410a77f2a137281d8042a0ffc7ef9ab24cd670b67139b24cd", minerTxHash.String())
+
+	hash, err := b.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "43bd1f2b6556dcafa413d8372974af59e4e8f37dbf74dc6b2a9b7212d0577428", hash.String())
+}
+
+func TestBlockHashingBlob(t *testing.T) {
+	b, err := tx.ParseBlockHex(blockTestTemplateBlob)
+	assert.NoError(t, err)
+	assert.Len(t, b.TxHashes, 5)
+
+	blob, err := b.HashingBlob()
+	assert.NoError(t, err)
+	assert.Equal(t, blockTestTemplateHashingBlob, hex.EncodeToString(blob))
+}
+
+func TestParseBlockErrors(t *testing.T) {
+	blob := decodeHexHelper(t, blockTestBlob)
+
+	_, err := tx.ParseBlock(blob[:len(blob)-1])
+	assert.True(t, errors.Is(err, tx.ErrUnexpectedEOF))
+
+	_, err = tx.ParseBlock(append(blob, 0))
+	assert.True(t, errors.Is(err, tx.ErrTrailingData))
+
+	// a tx count that doesn't fit into the blob
+	_, err = tx.ParseBlock(append(blob[:len(blob)-1], 0x01))
+	var parseErr *tx.ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, len(blob)-1, parseErr.Offset)
+}
+
+func blockTestHashHelper(t *testing.T, data ...tx.Hash) tx.Hash {
+	var buf []byte
+	for _, h := range data {
+		buf = append(buf, h[:]...)
+	}
+
+	hash, err := utils.Keccak256Hash(buf)
+	assert.NoError(t, err)
+
+	var res tx.Hash
+	copy(res[:], hash)
+	return res
+}
+
+func TestTreeHash(t *testing.T) {
+	hashes := make([]tx.Hash, 5)
+	for i := range hashes {
+		hashes[i][0] = byte(i + 1)
+	}
+
+	_, err := tx.TreeHash(nil)
+	assert.Error(t, err)
+
+	res, err := tx.TreeHash(hashes[:1])
+	assert.NoError(t, err)
+	assert.Equal(t, hashes[0], res)
+
+	res, err = tx.TreeHash(hashes[:2])
+	assert.NoError(t, err)
+	assert.Equal(t, blockTestHashHelper(t, hashes[0], hashes[1]), res)
+
+	// h0, H(h1 || h2)
+	res, err = tx.TreeHash(hashes[:3])
+	assert.NoError(t, err)
+	assert.Equal(t, blockTestHashHelper(t, hashes[0], blockTestHashHelper(t, hashes[1], hashes[2])), res)
+
+	// h0, h1, h2, H(h3 || h4)
+	res, err = tx.TreeHash(hashes)
+	assert.NoError(t, err)
+	assert.Equal(t, blockTestHashHelper(t,
+		blockTestHashHelper(t, hashes[0], hashes[1]),
+		blockTestHashHelper(t, hashes[2], blockTestHashHelper(t, hashes[3], hashes[4])),
+	), res)
+}
@@ -0,0 +1,240 @@
+package test
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/stretchr/testify/assert"
+)
+
+const configTestHeightResponse = `{"hash": "", "height": 3200000, "status": "OK", "untrusted": false}`
+
+func getConfigTestServer(tls bool, headers http.Header) *httptest.Server {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range r.Header {
+			headers[k] = v
+		}
+		w.Write([]byte(configTestHeightResponse))
+	})
+	if tls {
+		return httptest.NewTLSServer(handler)
+	}
+	return httptest.NewServer(handler)
+}
+
+func createTestDaemonRpcClientWithConfig(t *testing.T, u string, config daemon.DaemonRpcClientConfig) (daemon.IDaemonRpcClient, error) {
+	u1, err := url.Parse(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return daemon.NewDaemonRpcClientWithConfig(daemon.NewRpcConnection(u1, "", ""), config)
+}
+
+// Formats the fingerprint as openssl does
+func configTestFingerprintHelper(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	parts := make([]string, len(sum))
+	for i, v := range sum {
+		parts[i] = fmt.Sprintf("%02X", v)
+	}
+	return strings.Join(parts, ":")
+}
+
+func TestDaemonRpcClientTls(t *testing.T) {
+	server := getConfigTestServer(true, http.Header{})
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	// the certificates are verified by default
+	_, err := daemon.NewDaemonRpcClient(daemon.NewRpcConnection(u, "", "")).GetCurrentHeight()
+	var certErr x509.UnknownAuthorityError
+	assert.ErrorAs(t, err, &certErr)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	client, err := createTestDaemonRpcClientWithConfig(t, server.URL, daemon.DaemonRpcClientConfig{RootCAs: pool})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.GetCurrentHeight()
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(3200000), res.Height)
+	}
+
+	client, err = createTestDaemonRpcClientWithConfig(t, server.URL, daemon.DaemonRpcClientConfig{
+		AllowedFingerprints: []string{strings.Repeat("00", 32), configTestFingerprintHelper(server.Certificate())},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetCurrentHeight()
+	assert.NoError(t, err)
+
+	client, err = createTestDaemonRpcClientWithConfig(t, server.URL, daemon.DaemonRpcClientConfig{AllowedFingerprints: []string{strings.Repeat("ab", 32)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetCurrentHeight()
+	assert.ErrorIs(t, err, daemon.ErrCertificateNotAllowed)
+
+	// the CA is still accepted along with the fingerprints
+	client, err = createTestDaemonRpcClientWithConfig(t, server.URL, daemon.DaemonRpcClientConfig{AllowedFingerprints: []string{strings.Repeat("ab", 32)}, RootCAs: pool})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetCurrentHeight()
+	assert.NoError(t, err)
+}
+
+func TestDaemonRpcClientHeadersAndTimeout(t *testing.T) {
+	headers := http.Header{}
+	server := getConfigTestServer(false, headers)
+	defer server.Close()
+
+	client, err := createTestDaemonRpcClientWithConfig(t, server.URL, daemon.DaemonRpcClientConfig{CustomHeaders: map[string]string{"X-Api-Key": "secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetCurrentHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", headers.Get("X-Api-Key"))
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(configTestHeightResponse))
+	}))
+	defer slow.Close()
+
+	client, err = createTestDaemonRpcClientWithConfig(t, slow.URL, daemon.DaemonRpcClientConfig{Timeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetCurrentHeight()
+	var netErr net.Error
+	if assert.ErrorAs(t, err, &netErr) {
+		assert.True(t, netErr.Timeout())
+	}
+}
+
+// A SOCKS5 proxy without authentication that connects every request to the target and reports the requested hosts
+func getSocks5TestProxy(t *testing.T, target string, hosts chan<- string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				// greeting: version, methods
+				buf := make([]byte, 2)
+				if _, err := io.ReadFull(conn, buf); err != nil {
+					return
+				}
+				if _, err := io.ReadFull(conn, make([]byte, buf[1])); err != nil {
+					return
+				}
+				conn.Write([]byte{5, 0})
+
+				// request: version, CONNECT, reserved, domain name address type
+				req := make([]byte, 5)
+				if _, err := io.ReadFull(conn, req); err != nil || req[3] != 3 {
+					return
+				}
+				host := make([]byte, req[4]+2)
+				if _, err := io.ReadFull(conn, host); err != nil {
+					return
+				}
+				hosts <- fmt.Sprintf("%s:%d", host[:req[4]], binary.BigEndian.Uint16(host[req[4]:]))
+
+				upstream, err := net.Dial("tcp", target)
+				if err != nil {
+					return
+				}
+				defer upstream.Close()
+				conn.Write([]byte{5, 0, 0, 1, 127, 0, 0, 1, 0, 0})
+
+				go io.Copy(upstream, conn)
+				io.Copy(conn, upstream)
+			}()
+		}
+	}()
+
+	return listener
+}
+
+func TestDaemonRpcClientSocks5Proxy(t *testing.T) {
+	server := getConfigTestServer(false, http.Header{})
+	defer server.Close()
+
+	hosts := make(chan string, 1)
+	proxy := getSocks5TestProxy(t, server.Listener.Addr().String(), hosts)
+	defer proxy.Close()
+
+	client, err := createTestDaemonRpcClientWithConfig(t, "http://xmrnode3mxmhr5dvr3fxsztidecktjhffbvtt3rlvoqvgllm5ptrn5xid.onion:18089", daemon.DaemonRpcClientConfig{
+		Proxy: &url.URL{Scheme: "socks5", Host: proxy.Addr().String()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.GetCurrentHeight()
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(3200000), res.Height)
+	}
+	// the onion address is resolved by the proxy
+	assert.Equal(t, "xmrnode3mxmhr5dvr3fxsztidecktjhffbvtt3rlvoqvgllm5ptrn5xid.onion:18089", <-hosts)
+}
+
+type configTestRoundTripper struct {
+	calls int
+}
+
+func (rt *configTestRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.calls++
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(configTestHeightResponse)), Header: http.Header{}, Request: req}, nil
+}
+
+func TestDaemonRpcClientConfig(t *testing.T) {
+	rt := &configTestRoundTripper{}
+	client, err := createTestDaemonRpcClientWithConfig(t, "http://127.0.0.1:18081", daemon.DaemonRpcClientConfig{Transport: rt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetCurrentHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rt.calls)
+
+	_, err = createTestDaemonRpcClientWithConfig(t, "http://127.0.0.1:18081", daemon.DaemonRpcClientConfig{Transport: rt, InsecureSkipVerify: true})
+	assert.ErrorIs(t, err, daemon.ErrTransportConflict)
+
+	_, err = createTestDaemonRpcClientWithConfig(t, "http://127.0.0.1:18081", daemon.DaemonRpcClientConfig{AllowedFingerprints: []string{"abcd"}})
+	assert.ErrorIs(t, err, daemon.ErrInvalidFingerprint)
+
+	_, err = createTestDaemonRpcClientWithConfig(t, "http://127.0.0.1:18081", daemon.DaemonRpcClientConfig{Proxy: &url.URL{Scheme: "socks4", Host: "127.0.0.1:9050"}})
+	assert.ErrorIs(t, err, daemon.ErrUnsupportedProxy)
+
+	_, err = daemon.NewDaemonRpcPool([]*daemon.RpcConnection{daemon.NewRpcConnection(&url.URL{Scheme: "http", Host: "127.0.0.1:18081"}, "", "")}, daemon.PoolConfig{
+		Client: daemon.DaemonRpcClientConfig{Proxy: &url.URL{Scheme: "ftp"}},
+	})
+	assert.ErrorIs(t, err, daemon.ErrUnsupportedProxy)
+}
@@ -0,0 +1,197 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/scanner"
+	"github.com/stretchr/testify/assert"
+)
+
+// The txs known to the daemon and the hashes of the main chain blocks
+type confirmationTestClient struct {
+	daemon.IDaemonRpcClient
+	txs    map[string]daemon.MoneroTx1
+	top    uint64
+	blocks map[uint64]string
+	// the heights the header lookup fails for
+	failHeights map[uint64]bool
+}
+
+func (c *confirmationTestClient) GetTransactionsCtx(ctx context.Context, txHashes []string, decodeAsJson bool, prune bool, split bool) (*daemon.GetTransactionsResponse, error) {
+	res := &daemon.GetTransactionsResponse{}
+	for _, v := range txHashes {
+		if tx, ok := c.txs[v]; ok {
+			res.Txs = append(res.Txs, tx)
+		} else {
+			res.MissedTx = append(res.MissedTx, v)
+		}
+	}
+	return res, nil
+}
+
+func (c *confirmationTestClient) GetLastBlockHeaderCtx(ctx context.Context, fillPowHash bool) (*daemon.JsonRpcGenericResponse[daemon.GetBlockHeaderResult], error) {
+	return c.GetBlockHeaderByHeightCtx(ctx, fillPowHash, c.top)
+}
+
+func (c *confirmationTestClient) GetBlockHeaderByHeightCtx(ctx context.Context, fillPowHash bool, height uint64) (*daemon.JsonRpcGenericResponse[daemon.GetBlockHeaderResult], error) {
+	if c.failHeights[height] {
+		return nil, errors.New("header lookup failed")
+	}
+
+	res := &daemon.JsonRpcGenericResponse[daemon.GetBlockHeaderResult]{}
+	res.Result.BlockHeader.Height = height
+	res.Result.BlockHeader.Hash = c.blocks[height]
+	if res.Result.BlockHeader.Hash == "" {
+		res.Result.BlockHeader.Hash = fmt.Sprintf("main%d", height)
+	}
+	return res, nil
+}
+
+func TestConfirmationTracker(t *testing.T) {
+	client := &confirmationTestClient{
+		txs: map[string]daemon.MoneroTx1{
+			"aa": {TxHash: "aa", InPool: true, DoubleSpendSeen: true},
+			"bb": {TxHash: "bb", InPool: true},
+		},
+		top:    100,
+		blocks: make(map[uint64]string),
+	}
+
+	var events []string
+	eventHelper := func(name string) func(tx scanner.TrackedTx) {
+		return func(tx scanner.TrackedTx) {
+			events = append(events, fmt.Sprintf("%s %s %d", name, tx.TxHash, tx.Height))
+		}
+	}
+
+	store := scanner.NewMemoryConfirmationStore()
+	tracker, err := scanner.NewConfirmationTracker(client, store, scanner.ConfirmationCallbacks{
+		OnConfirmed:       eventHelper("confirmed"),
+		OnReorgedOut:      eventHelper("reorged"),
+		OnDoubleSpendSeen: eventHelper("double_spend"),
+		OnDropped:         eventHelper("dropped"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for hash, confirmations := range map[string]uint64{"aa": 3, "bb": 1, "cc": 1} {
+		if err := tracker.Track(hash, confirmations); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	update := func(expected ...string) {
+		events = nil
+		if err := tracker.Update(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expected, events)
+	}
+
+	update("double_spend aa 0")
+	tx, _ := tracker.Get("cc")
+	assert.Equal(t, scanner.TxUnseen, tx.Status)
+
+	client.txs["aa"] = daemon.MoneroTx1{TxHash: "aa", BlockHeight: 100}
+	delete(client.txs, "bb")
+	client.top = 101
+	update("dropped bb 0")
+	tx, _ = tracker.Get("aa")
+	assert.Equal(t, scanner.TrackedTx{TxHash: "aa", RequiredConfirmations: 3, Status: scanner.TxMined, Height: 100, BlockHash: "main100", Confirmations: 2, DoubleSpendSeen: true}, tx)
+
+	client.top = 102
+	update("confirmed aa 100")
+	update()
+
+	// the block 100 is replaced and the tx goes back to the pool
+	client.blocks[100] = "alt100"
+	client.txs["aa"] = daemon.MoneroTx1{TxHash: "aa", InPool: true}
+	update("reorged aa 0")
+	tx, _ = tracker.Get("aa")
+	assert.Equal(t, scanner.TxInPool, tx.Status)
+
+	client.txs["aa"] = daemon.MoneroTx1{TxHash: "aa", BlockHeight: 101}
+	client.top = 103
+	update("confirmed aa 101")
+
+	// the same block height but another block
+	client.blocks[101] = "alt101"
+	update("reorged aa 101", "confirmed aa 101")
+
+	// the state is restored from the store
+	restored, err := scanner.NewConfirmationTracker(client, store, scanner.ConfirmationCallbacks{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, ok := restored.Get("aa")
+	assert.True(t, ok)
+	assert.Equal(t, scanner.TrackedTx{TxHash: "aa", RequiredConfirmations: 3, Status: scanner.TxConfirmed, Height: 101, BlockHash: "alt101", Confirmations: 3, DoubleSpendSeen: true}, tx)
+	assert.Len(t, restored.Txs(), 3)
+
+	assert.NoError(t, restored.Untrack("aa"))
+	assert.ErrorIs(t, restored.Untrack("aa"), scanner.ErrTxNotTracked)
+	txs, _ := store.Load()
+	assert.Len(t, txs, 2)
+}
+
+// Fails to save the given tx
+type confirmationTestFailingStore struct {
+	*scanner.MemoryConfirmationStore
+	fail string
+}
+
+func (s *confirmationTestFailingStore) Save(tx scanner.TrackedTx) error {
+	if tx.TxHash == s.fail {
+		return errors.New("save failed")
+	}
+	return s.MemoryConfirmationStore.Save(tx)
+}
+
+func TestConfirmationTrackerUpdateError(t *testing.T) {
+	client := &confirmationTestClient{
+		txs: map[string]daemon.MoneroTx1{
+			"aa": {TxHash: "aa", BlockHeight: 100},
+			"bb": {TxHash: "bb", BlockHeight: 101},
+		},
+		top:         101,
+		blocks:      make(map[uint64]string),
+		failHeights: map[uint64]bool{101: true},
+	}
+
+	var confirmed []string
+	store := &confirmationTestFailingStore{MemoryConfirmationStore: scanner.NewMemoryConfirmationStore()}
+	tracker, err := scanner.NewConfirmationTracker(client, store, scanner.ConfirmationCallbacks{
+		OnConfirmed: func(tx scanner.TrackedTx) { confirmed = append(confirmed, tx.TxHash) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"aa", "bb"} {
+		if err := tracker.Track(v, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the header of the bb block can't be fetched, nothing is saved
+	assert.Error(t, tracker.Update(context.Background()))
+	assert.Empty(t, confirmed)
+	tx, _ := tracker.Get("aa")
+	assert.Equal(t, scanner.TxUnseen, tx.Status)
+
+	// bb can't be saved, the callbacks of the saved txs are still called
+	client.failHeights = nil
+	store.fail = "bb"
+	assert.Error(t, tracker.Update(context.Background()))
+	tx, _ = tracker.Get("bb")
+	assert.Equal(t, scanner.TxUnseen, tx.Status)
+
+	store.fail = ""
+	if err := tracker.Update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	assert.ElementsMatch(t, []string{"aa", "bb"}, confirmed)
+}
@@ -0,0 +1,217 @@
+package test
+
+import (
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/daemon/epee"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var defaultBinRpcFooter = daemon.BinRpcFooter{Status: "OK"}
+
+func daemonRpcBinTestServerCheck[B daemon.BinRpcRequestBody](r *http.Request, expected *daemon.MoneroRpcRequest[B]) bool {
+	if r.URL.Path != expected.Endpoint ||
+		r.Method != http.MethodPost ||
+		r.Header.Get("Content-Type") != "application/octet-stream" {
+		return false
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return false
+	}
+
+	var actual B
+	if err := epee.Unmarshal(data, &actual); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(&actual, expected.Body)
+}
+func getDaemonRpcBinTestServer[B daemon.BinRpcRequestBody](t *testing.T, req *daemon.MoneroRpcRequest[B], res string) *httptest.Server {
+	data, err := hex.DecodeString(res)
+	assert.NoError(t, err)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if daemonRpcBinTestServerCheck(r, req) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(data)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+}
+
+func TestGetBlocksBin(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.GetBlocksBinParams]{
+		Endpoint: "/get_blocks.bin",
+		Body: &daemon.GetBlocksBinParams{
+			BlockIds:    []string{epeeTestHash1, epeeTestHash2},
+			StartHeight: 2751506,
+			Prune:       false,
+			NoMinerTx:   true,
+		},
+	}
+	// block 2751506 with two fake tx blobs (0102 and 030405)
+	exres := "011101010101020101200763726564697473050000000000000000067374617475730a084f4b08746f705f686173680a0006626c6f636b738c040c05626c6f636b0a59021010c58bab9b06b27bdecfc6cd0a46172d136c08831cf67660377ba992332363228b1b722781e7807e07f502cef8a70101ff92f8a7010180e0a596bb1103d7cbf826b665d7a532c316982dc8dbc24f285cbc18bbcc27c7164cd9b3277a85d034019f629d8b36bd16a2bfce3ea80c31dc4d8762c67165aec21845494e32b7582fe00211000000297a787a0000000000000000000000000c626c6f636b5f77656967687405c400000000000000037478738a080801020c0304050e63757272656e745f68656967687405c0c62d00000000000e6f75747075745f696e64696365738c040407696e64696365738c080407696e64696365738508640000000000000065000000000000000407696e6469636573850466000000000000000c73746172745f6865696768740512fc29000000000009756e747275737465640b00"
+
+	server := getDaemonRpcBinTestServer(t, exreq, exres)
+	defer server.Close()
+
+	client, err := createTestDaemonRpcClient(server.URL)
+	assert.NoError(t, err)
+
+	res, err := client.GetBlocksBin(exreq.Body.BlockIds, exreq.Body.StartHeight, exreq.Body.Prune, exreq.Body.NoMinerTx)
+	assert.NoError(t, err)
+
+	expected := &daemon.GetBlocksBinResponse{
+		Blocks: []daemon.BlockCompleteEntry{{
+			Block:       decodeHexHelper(t, blockTestBlob),
+			BlockWeight: 196,
+			Txs:         []daemon.TxBlobEntry{{Blob: []byte{0x01, 0x02}}, {Blob: []byte{0x03, 0x04, 0x05}}},
+		}},
+		StartHeight:   2751506,
+		CurrentHeight: 3000000,
+		OutputIndices: []daemon.BlockOutputIndices{{Indices: []daemon.TxOutputIndices{{Indices: []uint64{100, 101}}, {Indices: []uint64{102}}}}},
+		BinRpcFooter:  defaultBinRpcFooter,
+	}
+	assert.Equal(t, expected, res)
+
+	// the entries are encoded back the way monerod does it
+	data, err := epee.Marshal(res)
+	assert.NoError(t, err)
+	var decoded daemon.GetBlocksBinResponse
+	assert.NoError(t, epee.Unmarshal(data, &decoded))
+	assert.Equal(t, expected, &decoded)
+}
+
+func TestGetBlocksByHeightBin(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.GetBlocksByHeightBinParams]{
+		Endpoint: "/get_blocks_by_height.bin",
+		Body:     &daemon.GetBlocksByHeightBinParams{Heights: []uint64{2751506}},
+	}
+	// a pruned entry of block 2751506 with a fake tx
+	exres := "011101010101020101140763726564697473050000000000000000067374617475730a084f4b08746f705f686173680a0009756e747275737465640b0006626c6f636b738c0410067072756e65640b0105626c6f636b0a59021010c58bab9b06b27bdecfc6cd0a46172d136c08831cf67660377ba992332363228b1b722781e7807e07f502cef8a70101ff92f8a7010180e0a596bb1103d7cbf826b665d7a532c316982dc8dbc24f285cbc18bbcc27c7164cd9b3277a85d034019f629d8b36bd16a2bfce3ea80c31dc4d8762c67165aec21845494e32b7582fe00211000000297a787a0000000000000000000000000c626c6f636b5f77656967687405c400000000000000037478738c040804626c6f620a0801020d7072756e61626c655f686173680a80000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+	server := getDaemonRpcBinTestServer(t, exreq, exres)
+	defer server.Close()
+
+	client, err := createTestDaemonRpcClient(server.URL)
+	assert.NoError(t, err)
+
+	res, err := client.GetBlocksByHeightBin(exreq.Body.Heights)
+	assert.NoError(t, err)
+
+	expected := &daemon.GetBlocksByHeightBinResponse{
+		Blocks: []daemon.BlockCompleteEntry{{
+			Pruned:      true,
+			Block:       decodeHexHelper(t, blockTestBlob),
+			BlockWeight: 196,
+			Txs:         []daemon.TxBlobEntry{{Blob: []byte{0x01, 0x02}, PrunableHash: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"}},
+		}},
+		BinRpcFooter: defaultBinRpcFooter,
+	}
+	assert.Equal(t, expected, res)
+
+	data, err := epee.Marshal(res)
+	assert.NoError(t, err)
+	var decoded daemon.GetBlocksByHeightBinResponse
+	assert.NoError(t, epee.Unmarshal(data, &decoded))
+	assert.Equal(t, expected, &decoded)
+}
+
+func TestGetHashesBin(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.GetHashesBinParams]{
+		Endpoint: "/get_hashes.bin",
+		Body:     &daemon.GetHashesBinParams{BlockIds: []string{epeeTestHash1}, StartHeight: 10},
+	}
+	exres := "0111010101010201011c0763726564697473050000000000000000067374617475730a084f4b08746f705f686173680a0009756e747275737465640b000e63757272656e745f68656967687405c0c62d00000000000b6d5f626c6f636b5f6964730a0101111111111111111111111111111111111111111111111111111111111111111122222222222222222222222222222222222222222222222222222222222222220c73746172745f686569676874050a00000000000000"
+
+	server := getDaemonRpcBinTestServer(t, exreq, exres)
+	defer server.Close()
+
+	client, err := createTestDaemonRpcClient(server.URL)
+	assert.NoError(t, err)
+
+	res, err := client.GetHashesBin(exreq.Body.BlockIds, exreq.Body.StartHeight)
+	assert.NoError(t, err)
+	assert.Equal(t, &daemon.GetHashesBinResponse{
+		MBlockIds:     []string{epeeTestHash1, epeeTestHash2},
+		StartHeight:   10,
+		CurrentHeight: 3000000,
+		BinRpcFooter:  defaultBinRpcFooter,
+	}, res)
+}
+
+func TestGetOIndexesBin(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.GetOIndexesBinParams]{
+		Endpoint: "/get_o_indexes.bin",
+		Body:     &daemon.GetOIndexesBinParams{TxId: epeeTestHash2},
+	}
+	exres := "011101010101020101140763726564697473050000000000000000067374617475730a084f4b08746f705f686173680a0009756e747275737465640b00096f5f696e6465786573850c010000000000000002000000000000002c01000000000000"
+
+	server := getDaemonRpcBinTestServer(t, exreq, exres)
+	defer server.Close()
+
+	client, err := createTestDaemonRpcClient(server.URL)
+	assert.NoError(t, err)
+
+	res, err := client.GetOIndexesBin(exreq.Body.TxId)
+	assert.NoError(t, err)
+	assert.Equal(t, &daemon.GetOIndexesBinResponse{OIndexes: []uint64{1, 2, 300}, BinRpcFooter: defaultBinRpcFooter}, res)
+}
+
+func TestGetOutsBin(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.GetOutsBinParams]{
+		Endpoint: "/get_outs.bin",
+		Body:     &daemon.GetOutsBinParams{Outputs: []daemon.GetOutputsOut{{Amount: 0, Index: 42}}, GetTxId: true},
+	}
+	exres := "011101010101020101140763726564697473050000000000000000067374617475730a084f4b08746f705f686173680a0009756e747275737465640b00046f7574738c0414066865696768740512fc290000000000036b65790a801111111111111111111111111111111111111111111111111111111111111111046d61736b0a80222222222222222222222222222222222222222222222222222222222222222204747869640a80000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f08756e6c6f636b65640b01"
+
+	server := getDaemonRpcBinTestServer(t, exreq, exres)
+	defer server.Close()
+
+	client, err := createTestDaemonRpcClient(server.URL)
+	assert.NoError(t, err)
+
+	res, err := client.GetOutsBin(exreq.Body.Outputs, exreq.Body.GetTxId)
+	assert.NoError(t, err)
+	assert.Equal(t, &daemon.GetOutsBinResponse{
+		Outs: []daemon.OutKeyBin{{
+			Key:      epeeTestHash1,
+			Mask:     epeeTestHash2,
+			Unlocked: true,
+			Height:   2751506,
+			TxId:     "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		}},
+		BinRpcFooter: defaultBinRpcFooter,
+	}, res)
+}
+
+func TestGetOIndexesBinStatus(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.GetOIndexesBinParams]{
+		Endpoint: "/get_o_indexes.bin",
+		Body:     &daemon.GetOIndexesBinParams{TxId: epeeTestHash1},
+	}
+
+	data, err := epee.Marshal(&daemon.GetOIndexesBinResponse{BinRpcFooter: daemon.BinRpcFooter{Status: "Failed"}})
+	assert.NoError(t, err)
+
+	server := getDaemonRpcBinTestServer(t, exreq, hex.EncodeToString(data))
+	defer server.Close()
+
+	client, err := createTestDaemonRpcClient(server.URL)
+	assert.NoError(t, err)
+
+	res, err := client.GetOIndexesBin(exreq.Body.TxId)
+	assert.Nil(t, res)
+	assert.EqualError(t, err, "/get_o_indexes.bin: Failed")
+}
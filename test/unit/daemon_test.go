@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/chekist32/go-monero/daemon"
 
@@ -1398,3 +1400,1563 @@ func TestGetInfo(t *testing.T) {
 	}
 	assert.Equal(t, expected, actual)
 }
+
+// get_connections
+func TestGetConnections(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "get_connections", Params: daemon.EmptyMoneroRpcParams{}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"connections": [{
+				"address": "88.198.163.90:18080",
+				"address_type": 1,
+				"avg_download": 0,
+				"avg_upload": 2,
+				"connection_id": "3a3e2ed9ebd44b9c8b4f83e3d0a5be50",
+				"current_download": 0,
+				"current_upload": 1,
+				"height": 3195296,
+				"host": "88.198.163.90",
+				"incoming": false,
+				"ip": "88.198.163.90",
+				"live_time": 1047,
+				"local_ip": false,
+				"localhost": false,
+				"peer_id": "fd3c6d2b59d3bd4d",
+				"port": "18080",
+				"pruning_seed": 0,
+				"recv_count": 1104718,
+				"recv_idle_time": 5,
+				"rpc_credits_per_hash": 0,
+				"rpc_port": 18089,
+				"send_count": 2423414,
+				"send_idle_time": 5,
+				"state": "normal",
+				"support_flags": 1
+			}],
+			"status": "OK",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.GetConnectionsResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.GetConnectionsResult{
+			Connections: []daemon.Connection{{
+				Address:       "88.198.163.90:18080",
+				AddressType:   1,
+				AvgUpload:     2,
+				ConnectionId:  "3a3e2ed9ebd44b9c8b4f83e3d0a5be50",
+				CurrentUpload: 1,
+				Height:        3195296,
+				Host:          "88.198.163.90",
+				Ip:            "88.198.163.90",
+				LiveTime:      1047,
+				PeerId:        "fd3c6d2b59d3bd4d",
+				Port:          "18080",
+				RecvCount:     1104718,
+				RecvIdleTime:  5,
+				RpcPort:       18089,
+				SendCount:     2423414,
+				SendIdleTime:  5,
+				State:         "normal",
+				SupportFlags:  1,
+			}},
+			JsonRpcFooter: defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.GetConnections()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_bans
+func TestGetBans(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "get_bans", Params: daemon.EmptyMoneroRpcParams{}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"bans": [{
+				"host": "102.168.1.51",
+				"ip": 855746662,
+				"seconds": 22
+			}, {
+				"host": "192.168.1.50",
+				"ip": 838969536,
+				"seconds": 28
+			}],
+			"status": "OK",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.GetBansResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.GetBansResult{
+			Bans: []daemon.Ban{
+				{Host: "102.168.1.51", Ip: 855746662, Seconds: 22},
+				{Host: "192.168.1.50", Ip: 838969536, Seconds: 28},
+			},
+			JsonRpcFooter: defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.GetBans()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// set_bans
+func TestSetBans(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.SetBansParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "set_bans", Params: daemon.SetBansParams{Bans: []daemon.SetBan{{Host: "192.168.1.51", Ban: true, Seconds: 30}, {Ip: 838969536, Ban: false}}}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.SetBansParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"status": "OK",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.SetBansResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result:        daemon.SetBansResult{JsonRpcFooter: defaultMoneroRpcFooter},
+		Error:         daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.SetBans(exreq.Body.Params.Bans)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// flush_txpool
+func TestFlushTxpool(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.FlushTxpoolParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "flush_txpool", Params: daemon.FlushTxpoolParams{TxIds: []string{"dc16fa8eaffe1484ca9014ea050e13131d3acf23b419f33bb4cc0b32b6c49308"}}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.FlushTxpoolParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"status": "OK"
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.FlushTxpoolResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result:        daemon.FlushTxpoolResult{Status: "OK"},
+		Error:         daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.FlushTxpool(exreq.Body.Params.TxIds)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_output_histogram
+func TestGetOutputHistogram(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.GetOutputHistogramParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "get_output_histogram", Params: daemon.GetOutputHistogramParams{Amounts: []uint64{20000000000}, MinCount: 1, MaxCount: 100, Unlocked: true, RecentCutoff: 1700000000}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.GetOutputHistogramParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"credits": 0,
+			"histogram": [{
+				"amount": 20000000000,
+				"recent_instances": 0,
+				"total_instances": 381490,
+				"unlocked_instances": 0
+			}],
+			"status": "OK",
+			"top_hash": "",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.GetOutputHistogramResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.GetOutputHistogramResult{
+			Histogram:     []daemon.HistogramEntry{{Amount: 20000000000, TotalInstances: 381490}},
+			JsonRpcFooter: defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.GetOutputHistogram(exreq.Body.Params.Amounts, exreq.Body.Params.MinCount, exreq.Body.Params.MaxCount, exreq.Body.Params.Unlocked, exreq.Body.Params.RecentCutoff)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_coinbase_tx_sum
+func TestGetCoinbaseTxSum(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.GetCoinbaseTxSumParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "get_coinbase_tx_sum", Params: daemon.GetCoinbaseTxSumParams{Height: 1563078, Count: 2}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.GetCoinbaseTxSumParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"credits": 0,
+			"emission_amount": 9387565438925,
+			"emission_amount_top64": 0,
+			"fee_amount": 126318410,
+			"fee_amount_top64": 0,
+			"status": "OK",
+			"top_hash": "",
+			"untrusted": false,
+			"wide_emission_amount": "0x889bd4f48cd",
+			"wide_fee_amount": "0x7877e4a"
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.GetCoinbaseTxSumResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.GetCoinbaseTxSumResult{
+			EmissionAmount:     9387565438925,
+			FeeAmount:          126318410,
+			WideEmissionAmount: "0x889bd4f48cd",
+			WideFeeAmount:      "0x7877e4a",
+			JsonRpcFooter:      defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.GetCoinbaseTxSum(exreq.Body.Params.Height, exreq.Body.Params.Count)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_alternate_chains
+func TestGetAlternateChains(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "get_alternate_chains", Params: daemon.EmptyMoneroRpcParams{}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"chains": [{
+				"block_hash": "697cf03c89a9b118f7bdf11b1b3a6a028d7b3617d2d0ed91322c5709acf75625",
+				"block_hashes": ["697cf03c89a9b118f7bdf11b1b3a6a028d7b3617d2d0ed91322c5709acf75625"],
+				"difficulty": 14114729638300280,
+				"difficulty_top64": 0,
+				"height": 1562062,
+				"length": 2,
+				"main_chain_parent_block": "b7c83b7b8a01a7d6e8fc4a2f9d6e4b1ed0b5fb38b1e8e7a4dbf3ad4e7d0b2c45",
+				"wide_difficulty": "0x3225d4a8c0b978"
+			}],
+			"status": "OK",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.GetAlternateChainsResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.GetAlternateChainsResult{
+			Chains: []daemon.AlternateChain{{
+				BlockHash:            "697cf03c89a9b118f7bdf11b1b3a6a028d7b3617d2d0ed91322c5709acf75625",
+				BlockHashes:          []string{"697cf03c89a9b118f7bdf11b1b3a6a028d7b3617d2d0ed91322c5709acf75625"},
+				Difficulty:           14114729638300280,
+				Height:               1562062,
+				Length:               2,
+				MainChainParentBlock: "b7c83b7b8a01a7d6e8fc4a2f9d6e4b1ed0b5fb38b1e8e7a4dbf3ad4e7d0b2c45",
+				WideDifficulty:       "0x3225d4a8c0b978",
+			}},
+			JsonRpcFooter: defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.GetAlternateChains()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// relay_tx
+func TestRelayTx(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.RelayTxParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "relay_tx", Params: daemon.RelayTxParams{TxIds: []string{"9fd75c429cbe52da9a52f2ffc5fbd107fe7fd2099c0d8de274dc8a67e0c98613"}}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.RelayTxParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"status": "OK"
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.RelayTxResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result:        daemon.RelayTxResult{Status: "OK"},
+		Error:         daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.RelayTx(exreq.Body.Params.TxIds)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// sync_info
+func TestSyncInfo(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "sync_info", Params: daemon.EmptyMoneroRpcParams{}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"credits": 0,
+			"height": 3195305,
+			"next_needed_pruning_seed": 0,
+			"overview": "[]",
+			"peers": [{
+				"info": {
+					"address": "142.93.128.65:44986",
+					"connection_id": "a5803c4c2dac49e7b201dccdef54c862",
+					"height": 3195305,
+					"incoming": true,
+					"state": "normal"
+				}
+			}],
+			"spans": [{
+				"connection_id": "a5803c4c2dac49e7b201dccdef54c862",
+				"nblocks": 20,
+				"rate": 1500,
+				"remote_address": "142.93.128.65:44986",
+				"size": 40000,
+				"speed": 100,
+				"start_block_height": 3195286
+			}],
+			"status": "OK",
+			"target_height": 0,
+			"top_hash": "",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.SyncInfoResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.SyncInfoResult{
+			Height:   3195305,
+			Overview: "[]",
+			Peers: []daemon.SyncInfoPeer{{Info: daemon.Connection{
+				Address:      "142.93.128.65:44986",
+				ConnectionId: "a5803c4c2dac49e7b201dccdef54c862",
+				Height:       3195305,
+				Incoming:     true,
+				State:        "normal",
+			}}},
+			Spans: []daemon.SyncInfoSpan{{
+				ConnectionId:     "a5803c4c2dac49e7b201dccdef54c862",
+				NBlocks:          20,
+				Rate:             1500,
+				RemoteAddress:    "142.93.128.65:44986",
+				Size:             40000,
+				Speed:            100,
+				StartBlockHeight: 3195286,
+			}},
+			JsonRpcFooter: defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.SyncInfo()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_txpool_backlog
+func TestGetTxpoolBacklog(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "get_txpool_backlog", Params: daemon.EmptyMoneroRpcParams{}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"backlog": "\u0001\u0002\u0000\u0000\u0000\u0000\u0000\u0000\u0010\u0000\u0000\u0000\u0000\u0000\u0000\u0000\u0005\u0000\u0000\u0000\u0000\u0000\u0000\u0000",
+			"status": "OK",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.GetTxpoolBacklogResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.GetTxpoolBacklogResult{
+			Backlog:       "\x01\x02\x00\x00\x00\x00\x00\x00\x10\x00\x00\x00\x00\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00",
+			JsonRpcFooter: defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.GetTxpoolBacklog()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_output_distribution
+func TestGetOutputDistribution(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.GetOutputDistributionParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "get_output_distribution", Params: daemon.GetOutputDistributionParams{Amounts: []uint64{0}, Cumulative: true, FromHeight: 3195300, ToHeight: 3195302}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.GetOutputDistributionParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"credits": 0,
+			"distributions": [{
+				"amount": 0,
+				"base": 0,
+				"binary": false,
+				"compress": false,
+				"distribution": [93296839, 93296891, 93296937],
+				"start_height": 3195300
+			}],
+			"status": "OK",
+			"top_hash": "",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.GetOutputDistributionResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.GetOutputDistributionResult{
+			Distributions: []daemon.OutputDistribution{{
+				Distribution: []uint64{93296839, 93296891, 93296937},
+				StartHeight:  3195300,
+			}},
+			JsonRpcFooter: defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.GetOutputDistribution(exreq.Body.Params.Amounts, exreq.Body.Params.Cumulative, exreq.Body.Params.FromHeight, exreq.Body.Params.ToHeight)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_miner_data
+func TestGetMinerData(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "get_miner_data", Params: daemon.EmptyMoneroRpcParams{}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"already_generated_coins": 18186022843595960691,
+			"difficulty": "0x48afae42de",
+			"height": 2731375,
+			"major_version": 16,
+			"median_weight": 300000,
+			"prev_id": "78d50c5894d187c4946d54410990ca59a75017628174a9e8c7055fa4ca5c7c6d",
+			"seed_hash": "a6b869d50eca3a43ec26fe4c369859cf36ae37ce6ecb76457d31ffeb8a6ca8a6",
+			"status": "OK",
+			"tx_backlog": [{
+				"fee": 30700000,
+				"id": "9868490d6bb9207fdd9cf17ca1f6c791b92ca97de0365855ea5c089f67c22208",
+				"weight": 1535
+			}],
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.GetMinerDataResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.GetMinerDataResult{
+			AlreadyGeneratedCoins: 18186022843595960691,
+			Difficulty:            "0x48afae42de",
+			Height:                2731375,
+			MajorVersion:          16,
+			MedianWeight:          300000,
+			PrevId:                "78d50c5894d187c4946d54410990ca59a75017628174a9e8c7055fa4ca5c7c6d",
+			SeedHash:              "a6b869d50eca3a43ec26fe4c369859cf36ae37ce6ecb76457d31ffeb8a6ca8a6",
+			TxBacklog: []daemon.MinerDataTxBacklogEntry{{
+				Id:     "9868490d6bb9207fdd9cf17ca1f6c791b92ca97de0365855ea5c089f67c22208",
+				Weight: 1535,
+				Fee:    30700000,
+			}},
+			JsonRpcFooter: defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.GetMinerData()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// calc_pow
+func TestCalcPow(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.CalcPowParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "calc_pow", Params: daemon.CalcPowParams{MajorVersion: 14, Height: 2286447, BlockBlob: "0e0ed286da8006ecdc1aab3033cf1716c52f13f9d8ae0051615a2453643de94643b550d543becd00000000d130d22cf308b308498bbc16e2e955e7dbd691e6a8fab805f98ad82e6faa8bcc06", SeedHash: "d432f499205150873b2572b5f033c9c6e4b7c6f3394bd2dd93822cd7085e7307"}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.CalcPowParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": "d0402d6834e26fb94a9ce38c6424d27d2069896a9b8b1ce685d79936bca6e0a8"
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.CalcPowResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result:        "d0402d6834e26fb94a9ce38c6424d27d2069896a9b8b1ce685d79936bca6e0a8",
+		Error:         daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.CalcPow(exreq.Body.Params.MajorVersion, exreq.Body.Params.Height, exreq.Body.Params.BlockBlob, exreq.Body.Params.SeedHash)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// add_aux_pow
+func TestAddAuxPow(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.AddAuxPowParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "add_aux_pow", Params: daemon.AddAuxPowParams{BlocktemplateBlob: "1010f4bae0b4069d648e741d85ca0e7acb4501f051b27e9b107d3cd7a3f03aa7f776089117c81a00000000", AuxPow: []daemon.AuxPow{{Id: "3200b4ea97c3b2081cd4190b58e49572b2319fed00d030ad51809dff06b5d8c8", Hash: "7b35762de164b20885e15dbe656b1138db06bb402fa1796f5765a23933d8859a"}}}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.AddAuxPowParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"aux_pow": [{
+				"hash": "7b35762de164b20885e15dbe656b1138db06bb402fa1796f5765a23933d8859a",
+				"id": "3200b4ea97c3b2081cd4190b58e49572b2319fed00d030ad51809dff06b5d8c8"
+			}],
+			"blockhashing_blob": "1010ee97e2a106e9f8ebe8887e5b609949ac8ea6143e560ed13552b110cb009b21f0cfca1eaccf00000000b2685c1283a646bc9020c758daa443be145b7370ce5a6efacb3e614117032e2c22",
+			"blocktemplate_blob": "1010f4bae0b4069d648e741d85ca0e7acb4501f051b27e9b107d3cd7a3f03aa7f776089117c81a00000000",
+			"merkle_root": "07b3a6e2ba7e4bc1f6a8d62e84f9f0b8a3c6f5a0b2d3f6a8a7e4c9f2b1a0d3e6",
+			"merkle_tree_depth": 0,
+			"status": "OK",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.AddAuxPowResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.AddAuxPowResult{
+			BlocktemplateBlob: "1010f4bae0b4069d648e741d85ca0e7acb4501f051b27e9b107d3cd7a3f03aa7f776089117c81a00000000",
+			BlockhashingBlob:  "1010ee97e2a106e9f8ebe8887e5b609949ac8ea6143e560ed13552b110cb009b21f0cfca1eaccf00000000b2685c1283a646bc9020c758daa443be145b7370ce5a6efacb3e614117032e2c22",
+			MerkleRoot:        "07b3a6e2ba7e4bc1f6a8d62e84f9f0b8a3c6f5a0b2d3f6a8a7e4c9f2b1a0d3e6",
+			AuxPow: []daemon.AuxPow{{
+				Id:   "3200b4ea97c3b2081cd4190b58e49572b2319fed00d030ad51809dff06b5d8c8",
+				Hash: "7b35762de164b20885e15dbe656b1138db06bb402fa1796f5765a23933d8859a",
+			}},
+			JsonRpcFooter: defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.AddAuxPow(exreq.Body.Params.BlocktemplateBlob, exreq.Body.Params.AuxPow)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// prune_blockchain
+func TestPruneBlockchain(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.PruneBlockchainParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "prune_blockchain", Params: daemon.PruneBlockchainParams{Check: true}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.PruneBlockchainParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"pruned": true,
+			"pruning_seed": 387,
+			"status": "OK",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.PruneBlockchainResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result:        daemon.PruneBlockchainResult{Pruned: true, PruningSeed: 387, JsonRpcFooter: defaultMoneroRpcFooter},
+		Error:         daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.PruneBlockchain(exreq.Body.Params.Check)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// hard_fork_info
+func TestHardForkInfo(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "hard_fork_info", Params: daemon.EmptyMoneroRpcParams{}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.EmptyMoneroRpcParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"credits": 0,
+			"earliest_height": 2689608,
+			"enabled": true,
+			"state": 0,
+			"status": "OK",
+			"threshold": 0,
+			"top_hash": "",
+			"untrusted": false,
+			"version": 16,
+			"votes": 10080,
+			"voting": 16,
+			"window": 10080
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.HardForkInfoResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result: daemon.HardForkInfoResult{
+			EarliestHeight: 2689608,
+			Enabled:        true,
+			Version:        16,
+			Votes:          10080,
+			Voting:         16,
+			Window:         10080,
+			JsonRpcFooter:  defaultMoneroRpcFooter,
+		},
+		Error: daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.HardForkInfo()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// banned
+func TestBanned(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.BannedParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "banned", Params: daemon.BannedParams{Address: "95.216.203.255"}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.BannedParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"banned": true,
+			"seconds": 690,
+			"status": "OK"
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.BannedResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result:        daemon.BannedResult{Banned: true, Seconds: 690, Status: "OK"},
+		Error:         daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.Banned(exreq.Body.Params.Address)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// flush_cache
+func TestFlushCache(t *testing.T) {
+	reqBody := daemon.JsonRpcGenericRequestBody[daemon.FlushCacheParams]{JsonRpcHeader: defaultMoneroRpcHeader, Method: "flush_cache", Params: daemon.FlushCacheParams{BadTxs: true, BadBlocks: false}}
+	exreq := &daemon.MoneroRpcRequest[daemon.JsonRpcGenericRequestBody[daemon.FlushCacheParams]]{Endpoint: daemon.DEFAULT_MONERO_RPC_ENDPOINT, Body: &reqBody}
+	exres := `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"result": {
+			"status": "OK",
+			"untrusted": false
+		}
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.JsonRpcGenericResponse[daemon.FlushCacheResult]{
+		JsonRpcHeader: defaultMoneroRpcHeader,
+		Result:        daemon.FlushCacheResult{JsonRpcFooter: defaultMoneroRpcFooter},
+		Error:         daemon.MoneroRpcError{},
+	}
+
+	actual, err := test_daemon.FlushCache(exreq.Body.Params.BadTxs, exreq.Body.Params.BadBlocks)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetTxpoolBacklogEntries(t *testing.T) {
+	res := daemon.GetTxpoolBacklogResult{Backlog: "\x01\x02\x00\x00\x00\x00\x00\x00\x10\x00\x00\x00\x00\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00"}
+
+	entries, err := res.Entries()
+	assert.NoError(t, err)
+	assert.Equal(t, []daemon.TxBacklogEntry{{Weight: 513, Fee: 16, TimeInPool: 5}}, entries)
+
+	res.Backlog = "\ufffd"
+	_, err = res.Entries()
+	assert.Error(t, err)
+
+	res.Backlog = "\x01"
+	_, err = res.Entries()
+	assert.Error(t, err)
+}
+
+// send_raw_transaction
+func TestSendRawTransaction(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.SendRawTransactionParams]{Endpoint: "/send_raw_transaction", Body: &daemon.SendRawTransactionParams{TxAsHex: "de6a3...", DoNotRelay: false, DoSanityChecks: true}}
+	exres := `{
+		"credits": 0,
+		"double_spend": false,
+		"fee_too_low": false,
+		"invalid_input": false,
+		"invalid_output": false,
+		"low_mixin": false,
+		"not_relayed": false,
+		"overspend": false,
+		"reason": "",
+		"sanity_check_failed": false,
+		"too_big": false,
+		"too_few_outputs": false,
+		"top_hash": "",
+		"tx_extra_too_big": false,
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.SendRawTransactionResponse{JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.SendRawTransaction(exreq.Body.TxAsHex, exreq.Body.DoNotRelay, exreq.Body.DoSanityChecks)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// is_key_image_spent
+func TestIsKeyImageSpent(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.IsKeyImageSpentParams]{Endpoint: "/is_key_image_spent", Body: &daemon.IsKeyImageSpentParams{KeyImages: []string{"8d1bd8181bf7d857bdb281e0153d84cd55a3fcaa57c3e570f4a49f935850b5e3", "7319134bfc50668251f5b899c66b005805ee255c136f0e1cecbb0f3a912e09d4", "045fdea0ca6f106cb9dd9da659d31af2f7f08ba79b10148a6f5d1f424d7107c5"}}}
+	exres := `{
+		"credits": 0,
+		"spent_status": [1, 0, 2],
+		"top_hash": "",
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.IsKeyImageSpentResponse{
+		SpentStatus:   []daemon.KeyImageSpentStatus{daemon.KeyImageSpentInBlockchain, daemon.KeyImageUnspent, daemon.KeyImageSpentInPool},
+		JsonRpcFooter: defaultMoneroRpcFooter,
+	}
+	actual, err := test_daemon.IsKeyImageSpent(exreq.Body.KeyImages)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_alt_blocks_hashes
+func TestGetAltBlocksHashes(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/get_alt_blocks_hashes", Body: nil}
+	exres := `{
+		"blks_hashes": ["8ee10db35b1baf943f201b303890a29e7d45437bd76c2bd4df0d2f2ee34be109"],
+		"credits": 0,
+		"top_hash": "",
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.GetAltBlocksHashesResponse{
+		BlksHashes:    []string{"8ee10db35b1baf943f201b303890a29e7d45437bd76c2bd4df0d2f2ee34be109"},
+		JsonRpcFooter: defaultMoneroRpcFooter,
+	}
+	actual, err := test_daemon.GetAltBlocksHashes()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_transaction_pool_hashes
+func TestGetTransactionPoolHashes(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/get_transaction_pool_hashes", Body: nil}
+	exres := `{
+		"credits": 0,
+		"top_hash": "",
+		"tx_hashes": ["aa928aed888acd6152c60194d50a4df29b0b851be6169acf11b6a8e304dd6c03", "794345f321a98f3135151f3056c0fdf8188646a8dab27de971428acf3551dd11"],
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.GetTransactionPoolHashesResponse{
+		TxHashes:      []string{"aa928aed888acd6152c60194d50a4df29b0b851be6169acf11b6a8e304dd6c03", "794345f321a98f3135151f3056c0fdf8188646a8dab27de971428acf3551dd11"},
+		JsonRpcFooter: defaultMoneroRpcFooter,
+	}
+	actual, err := test_daemon.GetTransactionPoolHashes()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_transaction_pool_stats
+func TestGetTransactionPoolStats(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/get_transaction_pool_stats", Body: nil}
+	exres := `{
+		"credits": 0,
+		"pool_stats": {
+			"bytes_max": 11843,
+			"bytes_med": 2219,
+			"bytes_min": 1528,
+			"bytes_total": 144192,
+			"fee_total": 7018100000,
+			"histo": [{"bytes": 11219, "txs": 4}, {"bytes": 9737, "txs": 5}],
+			"histo_98pc": 0,
+			"num_10m": 0,
+			"num_double_spends": 0,
+			"num_failing": 0,
+			"num_not_relayed": 0,
+			"oldest": 1583442660,
+			"txs_total": 50
+		},
+		"top_hash": "",
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.GetTransactionPoolStatsResponse{
+		PoolStats: daemon.TxPoolStats{
+			BytesMax:   11843,
+			BytesMed:   2219,
+			BytesMin:   1528,
+			BytesTotal: 144192,
+			FeeTotal:   7018100000,
+			Histo:      []daemon.TxPoolHisto{{Txs: 4, Bytes: 11219}, {Txs: 5, Bytes: 9737}},
+			Oldest:     1583442660,
+			TxsTotal:   50,
+		},
+		JsonRpcFooter: defaultMoneroRpcFooter,
+	}
+	actual, err := test_daemon.GetTransactionPoolStats()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_limit
+func TestGetLimit(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/get_limit", Body: nil}
+	exres := `{
+		"limit_down": 1280000,
+		"limit_up": 1280000,
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.GetLimitResponse{LimitDown: 1280000, LimitUp: 1280000, JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.GetLimit()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// set_limit
+func TestSetLimit(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.SetLimitParams]{Endpoint: "/set_limit", Body: &daemon.SetLimitParams{LimitDown: 1024, LimitUp: -1}}
+	exres := `{
+		"limit_down": 1024,
+		"limit_up": 128,
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.SetLimitResponse{LimitDown: 1024, LimitUp: 128, JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.SetLimit(exreq.Body.LimitDown, exreq.Body.LimitUp)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_peer_list
+func TestGetPeerList(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.GetPeerListParams]{Endpoint: "/get_peer_list", Body: &daemon.GetPeerListParams{PublicOnly: true, IncludeBlocked: false}}
+	exres := `{
+		"gray_list": [{
+			"host": "640304833",
+			"id": 5345237316225602120,
+			"ip": 640304833,
+			"last_seen": 1582115417,
+			"port": 18080,
+			"pruning_seed": 0,
+			"rpc_credits_per_hash": 0,
+			"rpc_port": 0
+		}],
+		"white_list": [{
+			"host": "1224475168",
+			"id": 8114524985823458493,
+			"ip": 1224475168,
+			"last_seen": 1583442667,
+			"port": 18080,
+			"pruning_seed": 384,
+			"rpc_credits_per_hash": 0,
+			"rpc_port": 18089
+		}],
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.GetPeerListResponse{
+		GrayList:      []daemon.Peer{{Host: "640304833", Id: 5345237316225602120, Ip: 640304833, LastSeen: 1582115417, Port: 18080}},
+		WhiteList:     []daemon.Peer{{Host: "1224475168", Id: 8114524985823458493, Ip: 1224475168, LastSeen: 1583442667, Port: 18080, PruningSeed: 384, RpcPort: 18089}},
+		JsonRpcFooter: defaultMoneroRpcFooter,
+	}
+	actual, err := test_daemon.GetPeerList(exreq.Body.PublicOnly, exreq.Body.IncludeBlocked)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_public_nodes
+func TestGetPublicNodes(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.GetPublicNodesParams]{Endpoint: "/get_public_nodes", Body: &daemon.GetPublicNodesParams{Gray: false, White: true, IncludeBlocked: false}}
+	exres := `{
+		"white": [{
+			"host": "70.52.75.3",
+			"last_seen": 1631766523,
+			"rpc_credits_per_hash": 0,
+			"rpc_port": 18081
+		}],
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.GetPublicNodesResponse{
+		White:         []daemon.PublicNode{{Host: "70.52.75.3", LastSeen: 1631766523, RpcPort: 18081}},
+		JsonRpcFooter: defaultMoneroRpcFooter,
+	}
+	actual, err := test_daemon.GetPublicNodes(exreq.Body.Gray, exreq.Body.White, exreq.Body.IncludeBlocked)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// out_peers
+func TestOutPeers(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.OutPeersParams]{Endpoint: "/out_peers", Body: &daemon.OutPeersParams{Set: true, OutPeers: 3232235535}}
+	exres := `{
+		"out_peers": 3232235535,
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.OutPeersResponse{OutPeers: 3232235535, JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.OutPeers(exreq.Body.OutPeers)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// in_peers
+func TestInPeers(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.InPeersParams]{Endpoint: "/in_peers", Body: &daemon.InPeersParams{Set: true, InPeers: 32}}
+	exres := `{
+		"in_peers": 32,
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.InPeersResponse{InPeers: 32, JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.InPeers(exreq.Body.InPeers)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// start_mining
+func TestStartMining(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.StartMiningParams]{Endpoint: "/start_mining", Body: &daemon.StartMiningParams{DoBackgroundMining: false, IgnoreBattery: true, MinerAddress: "47xu3gQpF569au9C2ajo5SSMrWji6xnoE5vhr94EzFRaKAGw6hEGFXYAwVADKuRpzsjiU1PtmaVgcjUJF89ghGPhUXkndHc", ThreadsCount: 1}}
+	exres := `{
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.StartMiningResponse{JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.StartMining(exreq.Body.MinerAddress, exreq.Body.ThreadsCount, exreq.Body.DoBackgroundMining, exreq.Body.IgnoreBattery)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// stop_mining
+func TestStopMining(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/stop_mining", Body: nil}
+	exres := `{
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.StopMiningResponse{JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.StopMining()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// mining_status
+func TestMiningStatus(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/mining_status", Body: nil}
+	exres := `{
+		"active": true,
+		"address": "47xu3gQpF569au9C2ajo5SSMrWji6xnoE5vhr94EzFRaKAGw6hEGFXYAwVADKuRpzsjiU1PtmaVgcjUJF89ghGPhUXkndHc",
+		"bg_idle_threshold": 0,
+		"bg_ignore_battery": false,
+		"bg_min_idle_seconds": 0,
+		"bg_target": 0,
+		"block_reward": 1793302048716,
+		"block_target": 120,
+		"difficulty": 292022797663,
+		"difficulty_top64": 0,
+		"is_background_mining_enabled": false,
+		"pow_algorithm": "RandomX",
+		"speed": 8,
+		"threads_count": 1,
+		"wide_difficulty": "0x43fdea455f",
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.MiningStatusResponse{
+		Active:         true,
+		Address:        "47xu3gQpF569au9C2ajo5SSMrWji6xnoE5vhr94EzFRaKAGw6hEGFXYAwVADKuRpzsjiU1PtmaVgcjUJF89ghGPhUXkndHc",
+		BlockReward:    1793302048716,
+		BlockTarget:    120,
+		Difficulty:     292022797663,
+		PowAlgorithm:   "RandomX",
+		Speed:          8,
+		ThreadsCount:   1,
+		WideDifficulty: "0x43fdea455f",
+		JsonRpcFooter:  defaultMoneroRpcFooter,
+	}
+	actual, err := test_daemon.MiningStatus()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// save_bc
+func TestSaveBc(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/save_bc", Body: nil}
+	exres := `{
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.SaveBcResponse{JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.SaveBc()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// get_net_stats
+func TestGetNetStats(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/get_net_stats", Body: nil}
+	exres := `{
+		"start_time": 1582109080,
+		"total_bytes_in": 3556844193,
+		"total_bytes_out": 5683903603,
+		"total_packets_in": 3139540,
+		"total_packets_out": 1938785,
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.GetNetStatsResponse{
+		StartTime:       1582109080,
+		TotalBytesIn:    3556844193,
+		TotalBytesOut:   5683903603,
+		TotalPacketsIn:  3139540,
+		TotalPacketsOut: 1938785,
+		JsonRpcFooter:   defaultMoneroRpcFooter,
+	}
+	actual, err := test_daemon.GetNetStats()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// pop_blocks
+func TestPopBlocks(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.PopBlocksParams]{Endpoint: "/pop_blocks", Body: &daemon.PopBlocksParams{NBlocks: 6}}
+	exres := `{
+		"height": 76482,
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.PopBlocksResponse{Height: 76482, JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.PopBlocks(exreq.Body.NBlocks)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// update
+func TestUpdate(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.UpdateParams]{Endpoint: "/update", Body: &daemon.UpdateParams{Command: "check"}}
+	exres := `{
+		"auto_uri": "",
+		"hash": "",
+		"path": "",
+		"update": false,
+		"user_uri": "",
+		"version": "",
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.UpdateResponse{JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.Update(exreq.Body.Command, exreq.Body.Path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// stop_daemon
+func TestStopDaemon(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/stop_daemon", Body: nil}
+	exres := `{
+		"status": "OK",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &daemon.StopDaemonResponse{JsonRpcFooter: defaultMoneroRpcFooter}
+	actual, err := test_daemon.StopDaemon()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestSendRawTransactionRejected(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.SendRawTransactionParams]{Endpoint: "/send_raw_transaction", Body: &daemon.SendRawTransactionParams{TxAsHex: "de6a3...", DoSanityChecks: true}}
+	exres := `{
+		"double_spend": true,
+		"reason": "double spend",
+		"not_relayed": true,
+		"status": "Failed",
+		"untrusted": false
+	}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := test_daemon.SendRawTransaction(exreq.Body.TxAsHex, exreq.Body.DoNotRelay, exreq.Body.DoSanityChecks)
+	assert.EqualError(t, err, "/send_raw_transaction: Failed")
+	assert.NotNil(t, actual)
+	assert.True(t, actual.DoubleSpend)
+	assert.True(t, actual.NotRelayed)
+	assert.Equal(t, "double spend", actual.Reason)
+}
+
+func getBlockingDaemonRpcTestServer(started chan<- struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		started <- struct{}{}
+		<-r.Context().Done()
+	}))
+}
+
+func TestGetBlockByHeightCtxDeadline(t *testing.T) {
+	started := make(chan struct{}, 1)
+	server := getBlockingDaemonRpcTestServer(started)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = test_daemon.GetBlockByHeightCtx(ctx, false, 912345)
+	<-started
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGetTransactionsCtxCancel(t *testing.T) {
+	started := make(chan struct{}, 1)
+	server := getBlockingDaemonRpcTestServer(started)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	_, err = test_daemon.GetTransactionsCtx(ctx, []string{"d6e48158472848e6687173a91ae6eebfa3e1d778e65252ee99d7515d63090408"}, true, false, false)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGetCurrentHeightCtxCanceledBeforeSend(t *testing.T) {
+	exreq := &daemon.MoneroRpcRequest[daemon.EmptyMoneroRpcParams]{Endpoint: "/get_height", Body: nil}
+	exres := `{"hash": "7e23a28cfa6df925d5b63940baf60b83c0cbb65da95f49b19e7cf0ce7dd709ce", "height": 2287217, "status": "OK", "untrusted": false}`
+	server := getDaemonRpcTestServer(exreq, &exres)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = test_daemon.GetCurrentHeightCtx(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	actual, err := test_daemon.GetCurrentHeightCtx(context.Background())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, uint64(2287217), actual.Height)
+}
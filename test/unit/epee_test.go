@@ -0,0 +1,187 @@
+package test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/daemon/epee"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	epeeTestHash1 = "1111111111111111111111111111111111111111111111111111111111111111"
+	epeeTestHash2 = "2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+// get_blocks.bin request with two block ids (0x11... and 0x22...), start_height 2751506 and prune set
+const epeeTestGetBlocksRequest = "0111010101010201010c09626c6f636b5f6964730a0101111111111111111111111111111111111111111111111111111111111111111122222222222222222222222222222222222222222222222222222222222222220c73746172745f6865696768740512fc290000000000057072756e650b01"
+
+func TestEpeeMarshal(t *testing.T) {
+	data, err := epee.Marshal(&daemon.GetBlocksBinParams{
+		BlockIds:    []string{epeeTestHash1, epeeTestHash2},
+		StartHeight: 2751506,
+		Prune:       true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, epeeTestGetBlocksRequest, hex.EncodeToString(data))
+
+	var res daemon.GetBlocksBinParams
+	assert.NoError(t, epee.Unmarshal(data, &res))
+	assert.Equal(t, []string{epeeTestHash1, epeeTestHash2}, res.BlockIds)
+	assert.Equal(t, uint64(2751506), res.StartHeight)
+	assert.True(t, res.Prune)
+	assert.False(t, res.NoMinerTx)
+}
+
+type epeeTestInner struct {
+	Name  string   `epee:"name"`
+	Items []uint32 `epee:"items"`
+}
+
+type epeeTestValue struct {
+	I64      int64           `epee:"i64"`
+	I32      int32           `epee:"i32"`
+	I16      int16           `epee:"i16"`
+	I8       int8            `epee:"i8"`
+	U64      uint64          `epee:"u64"`
+	U32      uint32          `epee:"u32"`
+	U16      uint16          `epee:"u16"`
+	U8       uint8           `epee:"u8"`
+	Double   float64         `epee:"double"`
+	Bool     bool            `epee:"bool"`
+	Str      string          `epee:"str"`
+	Blob     []byte          `epee:"blob"`
+	Hash     string          `epee:"hash,hex"`
+	Inner    epeeTestInner   `epee:"inner"`
+	Objects  []epeeTestInner `epee:"objects"`
+	Nested   [][]uint64      `epee:"nested"`
+	Strings  []string        `epee:"strings"`
+	Ptr      *epeeTestInner  `epee:"ptr"`
+	Empty    []uint64        `epee:"empty"`
+	Omitted  uint64          `epee:"omitted,omitempty"`
+	Skipped  uint64          `epee:"-"`
+	Untagged uint8
+}
+
+func TestEpeeRoundTrip(t *testing.T) {
+	v := epeeTestValue{
+		I64:      math.MinInt64,
+		I32:      -2,
+		I16:      -3,
+		I8:       -4,
+		U64:      math.MaxUint64,
+		U32:      6,
+		U16:      7,
+		U8:       8,
+		Double:   0.5,
+		Bool:     true,
+		Str:      string(bytes.Repeat([]byte("a"), 0x4000)),
+		Blob:     bytes.Repeat([]byte{0xff}, 0x40),
+		Hash:     epeeTestHash1,
+		Inner:    epeeTestInner{"inner", []uint32{1, 2}},
+		Objects:  []epeeTestInner{{"first", nil}, {"second", []uint32{3}}},
+		Nested:   [][]uint64{{1}, {2, 3}},
+		Strings:  []string{"x", "y"},
+		Ptr:      &epeeTestInner{Name: "ptr"},
+		Empty:    []uint64{},
+		Skipped:  9,
+		Untagged: 10,
+	}
+
+	data, err := epee.Marshal(v)
+	assert.NoError(t, err)
+
+	s, err := epee.Parse(data)
+	assert.NoError(t, err)
+	assert.NotContains(t, s, "omitted")
+	assert.NotContains(t, s, "Skipped")
+	assert.Contains(t, s, "Untagged")
+	assert.Equal(t, epee.Section{"name": []byte("ptr"), "items": []any{}}, s["ptr"])
+
+	var res epeeTestValue
+	assert.NoError(t, epee.Unmarshal(data, &res))
+
+	// the absent arrays are decoded as empty ones
+	v.Objects[0].Items = []uint32{}
+	v.Ptr.Items = []uint32{}
+	v.Skipped = 0
+	assert.Equal(t, v, res)
+}
+
+func TestEpeeUnmarshalConversions(t *testing.T) {
+	data, err := epee.Marshal(map[string]any{"a": uint8(5), "b": int64(-1), "c": uint64(300)})
+	assert.NoError(t, err)
+
+	var ok struct {
+		A uint64 `epee:"a"`
+		B int8   `epee:"b"`
+		C int    `epee:"c"`
+	}
+	assert.NoError(t, epee.Unmarshal(data, &ok))
+	assert.Equal(t, uint64(5), ok.A)
+	assert.Equal(t, int8(-1), ok.B)
+	assert.Equal(t, 300, ok.C)
+
+	var negative struct {
+		B uint64 `epee:"b"`
+	}
+	assert.True(t, errors.Is(epee.Unmarshal(data, &negative), epee.ErrTypeMismatch))
+
+	var overflow struct {
+		C uint8 `epee:"c"`
+	}
+	assert.True(t, errors.Is(epee.Unmarshal(data, &overflow), epee.ErrTypeMismatch))
+
+	var wrongType struct {
+		A string `epee:"a"`
+	}
+	assert.True(t, errors.Is(epee.Unmarshal(data, &wrongType), epee.ErrTypeMismatch))
+}
+
+func TestEpeeParseErrors(t *testing.T) {
+	valid := decodeHexHelper(t, epeeTestGetBlocksRequest)
+
+	_, err := epee.Parse(valid[:5])
+	assert.True(t, errors.Is(err, epee.ErrUnexpectedEOF))
+
+	invalid := append([]byte{}, valid...)
+	invalid[0] = 0
+	_, err = epee.Parse(invalid)
+	assert.True(t, errors.Is(err, epee.ErrInvalidSignature))
+
+	_, err = epee.Parse(valid[:len(valid)-1])
+	assert.True(t, errors.Is(err, epee.ErrUnexpectedEOF))
+
+	_, err = epee.Parse(append(append([]byte{}, valid...), 0))
+	assert.True(t, errors.Is(err, epee.ErrTrailingData))
+
+	// unknown type of the first entry
+	invalid = append([]byte{}, valid...)
+	invalid[epee.PORTABLE_STORAGE_HEADER_SIZE+1+1+len("block_ids")] = 0x0e
+	_, err = epee.Parse(invalid)
+	assert.True(t, errors.Is(err, epee.ErrUnsupportedType))
+	var parseErr *epee.ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, epee.PORTABLE_STORAGE_HEADER_SIZE+2+len("block_ids"), parseErr.Offset)
+
+	// a section claiming more entries than the data can hold
+	invalid = append([]byte{}, valid[:epee.PORTABLE_STORAGE_HEADER_SIZE]...)
+	invalid = append(invalid, 0xfe, 0xff, 0xff, 0xff)
+	_, err = epee.Parse(invalid)
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, epee.PORTABLE_STORAGE_HEADER_SIZE, parseErr.Offset)
+
+	// objects nested deeper than MAX_DEPTH
+	deep := append([]byte{}, valid[:epee.PORTABLE_STORAGE_HEADER_SIZE]...)
+	for i := 0; i <= epee.MAX_DEPTH; i++ {
+		deep = append(deep, 0x04, 0x01, 'a', epee.SERIALIZE_TYPE_OBJECT)
+	}
+	deep = append(deep, 0x00)
+	_, err = epee.Parse(deep)
+	assert.True(t, errors.Is(err, epee.ErrTooDeep))
+}
@@ -0,0 +1,168 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func getDaemonRpcErrorTestServer(status int, res string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(res))
+	}))
+}
+
+func TestDaemonRpcErrorCodes(t *testing.T) {
+	server := getDaemonRpcErrorTestServer(http.StatusOK, `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"error": {
+			"code": -2,
+			"message": "Requested block height: 3500000 greater than current top block height: 3200000"
+		}
+	}`)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = test_daemon.GetBlockByHeight(false, 3500000)
+	assert.ErrorIs(t, err, daemon.ErrTooBigHeight)
+	assert.ErrorIs(t, err, daemon.ErrBlockNotFound)
+	assert.NotErrorIs(t, err, daemon.ErrDaemonBusy)
+	assert.EqualError(t, err, "get_block: code -2: Requested block height: 3500000 greater than current top block height: 3200000")
+
+	var rpcErr *daemon.MoneroRpcError
+	if assert.True(t, errors.As(err, &rpcErr)) {
+		assert.Equal(t, "get_block", rpcErr.Method)
+		assert.Equal(t, http.StatusOK, rpcErr.HttpStatus)
+		assert.Equal(t, daemon.RPC_ERROR_CODE_TOO_BIG_HEIGHT, rpcErr.Code)
+	}
+}
+
+func TestDaemonRpcErrorBlockNotFound(t *testing.T) {
+	server := getDaemonRpcErrorTestServer(http.StatusOK, `{
+		"id": "0",
+		"jsonrpc": "2.0",
+		"error": {
+			"code": -5,
+			"message": "Internal error: can't get block by hash. Hash = 0000000000000000000000000000000000000000000000000000000000000000."
+		}
+	}`)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = test_daemon.GetBlockHeaderByHash(false, "0000000000000000000000000000000000000000000000000000000000000000")
+	assert.ErrorIs(t, err, daemon.ErrBlockNotFound)
+	assert.ErrorIs(t, err, daemon.ErrInternalError)
+	assert.NotErrorIs(t, err, daemon.ErrTooBigHeight)
+}
+
+func TestDaemonRpcErrorMethodNotFound(t *testing.T) {
+	server := getDaemonRpcErrorTestServer(http.StatusOK, `{"id": "0", "jsonrpc": "2.0", "error": {"code": -32601, "message": "Method not found"}}`)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = test_daemon.GetMinerData()
+	assert.ErrorIs(t, err, daemon.ErrMethodNotFound)
+}
+
+func TestDaemonRpcErrorStatuses(t *testing.T) {
+	tests := []struct {
+		status   string
+		expected error
+	}{
+		{daemon.RPC_STATUS_BUSY, daemon.ErrDaemonBusy},
+		{daemon.RPC_STATUS_NOT_MINING, daemon.ErrNotMining},
+		{daemon.RPC_STATUS_PAYMENT_REQUIRED, daemon.ErrPaymentRequired},
+	}
+
+	for _, v := range tests {
+		server := getDaemonRpcErrorTestServer(http.StatusOK, `{"status": "`+v.status+`", "untrusted": false}`)
+
+		test_daemon, err := createTestDaemonRpcClient(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = test_daemon.StopMining()
+		assert.ErrorIs(t, err, v.expected)
+		assert.ErrorIs(t, err, daemon.ErrStatusNotOk)
+
+		var statusErr *daemon.RpcStatusError
+		if assert.True(t, errors.As(err, &statusErr)) {
+			assert.Equal(t, "/stop_mining", statusErr.Endpoint)
+			assert.Equal(t, v.status, statusErr.Status)
+		}
+
+		server.Close()
+	}
+}
+
+func TestDaemonRpcErrorJsonRpcBusyStatus(t *testing.T) {
+	server := getDaemonRpcErrorTestServer(http.StatusOK, `{"id": "0", "jsonrpc": "2.0", "result": {"status": "BUSY", "untrusted": false}}`)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = test_daemon.GetInfo()
+	assert.ErrorIs(t, err, daemon.ErrDaemonBusy)
+	assert.ErrorIs(t, err, daemon.ErrStatusNotOk)
+
+	var statusErr *daemon.RpcStatusError
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, "get_info", statusErr.Endpoint)
+		assert.Equal(t, daemon.RPC_STATUS_BUSY, statusErr.Status)
+	}
+
+	_, err = test_daemon.GetBlockCount()
+	assert.ErrorIs(t, err, daemon.ErrDaemonBusy)
+
+	// the same after the retries are exhausted
+	test_daemon.SetRetryPolicy(newTestRetryPolicy())
+	_, err = test_daemon.GetBlockCount()
+	assert.ErrorIs(t, err, daemon.ErrDaemonBusy)
+}
+
+func TestDaemonRpcErrorHttpStatus(t *testing.T) {
+	server := getDaemonRpcErrorTestServer(http.StatusUnauthorized, "")
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = test_daemon.GetInfo()
+	assert.ErrorIs(t, err, daemon.ErrUnauthorized)
+	assert.NotErrorIs(t, err, daemon.ErrForbidden)
+	assert.EqualError(t, err, "get_info: 401 Unauthorized")
+
+	var httpErr *utils.HttpStatusError
+	if assert.True(t, errors.As(err, &httpErr)) {
+		assert.Equal(t, "get_info", httpErr.Method)
+		assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+	}
+
+	_, err = test_daemon.GetHashesBin(nil, 0)
+	assert.EqualError(t, err, "get_hashes.bin: 401 Unauthorized")
+}
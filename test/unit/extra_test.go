@@ -0,0 +1,206 @@
+package test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/chekist32/go-monero/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTxExtraRoundTrip(t *testing.T) {
+	cases := []string{
+		"",
+		"0166488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a81835020901c26ecfa7aabbb41b",
+		// nonce before the pub key
+		"020901a88dcbe6025e76ce01c4865e47b9392d52e6f4957d0a6f6a9feda0ef0ac4807e1127bea56cb3ba583e",
+		// unencrypted payment id
+		"01c4865e47b9392d52e6f4957d0a6f6a9feda0ef0ac4807e1127bea56cb3ba583e022100" + "1122334455667788112233445566778811223344556677881122334455667788",
+		additionalKeysTestExtra,
+		// merge mining tag + minergate + padding
+		"0166488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a8183503210a" + "aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899" + "de0401020304" + "000000",
+	}
+
+	for _, v := range cases {
+		t.Run("", func(t *testing.T) {
+			extra, err := hex.DecodeString(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			txExtra, err := utils.ParseTxExtra(extra)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, v, hex.EncodeToString(txExtra.Bytes()))
+		})
+	}
+}
+
+func TestParseTxExtraFields(t *testing.T) {
+	extra, err := hex.DecodeString("0166488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a8183503210a" + "aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899" + "de0401020304" + "020901a88dcbe6025e76ce" + "000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txExtra, err := utils.ParseTxExtra(extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, txExtra.Fields, 5)
+	assert.Equal(t, "66488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a81835", hex.EncodeToString(txExtra.TxPublicKey().Bytes()))
+
+	mm, ok := txExtra.Fields[1].(*utils.TxExtraMergeMining)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), mm.Depth)
+	assert.Equal(t, "aabbccddeeff00112233445566778899aabbccddeeff00112233445566778899", hex.EncodeToString(mm.MerkleRoot[:]))
+
+	mg, ok := txExtra.Fields[2].(*utils.TxExtraMinergate)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{1, 2, 3, 4}, mg.Data)
+
+	payId, ok := txExtra.EncryptedPaymentID()
+	assert.True(t, ok)
+	assert.Equal(t, "a88dcbe6025e76ce", hex.EncodeToString(payId))
+	_, ok = txExtra.PaymentID()
+	assert.False(t, ok)
+
+	padding, ok := txExtra.Fields[4].(*utils.TxExtraPadding)
+	assert.True(t, ok)
+	assert.Equal(t, 3, padding.Size)
+}
+
+func TestParseTxExtraErrors(t *testing.T) {
+	cases := []struct {
+		extra  string
+		offset int
+	}{
+		// unknown tag
+		{extra: "05", offset: 0},
+		// truncated pub key
+		{extra: "0166488b56658159e0", offset: 1},
+		// nonce size exceeds the extra
+		{extra: "020901a88dcbe6025e76", offset: 2},
+		// non canonical varint size
+		{extra: "02800001", offset: 1},
+		// non zero padding
+		{extra: "00000100", offset: 2},
+		// invalid merge mining tag size
+		{extra: "0302000a", offset: 3},
+		// additional pub keys count exceeds the extra
+		{extra: "04020993e6ca2d66871e4869adb2c3a524ad7205fcd3e0b3339daafaea76fc5518ee", offset: 2},
+	}
+
+	for _, v := range cases {
+		t.Run(v.extra, func(t *testing.T) {
+			extra, err := hex.DecodeString(v.extra)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = utils.ParseTxExtra(extra)
+
+			var extraErr *utils.ExtraError
+			if !errors.As(err, &extraErr) {
+				t.Fatalf("expected *utils.ExtraError, got %v", err)
+			}
+			assert.Equal(t, v.offset, extraErr.Offset)
+		})
+	}
+}
+
+func TestParseTxExtraPartial(t *testing.T) {
+	extra, err := hex.DecodeString("0166488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a81835020901c26ecfa7aabbb41b05ffff")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txExtra, err := utils.ParseTxExtra(extra)
+	assert.Error(t, err)
+	assert.Len(t, txExtra.Fields, 2)
+
+	txPub, payId := utils.ParseExtra(extra)
+	assert.Equal(t, "66488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a81835", hex.EncodeToString(txPub.Bytes()))
+	assert.Equal(t, "c26ecfa7aabbb41b", hex.EncodeToString(payId))
+}
+
+func TestTxExtraNoncePaymentID(t *testing.T) {
+	payId := utils.NewPaymentID256()
+	nonce, err := utils.NewTxExtraNoncePaymentID(payId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, ok := nonce.PaymentID()
+	assert.True(t, ok)
+	assert.Equal(t, payId, res)
+	_, ok = nonce.EncryptedPaymentID()
+	assert.False(t, ok)
+
+	_, err = utils.NewTxExtraNonceEncryptedPaymentID(payId)
+	assert.Error(t, err)
+
+	txExtra := &utils.TxExtra{Fields: []utils.TxExtraField{nonce}}
+	parsed, err := utils.ParseTxExtra(txExtra.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, ok = parsed.PaymentID()
+	assert.True(t, ok)
+	assert.Equal(t, payId, res)
+}
+
+func TestEncryptDecryptPaymentID(t *testing.T) {
+	viewKey, err := utils.NewPrivateKey("8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009")
+	if err != nil {
+		t.Fatal(err)
+	}
+	txKey, err := utils.NewPrivateKey("c04ac8adc844e07263bf9a4dd337883eb55db89743c9aece4357381ae6c0b106")
+	if err != nil {
+		t.Fatal(err)
+	}
+	txPub := utils.GetPublicKeyFromPrivate(txKey)
+
+	payId := utils.NewPaymentID64()
+	encrypted, err := utils.EncryptPaymentID(payId, utils.GetPublicKeyFromPrivate(viewKey), txKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, payId, encrypted)
+
+	decrypted, err := utils.DecryptPaymentID(encrypted, txPub, viewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, payId, decrypted)
+
+	_, err = utils.DecryptPaymentID(utils.NewPaymentID256(), txPub, viewKey)
+	assert.Error(t, err)
+}
+
+func TestParseExtraWithDecryptedPaymentID(t *testing.T) {
+	viewKey, err := utils.NewPrivateKey("8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extra, err := hex.DecodeString("017302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364" + "020901" + "6fdb5dba8289cee2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	txPub, _, payId := utils.ParseExtraWithDecryptedPaymentID(extra, viewKey)
+	assert.Equal(t, "7302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364", hex.EncodeToString(txPub.Bytes()))
+	assert.Equal(t, "1122334455667788", hex.EncodeToString(payId))
+
+	// the dummy payment id, which decrypts to zeros
+	extra, err = hex.DecodeString("017302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364" + "020901" + "7ef96efed7efb96a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, payId = utils.ParseExtraWithDecryptedPaymentID(extra, viewKey)
+	assert.Nil(t, payId)
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/stretchr/testify/assert"
+)
+
+// Answers with the blocks whose weight is the height, the txs whose hash starts with "missed" are unknown.
+// Keeps track of the sizes of the requests and of the number of the concurrent ones.
+type fetchTestClient struct {
+	daemon.IDaemonRpcClient
+	mu            sync.Mutex
+	sizes         []int
+	active        int
+	maxActive     int
+	failAtHeight  uint64
+	reverseTxs    bool
+	requestsDelay time.Duration
+}
+
+func (c *fetchTestClient) beginHelper(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sizes = append(c.sizes, size)
+	c.active++
+	if c.active > c.maxActive {
+		c.maxActive = c.active
+	}
+}
+
+func (c *fetchTestClient) endHelper() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active--
+}
+
+func (c *fetchTestClient) GetBlocksByHeightBinCtx(ctx context.Context, heights []uint64) (*daemon.GetBlocksByHeightBinResponse, error) {
+	c.beginHelper(len(heights))
+	defer c.endHelper()
+	time.Sleep(c.requestsDelay)
+
+	res := &daemon.GetBlocksByHeightBinResponse{}
+	for _, v := range heights {
+		if c.failAtHeight != 0 && v == c.failAtHeight {
+			return nil, daemon.ErrTooBigHeight
+		}
+		res.Blocks = append(res.Blocks, daemon.BlockCompleteEntry{BlockWeight: v})
+	}
+	return res, nil
+}
+
+func (c *fetchTestClient) GetTransactionsCtx(ctx context.Context, txHashes []string, decodeAsJson bool, prune bool, split bool) (*daemon.GetTransactionsResponse, error) {
+	c.beginHelper(len(txHashes))
+	defer c.endHelper()
+	time.Sleep(c.requestsDelay)
+
+	res := &daemon.GetTransactionsResponse{}
+	for _, v := range txHashes {
+		if len(v) >= 6 && v[:6] == "missed" {
+			res.MissedTx = append(res.MissedTx, v)
+			continue
+		}
+		res.Txs = append(res.Txs, daemon.MoneroTx1{TxHash: v, PrunableHash: fmt.Sprint(prune)})
+	}
+	if c.reverseTxs {
+		for i, j := 0, len(res.Txs)-1; i < j; i, j = i+1, j-1 {
+			res.Txs[i], res.Txs[j] = res.Txs[j], res.Txs[i]
+		}
+	}
+	return res, nil
+}
+
+func TestFetchBlocks(t *testing.T) {
+	client := &fetchTestClient{requestsDelay: 5 * time.Millisecond}
+	f := daemon.NewFetcher(client)
+
+	blocks, err := f.FetchBlocks(context.Background(), 100, 2599, &daemon.FetchOptions{Workers: 3, ChunkSize: 5000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, blocks, 2500)
+	for i, v := range blocks {
+		assert.Equal(t, uint64(100+i), v.BlockWeight)
+	}
+	// the chunks are capped by the restricted rpc limit
+	assert.ElementsMatch(t, []int{1000, 1000, 500}, client.sizes)
+	assert.LessOrEqual(t, client.maxActive, 3)
+
+	_, err = f.FetchBlocks(context.Background(), 10, 9, nil)
+	assert.ErrorIs(t, err, daemon.ErrInvalidRange)
+}
+
+func TestFetchBlocksError(t *testing.T) {
+	client := &fetchTestClient{failAtHeight: 350}
+	f := daemon.NewFetcher(client)
+
+	blocks, err := f.FetchBlocks(context.Background(), 0, 999, &daemon.FetchOptions{ChunkSize: 50})
+	assert.ErrorIs(t, err, daemon.ErrTooBigHeight)
+	assert.Nil(t, blocks)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = f.FetchBlocks(ctx, 0, 999, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFetchTransactions(t *testing.T) {
+	client := &fetchTestClient{reverseTxs: true}
+	f := daemon.NewFetcher(client)
+
+	var hashes, expected []string
+	for i := 0; i < 250; i++ {
+		if i%60 == 7 {
+			hashes = append(hashes, fmt.Sprintf("missed%d", i))
+			continue
+		}
+		h := fmt.Sprintf("tx%d", i)
+		hashes = append(hashes, h)
+		expected = append(expected, h)
+	}
+
+	txs, err := f.FetchTransactions(context.Background(), hashes, &daemon.FetchOptions{Workers: 2, Prune: true})
+	assert.ErrorIs(t, err, daemon.ErrMissedTx)
+
+	var missedErr *daemon.MissedTxError
+	if assert.True(t, errors.As(err, &missedErr)) {
+		assert.Equal(t, []string{"missed7", "missed67", "missed127", "missed187", "missed247"}, missedErr.Hashes)
+	}
+
+	actual := make([]string, len(txs))
+	for i, v := range txs {
+		actual[i] = v.TxHash
+		assert.Equal(t, "true", v.PrunableHash)
+	}
+	assert.Equal(t, expected, actual)
+	assert.ElementsMatch(t, []int{100, 100, 50}, client.sizes)
+
+	txs, err = f.FetchTransactions(context.Background(), expected[:10], nil)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 10)
+}
@@ -0,0 +1,391 @@
+package test
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/daemon/mock"
+	"github.com/chekist32/go-monero/scanner"
+	"github.com/chekist32/go-monero/tx"
+	"github.com/chekist32/go-monero/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func getMockTestServer(t *testing.T, config mock.Config) (*mock.Daemon, *httptest.Server, daemon.IDaemonRpcClient) {
+	d := mock.New(config)
+	server := httptest.NewServer(d)
+
+	client, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return d, server, client
+}
+
+func mockTestKeyHelper(n int) string {
+	return fmt.Sprintf("%064x", n)
+}
+
+func mockTestTx(keyImage int, outputs ...mock.Output) mock.Tx {
+	return mock.Tx{Inputs: []mock.Input{{KeyImage: mockTestKeyHelper(keyImage)}}, Outputs: outputs, Fee: 30000000}
+}
+
+func TestMockDaemonChain(t *testing.T) {
+	d, server, client := getMockTestServer(t, mock.Config{GenesisTime: time.Unix(1700000000, 0)})
+	defer server.Close()
+
+	hashes, err := d.GenerateBlocks(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis, _ := d.Block(0)
+
+	count, err := client.GetBlockCount()
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(3), count.Result.Count)
+	}
+
+	last, err := client.GetLastBlockHeader(false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, hashes[1], last.Result.BlockHeader.Hash)
+		assert.Equal(t, hashes[0], last.Result.BlockHeader.PrevHash)
+		assert.Equal(t, uint64(2), last.Result.BlockHeader.Height)
+		assert.Equal(t, uint32(1700000000+2*120), last.Result.BlockHeader.Timestamp)
+		assert.Equal(t, mock.DEFAULT_BLOCK_REWARD, last.Result.BlockHeader.Reward)
+	}
+
+	headers, err := client.GetBlockHeadersRange(false, 0, 2)
+	if assert.NoError(t, err) && assert.Len(t, headers.Result.Headers, 3) {
+		assert.Equal(t, genesis.Hash, headers.Result.Headers[0].Hash)
+		assert.Equal(t, uint64(2), headers.Result.Headers[0].Depth)
+		assert.Equal(t, headers.Result.Headers[0].Hash, headers.Result.Headers[1].PrevHash)
+	}
+
+	header, err := client.GetBlockHeaderByHash(false, hashes[0])
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(1), header.Result.BlockHeader.Height)
+	}
+	_, err = client.GetBlockHeaderByHash(false, mockTestKeyHelper(1))
+	assert.ErrorIs(t, err, daemon.ErrBlockNotFound)
+	_, err = client.GetBlockHeaderByHeight(false, 3)
+	assert.ErrorIs(t, err, daemon.ErrBlockNotFound)
+
+	// the blob is a real block with the reported hash
+	block, err := client.GetBlockByHeight(false, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, genesis.Hash, block.Result.BlockDetails.PrevId)
+		assert.Equal(t, uint64(1), block.Result.BlockDetails.MinerTx.Vin[0].Gen.Height)
+
+		parsed, err := tx.ParseBlockHex(block.Result.Blob)
+		if assert.NoError(t, err) {
+			hash, _ := parsed.Hash()
+			assert.Equal(t, hashes[0], hash.String())
+			minerHash, _ := parsed.MinerTx.Hash()
+			assert.Equal(t, block.Result.MinerTxHash, minerHash.String())
+		}
+	}
+
+	hashesBin, err := client.GetHashesBin([]string{hashes[0], genesis.Hash}, 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, hashes, hashesBin.MBlockIds)
+		assert.Equal(t, uint64(1), hashesBin.StartHeight)
+	}
+
+	/** a reorg: the new blocks at the same heights get other hashes **/
+	assert.NoError(t, d.PopBlocks(2))
+	assert.ErrorIs(t, d.PopBlocks(1), mock.ErrPopTooDeep)
+	reorged, err := d.GenerateBlocks(2)
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, hashes, reorged)
+	}
+	_, err = client.GetBlockHeaderByHash(false, hashes[1])
+	assert.ErrorIs(t, err, daemon.ErrBlockNotFound)
+}
+
+func TestMockDaemonTxs(t *testing.T) {
+	d, server, client := getMockTestServer(t, mock.Config{})
+	defer server.Close()
+
+	out := mock.Output{Key: mockTestKeyHelper(10), ViewTag: "ab", EncryptedAmount: "0102030405060708", Commitment: mockTestKeyHelper(11)}
+	hash, err := d.AddToPool(mockTestTx(1, out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = d.AddToPool(mockTestTx(1))
+	assert.ErrorIs(t, err, mock.ErrDoubleSpend)
+	_, err = d.AddToPool(mock.Tx{Outputs: []mock.Output{{Key: "abcd"}}})
+	assert.ErrorIs(t, err, mock.ErrInvalidTx)
+
+	pool, err := client.GetTransactionPool()
+	if assert.NoError(t, err) && assert.Len(t, pool.Transactions, 1) {
+		assert.Equal(t, hash, pool.Transactions[0].IdHash)
+		assert.Equal(t, uint64(30000000), pool.Transactions[0].Fee)
+		assert.Equal(t, mockTestKeyHelper(1), pool.Transactions[0].TxInfo.Vin[0].Key.KeyImage)
+		assert.Len(t, pool.Transactions[0].TxInfo.Vin[0].Key.KeyOffsets, mock.DEFAULT_RING_SIZE)
+	}
+
+	spent, err := client.IsKeyImageSpent([]string{mockTestKeyHelper(1), mockTestKeyHelper(2)})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []daemon.KeyImageSpentStatus{daemon.KeyImageSpentInPool, daemon.KeyImageUnspent}, spent.SpentStatus)
+	}
+
+	blocks, err := d.GenerateBlocks(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, d.Pool())
+
+	txs, err := client.GetTransactions([]string{hash, mockTestKeyHelper(3)}, true, false, false)
+	if assert.NoError(t, err) && assert.Len(t, txs.Txs, 1) {
+		res := txs.Txs[0]
+		assert.Equal(t, []string{mockTestKeyHelper(3)}, txs.MissedTx)
+		assert.False(t, res.InPool)
+		assert.Equal(t, uint64(1), res.BlockHeight)
+		assert.Equal(t, uint64(1), res.Confirmations)
+		// the miner txs of the genesis block and the block 1 have an output each
+		assert.Equal(t, []uint64{2}, res.OutputIndices)
+		assert.Equal(t, daemon.TaggedKey{Key: out.Key, ViewTag: out.ViewTag}, res.TxInfo.Vout[0].Target.TaggedKey)
+		assert.Equal(t, out.EncryptedAmount, res.TxInfo.RctSignatures.EcdhInfo[0].TruncAmount)
+		assert.Equal(t, []string{out.Commitment}, res.TxInfo.RctSignatures.OutPk)
+
+		parsed, err := tx.ParseTransactionHex(res.AsHex)
+		if assert.NoError(t, err) {
+			h, _ := parsed.Hash()
+			assert.Equal(t, hash, h.String())
+		}
+	}
+
+	spent, err = client.IsKeyImageSpent([]string{mockTestKeyHelper(1)})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []daemon.KeyImageSpentStatus{daemon.KeyImageSpentInBlockchain}, spent.SpentStatus)
+	}
+
+	bin, err := client.GetBlocksByHeightBin([]uint64{1})
+	if assert.NoError(t, err) && assert.Len(t, bin.Blocks, 1) && assert.Len(t, bin.Blocks[0].Txs, 1) {
+		parsed, err := tx.ParseTransaction(bin.Blocks[0].Txs[0].Blob)
+		if assert.NoError(t, err) {
+			h, _ := parsed.Hash()
+			assert.Equal(t, hash, h.String())
+		}
+	}
+	oindexes, err := client.GetOIndexesBin(hash)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []uint64{2}, oindexes.OIndexes)
+	}
+	outs, err := client.GetOutsBin([]daemon.GetOutputsOut{{Index: 2}}, true)
+	if assert.NoError(t, err) && assert.Len(t, outs.Outs, 1) {
+		assert.Equal(t, daemon.OutKeyBin{Key: out.Key, Mask: out.Commitment, Height: 1, TxId: hash}, outs.Outs[0])
+	}
+
+	/** send_raw_transaction **/
+	res, err := client.SendRawTransaction("abcd", false, true)
+	assert.ErrorIs(t, err, daemon.ErrStatusNotOk)
+	if assert.NotNil(t, res) {
+		assert.True(t, res.InvalidInput)
+	}
+
+	doubleSpend, err := (&mock.Tx{Inputs: []mock.Input{{KeyImage: mockTestKeyHelper(1)}}, Extra: []byte{1}}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = client.SendRawTransaction(hex.EncodeToString(doubleSpend), false, true)
+	assert.ErrorIs(t, err, daemon.ErrStatusNotOk)
+	if assert.NotNil(t, res) {
+		assert.True(t, res.DoubleSpend)
+	}
+
+	blob, err := (&mock.Tx{Inputs: []mock.Input{{KeyImage: mockTestKeyHelper(4)}}}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		// the known tx is accepted again
+		_, err = client.SendRawTransaction(hex.EncodeToString(blob), false, true)
+		assert.NoError(t, err)
+	}
+	poolHashes, err := client.GetTransactionPoolHashes()
+	if assert.NoError(t, err) {
+		assert.Equal(t, d.Pool(), poolHashes.TxHashes)
+		assert.Len(t, poolHashes.TxHashes, 1)
+	}
+
+	/** the txs of the popped blocks return to the pool **/
+	assert.NoError(t, d.PopBlocks(1))
+	_, err = client.GetBlockHeaderByHash(false, blocks[0])
+	assert.ErrorIs(t, err, daemon.ErrBlockNotFound)
+	assert.Equal(t, []string{hash, poolHashes.TxHashes[0]}, d.Pool())
+	txs, err = client.GetTransactions([]string{hash}, false, false, false)
+	if assert.NoError(t, err) && assert.Len(t, txs.Txs, 1) {
+		assert.True(t, txs.Txs[0].InPool)
+		assert.Empty(t, txs.Txs[0].OutputIndices)
+	}
+}
+
+func TestMockDaemonScanner(t *testing.T) {
+	d, server, client := getMockTestServer(t, mock.Config{})
+	defer server.Close()
+
+	hash, err := d.AddToPool(mock.Tx{
+		Outputs: []mock.Output{
+			{Key: scannerTestOtherKey, ViewTag: "1b", EncryptedAmount: "8b2fe303da838a84"},
+			{Key: scannerTestOwnedKey, ViewTag: "1a", EncryptedAmount: "5db33f80fd4990bc"},
+		},
+		Extra: append(scannerTestExtra(t), append([]byte{0x02, 0x09, 0x01}, decodeHexHelper(t, scannerTestEncryptedPaymentID)...)...),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks, err := d.GenerateBlocks(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := scanner.NewScanner(client, createTestScannerKeys(t), []scanner.SubaddressIndex{{Major: 0, Minor: 0}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs := make(chan scanner.OwnedOutput, 10)
+	if err := s.Scan(context.Background(), outputs); err != nil {
+		t.Fatal(err)
+	}
+	close(outputs)
+
+	var actual []scanner.OwnedOutput
+	for v := range outputs {
+		actual = append(actual, v)
+	}
+
+	expected := []scanner.OwnedOutput{
+		{
+			TxHash:      hash,
+			TxPubKey:    scannerTestTxPub,
+			BlockHash:   blocks[0],
+			Height:      1,
+			OutputIndex: 1,
+			// after the outputs of the miner txs of the genesis block and the block 1
+			GlobalIndex: 3,
+			PublicKey:   scannerTestOwnedKey,
+			Amount:      utils.Float64ToXMR(0.55),
+			PaymentID:   "1122334455667788",
+		},
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, scanner.Checkpoint{Height: 3, Hash: blocks[1]}, s.Checkpoint())
+}
+
+func TestMockDaemonFailures(t *testing.T) {
+	d, server, client := getMockTestServer(t, mock.Config{})
+	defer server.Close()
+
+	d.Fail("get_block_count", mock.Failure{HttpStatus: http.StatusInternalServerError, Times: 2})
+	client.SetRetryPolicy(newTestRetryPolicy())
+	_, err := client.GetBlockCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, d.Calls("get_block_count"))
+	client.SetRetryPolicy(nil)
+
+	d.Fail("get_last_block_header", mock.Failure{Code: daemon.RPC_ERROR_CODE_CORE_BUSY, Message: "Core is busy", Times: 1})
+	_, err = client.GetLastBlockHeader(false)
+	assert.ErrorIs(t, err, daemon.ErrDaemonBusy)
+	_, err = client.GetLastBlockHeader(false)
+	assert.NoError(t, err)
+
+	d.Fail("get_height", mock.Failure{Drop: true, Times: 1})
+	_, err = client.GetCurrentHeight()
+	assert.Error(t, err)
+	_, err = client.GetCurrentHeight()
+	assert.NoError(t, err)
+
+	genesis, _ := d.Block(0)
+	d.Fail("get_hashes.bin", mock.Failure{Status: daemon.RPC_STATUS_BUSY})
+	_, err = client.GetHashesBin([]string{genesis.Hash}, 0)
+	assert.ErrorIs(t, err, daemon.ErrDaemonBusy)
+
+	// all the methods
+	d.Fail("", mock.Failure{HttpStatus: http.StatusServiceUnavailable})
+	_, err = client.GetInfo()
+	var statusErr *utils.HttpStatusError
+	assert.ErrorAs(t, err, &statusErr)
+	d.ClearFailures()
+	_, err = client.GetHashesBin([]string{genesis.Hash}, 0)
+	assert.NoError(t, err)
+
+	/** latency **/
+	d.SetLatency(200 * time.Millisecond)
+	slow, err := createTestDaemonRpcClientWithConfig(t, server.URL, daemon.DaemonRpcClientConfig{Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = slow.GetBlockCount()
+	var netErr net.Error
+	if assert.ErrorAs(t, err, &netErr) {
+		assert.True(t, netErr.Timeout())
+	}
+}
+
+func TestMockDaemonDigestAuth(t *testing.T) {
+	d := mock.New(mock.Config{Username: "user", Password: "pass"})
+	server := httptest.NewServer(d)
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := daemon.NewDaemonRpcClient(daemon.NewRpcConnection(u, "user", "pass"))
+	for i := 0; i < 2; i++ {
+		// the second call reuses the challenge
+		_, err = client.GetBlockCount()
+		assert.NoError(t, err)
+	}
+	genesis, _ := d.Block(0)
+	_, err = client.GetHashesBin([]string{genesis.Hash}, 0)
+	assert.NoError(t, err)
+
+	client = daemon.NewDaemonRpcClient(daemon.NewRpcConnection(u, "user", "wrong"))
+	_, err = client.GetBlockCount()
+	assert.ErrorIs(t, err, daemon.ErrUnauthorized)
+
+	client = daemon.NewDaemonRpcClient(daemon.NewRpcConnection(u, "", ""))
+	_, err = client.GetBlockCount()
+	assert.ErrorIs(t, err, daemon.ErrUnauthorized)
+}
+
+func TestMockDaemonBatch(t *testing.T) {
+	for _, batch := range []bool{true, false} {
+		d, server, _ := getMockTestServer(t, mock.Config{Batch: batch})
+		client := createTestDaemonRpcBatchClient(t, server.URL)
+
+		hashes, err := d.GenerateBlocks(2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the client falls back to the single calls if the batches aren't supported
+		b := client.NewBatch()
+		found := b.GetBlockHeaderByHash(false, hashes[1])
+		missing := b.GetBlockHeaderByHash(false, mockTestKeyHelper(1))
+		if err := b.Execute(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		res, err := found.Result()
+		if assert.NoError(t, err) {
+			assert.Equal(t, uint64(2), res.Result.BlockHeader.Height)
+		}
+		_, err = missing.Result()
+		assert.ErrorIs(t, err, daemon.ErrBlockNotFound)
+
+		server.Close()
+	}
+}
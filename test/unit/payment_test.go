@@ -0,0 +1,448 @@
+package test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/daemon/epee"
+	"github.com/chekist32/go-monero/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	paymentTestCost           uint64 = 10
+	paymentTestDiff           uint64 = 4
+	paymentTestCreditsPerHash uint64 = 50
+	paymentTestCookie         uint32 = 7
+)
+
+// A daemon that charges paymentTestCost credits for get_info and get_transactions and accepts the nonces of its hashing blob
+type paymentTestDaemon struct {
+	t       *testing.T
+	mu      sync.Mutex
+	blob    []byte
+	credits map[string]uint64
+	lastTs  map[string]time.Time
+	nonces  map[uint32]bool
+	// the methods the daemon got the signature with
+	signed []string
+	// on_get_block_hash is charged for as well
+	chargeBlockHash bool
+}
+
+func newPaymentTestDaemon(t *testing.T) *paymentTestDaemon {
+	// major and minor versions, timestamp, prev id, nonce, merkle root, tx count
+	blob := append([]byte{16, 16, 1}, make([]byte, 32+4+32+1)...)
+	return &paymentTestDaemon{t: t, blob: blob, credits: map[string]uint64{}, lastTs: map[string]time.Time{}, nonces: map[uint32]bool{}}
+}
+
+func paymentTestHash(blob []byte, info *daemon.RpcAccessInfoResult) ([]byte, error) {
+	return utils.Keccak256Hash(blob)
+}
+
+// Returns the account of the client or the error code
+func (d *paymentTestDaemon) clientHelper(method string, client string) (string, int32) {
+	if client == "" {
+		return "", 0
+	}
+
+	pub, ts, err := daemon.VerifyRpcPaymentSignature(client)
+	if err != nil {
+		return "", daemon.RPC_ERROR_CODE_INVALID_CLIENT
+	}
+	if !ts.After(d.lastTs[pub]) || time.Since(ts).Abs() > daemon.RPC_PAYMENT_TIMESTAMP_LEEWAY {
+		return "", daemon.RPC_ERROR_CODE_STALE_PAYMENT
+	}
+	d.lastTs[pub] = ts
+	d.signed = append(d.signed, method)
+
+	return pub, 0
+}
+
+func (d *paymentTestDaemon) chargeHelper(pub string) (map[string]any, bool) {
+	if pub == "" || d.credits[pub] < paymentTestCost {
+		return map[string]any{"status": daemon.RPC_STATUS_PAYMENT_REQUIRED, "credits": d.credits[pub]}, false
+	}
+
+	d.credits[pub] -= paymentTestCost
+	return map[string]any{"status": daemon.RPC_STATUS_OK, "credits": d.credits[pub]}, true
+}
+
+func (d *paymentTestDaemon) jsonRpcHelper(body []byte) any {
+	var req struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		d.t.Error(err)
+	}
+
+	var params struct {
+		Client       string `json:"client"`
+		Nonce        uint32 `json:"nonce"`
+		Cookie       uint32 `json:"cookie"`
+		PayingFor    string `json:"paying_for"`
+		Payment      uint64 `json:"payment"`
+		DeltaBalance int64  `json:"delta_balance"`
+	}
+	if strings.HasPrefix(string(req.Params), "{") {
+		json.Unmarshal(req.Params, &params)
+	}
+
+	pub, code := d.clientHelper(req.Method, params.Client)
+	if code == 0 && pub == "" && strings.HasPrefix(req.Method, "rpc_access_") {
+		code = daemon.RPC_ERROR_CODE_INVALID_CLIENT
+	}
+	if code != 0 {
+		return map[string]any{"error": map[string]any{"code": code, "message": "payment error"}}
+	}
+
+	var result map[string]any
+	switch req.Method {
+	case "rpc_access_info":
+		result = map[string]any{
+			"credits": d.credits[pub], "top_hash": "top", "hashing_blob": hex.EncodeToString(d.blob), "seed_hash": "seed",
+			"cookie": paymentTestCookie, "diff": paymentTestDiff, "credits_per_hash_found": paymentTestCreditsPerHash, "height": 3200000, "status": "OK",
+		}
+	case "rpc_access_submit_nonce":
+		blob := append([]byte(nil), d.blob...)
+		binary.LittleEndian.PutUint32(blob[35:], params.Nonce)
+		hash, _ := utils.Keccak256Hash(blob)
+		switch {
+		case params.Cookie != paymentTestCookie:
+			code = daemon.RPC_ERROR_CODE_STALE_PAYMENT
+		case d.nonces[params.Nonce]:
+			code = daemon.RPC_ERROR_CODE_DUPLICATE_PAYMENT
+		case !daemon.CheckPowHash(hash, paymentTestDiff):
+			code = daemon.RPC_ERROR_CODE_PAYMENT_TOO_LOW
+		default:
+			d.nonces[params.Nonce] = true
+			d.credits[pub] += paymentTestCreditsPerHash
+		}
+		result = map[string]any{"credits": d.credits[pub], "top_hash": "top", "status": "OK"}
+	case "rpc_access_pay":
+		if d.credits[pub] < params.Payment {
+			code = daemon.RPC_ERROR_CODE_PAYMENT_TOO_LOW
+		} else {
+			d.credits[pub] -= params.Payment
+		}
+		result = map[string]any{"credits": d.credits[pub], "top_hash": "top", "status": "OK"}
+	case "rpc_access_account":
+		d.credits[pub] = uint64(int64(d.credits[pub]) + params.DeltaBalance)
+		result = map[string]any{"credits": d.credits[pub], "status": "OK"}
+	case "get_info":
+		result, _ = d.chargeHelper(pub)
+		result["height"] = 3200000
+	case "on_get_block_hash":
+		if d.chargeBlockHash {
+			if _, ok := d.chargeHelper(pub); !ok {
+				return map[string]any{"error": map[string]any{"code": daemon.RPC_ERROR_CODE_PAYMENT_REQUIRED, "message": "Payment required"}}
+			}
+		}
+		return map[string]any{"result": "e22cf75f39ae720e8b71b3d120a5ac03f0db50bba6379e2850975b4859190bc6"}
+	}
+	if code != 0 {
+		return map[string]any{"error": map[string]any{"code": code, "message": "payment error"}}
+	}
+
+	return map[string]any{"result": result}
+}
+
+func (d *paymentTestDaemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		d.t.Error(err)
+	}
+
+	switch r.URL.Path {
+	case daemon.DEFAULT_MONERO_RPC_ENDPOINT:
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err == nil {
+			res := make([]any, len(batch))
+			for i, v := range batch {
+				var header struct {
+					Id string `json:"id"`
+				}
+				json.Unmarshal(v, &header)
+
+				r := d.jsonRpcHelper(v).(map[string]any)
+				r["id"] = header.Id
+				res[i] = r
+			}
+			json.NewEncoder(w).Encode(res)
+			return
+		}
+		json.NewEncoder(w).Encode(d.jsonRpcHelper(body))
+	case "/get_transactions":
+		var req struct {
+			Client string `json:"client"`
+		}
+		json.Unmarshal(body, &req)
+		pub, _ := d.clientHelper("get_transactions", req.Client)
+		res, _ := d.chargeHelper(pub)
+		json.NewEncoder(w).Encode(res)
+	case "/get_o_indexes.bin":
+		req, err := epee.Parse(body)
+		if err != nil {
+			d.t.Error(err)
+		}
+		client, _ := req["client"].([]byte)
+		d.clientHelper("get_o_indexes.bin", string(client))
+
+		data, err := epee.Marshal(&daemon.GetOIndexesBinResponse{OIndexes: []uint64{1, 2}, BinRpcFooter: daemon.BinRpcFooter{Status: daemon.RPC_STATUS_OK}})
+		if err != nil {
+			d.t.Error(err)
+		}
+		w.Write(data)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestRpcPaymentSignature(t *testing.T) {
+	key, err := utils.NewRandomPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.UnixMicro(time.Now().UnixMicro())
+	client, err := daemon.MakeRpcPaymentSignature(key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, client, daemon.RPC_PAYMENT_CLIENT_SIZE)
+
+	pub, ts, err := daemon.VerifyRpcPaymentSignature(client)
+	if assert.NoError(t, err) {
+		assert.Equal(t, hex.EncodeToString(utils.GetPublicKeyFromPrivate(key).Bytes()), pub)
+		assert.True(t, now.Equal(ts))
+	}
+
+	// another timestamp with the same signature
+	forged := client[:64] + "0000000000000001" + client[80:]
+	_, _, err = daemon.VerifyRpcPaymentSignature(forged)
+	assert.ErrorIs(t, err, daemon.ErrInvalidClient)
+
+	_, _, err = daemon.VerifyRpcPaymentSignature(client[:100])
+	assert.ErrorIs(t, err, daemon.ErrInvalidClient)
+}
+
+func TestRpcPaymentClient(t *testing.T) {
+	d := newPaymentTestDaemon(t)
+	server := httptest.NewServer(d)
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	topUps := 0
+	miner := &daemon.RpcPaymentMiner{Hash: paymentTestHash, TargetCredits: 100}
+	client, err := daemon.NewRpcPaymentClient(daemon.NewRpcConnection(u, "", ""), daemon.DaemonRpcClientConfig{}, daemon.RpcPaymentConfig{
+		Funder: daemon.RpcPaymentFunderFunc(func(ctx context.Context, c daemon.IDaemonRpcClient, info *daemon.RpcAccessInfoResult) error {
+			topUps++
+			assert.Equal(t, paymentTestCookie, info.Cookie)
+			return miner.Fund(ctx, c, info)
+		}),
+		MinCredits: 20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := hex.EncodeToString(client.PublicKey().Bytes())
+
+	// the daemon asks for the payment, the credits are mined and the request is repeated
+	res, err := client.GetInfo()
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(3200000), res.Result.Height)
+		assert.Equal(t, uint64(90), res.Result.Credits)
+	}
+	assert.Equal(t, 1, topUps)
+	assert.Equal(t, uint64(90), client.Credits())
+
+	for i := 0; i < 7; i++ {
+		_, err := client.GetTransactions([]string{"aa"}, false, false, false)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, uint64(20), client.Credits())
+	assert.Equal(t, 1, topUps)
+
+	// the balance drops below MinCredits and is topped up before the next request
+	_, err = client.GetInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, topUps)
+	_, err = client.GetInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, topUps)
+	assert.Equal(t, uint64(100), client.Credits())
+	assert.Equal(t, uint64(100), d.credits[pub])
+
+	// the positional params can't carry the signature
+	d.signed = nil
+	_, err = client.OnGetBlockHash(3200000)
+	assert.NoError(t, err)
+	indexes, err := client.GetOIndexesBin("b6aa4e2e5e1e9d3ffbc5b3ab1bb5e3f6e8ad6fd0f7bbc3d38b2ba14d7d1d4fa2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []uint64{1, 2}, indexes.OIndexes)
+	}
+	assert.Equal(t, []string{"get_o_indexes.bin"}, d.signed)
+
+	pay, err := client.RpcAccessPay("service", 30)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(70), pay.Result.Credits)
+	}
+	account, err := client.RpcAccessAccount(-20)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(50), account.Result.Credits)
+	}
+	_, err = client.RpcAccessSubmitNonce(1, paymentTestCookie+1)
+	assert.ErrorIs(t, err, daemon.ErrStalePayment)
+
+	// the requests are signed, but nothing pays for them
+	unfunded, err := daemon.NewRpcPaymentClient(daemon.NewRpcConnection(u, "", ""), daemon.DaemonRpcClientConfig{}, daemon.RpcPaymentConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = unfunded.GetInfo()
+	if assert.NoError(t, err) {
+		assert.Equal(t, daemon.RPC_STATUS_PAYMENT_REQUIRED, res.Result.Status)
+	}
+	assert.ErrorIs(t, unfunded.TopUp(context.Background()), daemon.ErrNoRpcPaymentFunder)
+
+	_, err = daemon.NewDaemonRpcClient(daemon.NewRpcConnection(u, "", "")).RpcAccessInfo()
+	assert.ErrorIs(t, err, daemon.ErrNoRpcPaymentKey)
+}
+
+func TestRpcPaymentBatch(t *testing.T) {
+	d := newPaymentTestDaemon(t)
+	server := httptest.NewServer(d)
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	topUps := 0
+	miner := &daemon.RpcPaymentMiner{Hash: paymentTestHash, TargetCredits: 100}
+	client, err := daemon.NewRpcPaymentClient(daemon.NewRpcConnection(u, "", ""), daemon.DaemonRpcClientConfig{}, daemon.RpcPaymentConfig{
+		Funder: daemon.RpcPaymentFunderFunc(func(ctx context.Context, c daemon.IDaemonRpcClient, info *daemon.RpcAccessInfoResult) error {
+			topUps++
+			return miner.Fund(ctx, c, info)
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// both calls get PAYMENT REQUIRED, the credits are mined and the calls are sent again
+	b := client.NewBatch()
+	c1 := b.GetInfo()
+	c2 := b.GetInfo()
+	if err := b.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []*daemon.BatchCall[daemon.GetInfoResult]{c1, c2} {
+		res, err := v.Result()
+		if assert.NoError(t, err) {
+			assert.Equal(t, daemon.RPC_STATUS_OK, res.Result.Status)
+			assert.Equal(t, uint64(3200000), res.Result.Height)
+		}
+	}
+	assert.Equal(t, 1, topUps)
+	assert.Equal(t, uint64(80), client.Credits())
+
+	// the unpaid and the paid attempts are all signed
+	signed := 0
+	for _, v := range d.signed {
+		if v == "get_info" {
+			signed++
+		}
+	}
+	assert.Equal(t, 4, signed)
+}
+
+func TestRpcPaymentUnsignable(t *testing.T) {
+	d := newPaymentTestDaemon(t)
+	d.chargeBlockHash = true
+	server := httptest.NewServer(d)
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	topUps := 0
+	client, err := daemon.NewRpcPaymentClient(daemon.NewRpcConnection(u, "", ""), daemon.DaemonRpcClientConfig{}, daemon.RpcPaymentConfig{
+		Funder: daemon.RpcPaymentFunderFunc(func(ctx context.Context, c daemon.IDaemonRpcClient, info *daemon.RpcAccessInfoResult) error {
+			topUps++
+			return nil
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the positional params can't carry the signature, so the credits wouldn't help
+	_, err = client.OnGetBlockHash(3200000)
+	assert.ErrorIs(t, err, daemon.ErrPaymentRequired)
+
+	b := client.NewBatch()
+	c1 := b.OnGetBlockHash(3200000)
+	c2 := b.OnGetBlockHash(3200001)
+	if err := b.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []*daemon.BatchCall[daemon.OnGetBlockHashResult]{c1, c2} {
+		_, err := v.Result()
+		assert.ErrorIs(t, err, daemon.ErrPaymentRequired)
+	}
+
+	assert.Equal(t, 0, topUps)
+	assert.Empty(t, d.signed)
+}
+
+func TestRpcPaymentMiner(t *testing.T) {
+	d := newPaymentTestDaemon(t)
+	server := httptest.NewServer(d)
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	client, err := daemon.NewRpcPaymentClient(daemon.NewRpcConnection(u, "", ""), daemon.DaemonRpcClientConfig{}, daemon.RpcPaymentConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := client.RpcAccessInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no hash of the difficulty among the tried ones
+	miner := &daemon.RpcPaymentMiner{Hash: func(blob []byte, info *daemon.RpcAccessInfoResult) ([]byte, error) {
+		hash := make([]byte, 32)
+		hash[31] = 0xff
+		return hash, nil
+	}, MaxHashes: 10}
+	assert.ErrorIs(t, miner.Fund(context.Background(), client, &info.Result), daemon.ErrRpcPaymentNotFunded)
+
+	miner = &daemon.RpcPaymentMiner{Hash: paymentTestHash, TargetCredits: 200}
+	assert.NoError(t, miner.Fund(context.Background(), client, &info.Result))
+	assert.Equal(t, uint64(200), client.Credits())
+
+	info.Result.HashingBlob = "1010"
+	assert.ErrorIs(t, miner.Fund(context.Background(), client, &info.Result), daemon.ErrInvalidHashingBlob)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	info.Result.HashingBlob = hex.EncodeToString(d.blob)
+	assert.ErrorIs(t, miner.Fund(ctx, client, &info.Result), context.Canceled)
+
+	hash := make([]byte, 32)
+	hash[31] = 0x3f
+	assert.True(t, daemon.CheckPowHash(hash, 4))
+	assert.False(t, daemon.CheckPowHash(hash, 5))
+}
@@ -0,0 +1,265 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type poolTestNode struct {
+	server       *httptest.Server
+	height       uint64
+	synchronized bool
+	nettype      string
+	delay        time.Duration
+	txStatus     string
+	// the HTTP status of get_height, 200 if 0
+	httpStatus int
+	calls      atomic.Int32
+}
+
+func newPoolTestNode(height uint64) *poolTestNode {
+	n := &poolTestNode{height: height, synchronized: true, nettype: "mainnet", txStatus: "OK"}
+	n.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(n.delay)
+
+		switch r.URL.Path {
+		case "/json_rpc":
+			fmt.Fprintf(w, `{"id": "0", "jsonrpc": "2.0", "result": {"height": %d, "synchronized": %t, "nettype": "%s", "status": "OK"}}`, n.height, n.synchronized, n.nettype)
+		case "/get_height":
+			n.calls.Add(1)
+			if n.httpStatus != 0 {
+				w.WriteHeader(n.httpStatus)
+				return
+			}
+			fmt.Fprintf(w, `{"hash": "", "height": %d, "status": "OK", "untrusted": false}`, n.height)
+		case "/send_raw_transaction":
+			n.calls.Add(1)
+			fmt.Fprintf(w, `{"status": "%s", "untrusted": false}`, n.txStatus)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return n
+}
+
+func createTestDaemonRpcPool(t *testing.T, config daemon.PoolConfig, nodes ...*poolTestNode) *daemon.DaemonRpcPool {
+	connections := make([]*daemon.RpcConnection, len(nodes))
+	for i, v := range nodes {
+		u, err := url.Parse(v.server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		connections[i] = daemon.NewRpcConnection(u, "", "")
+	}
+
+	pool, err := daemon.NewDaemonRpcPool(connections, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pool
+}
+
+func TestPoolFailover(t *testing.T) {
+	down := newPoolTestNode(100)
+	down.server.Close()
+	up := newPoolTestNode(101)
+	defer up.server.Close()
+
+	var pool daemon.IDaemonRpcClient = createTestDaemonRpcPool(t, daemon.PoolConfig{}, down, up)
+
+	res, err := pool.GetCurrentHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(101), res.Height)
+
+	status := pool.(*daemon.DaemonRpcPool).Status()
+	assert.Equal(t, daemon.PoolNodeUnavailable, status[0].State)
+	assert.Error(t, status[0].Err)
+	assert.Equal(t, daemon.PoolNodeUnknown, status[1].State)
+
+	// the unavailable node is tried last now
+	_, err = pool.GetCurrentHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), up.calls.Load())
+}
+
+func TestPoolNoFailoverOfNonIdempotentCalls(t *testing.T) {
+	// the node times out, though it might have relayed the tx
+	slow := newPoolTestNode(100)
+	slow.delay = 200 * time.Millisecond
+	defer slow.server.Close()
+	up := newPoolTestNode(100)
+	defer up.server.Close()
+
+	pool := createTestDaemonRpcPool(t, daemon.PoolConfig{Client: daemon.DaemonRpcClientConfig{Timeout: 50 * time.Millisecond}}, slow, up)
+
+	_, err := pool.SendRawTransaction("aa", false, false)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, daemon.ErrAllNodesFailed)
+	assert.Equal(t, int32(0), up.calls.Load())
+	assert.Equal(t, daemon.PoolNodeUnavailable, pool.Status()[0].State)
+
+	// the idempotent calls still fail over
+	_, err = pool.GetCurrentHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), up.calls.Load())
+}
+
+func TestPoolAllNodesFailed(t *testing.T) {
+	n1 := newPoolTestNode(100)
+	n1.server.Close()
+	n2 := newPoolTestNode(100)
+	n2.server.Close()
+
+	pool := createTestDaemonRpcPool(t, daemon.PoolConfig{}, n1, n2)
+
+	_, err := pool.GetCurrentHeight()
+	assert.ErrorIs(t, err, daemon.ErrAllNodesFailed)
+
+	_, err = daemon.NewDaemonRpcPool(nil, daemon.PoolConfig{})
+	assert.ErrorIs(t, err, daemon.ErrEmptyPool)
+}
+
+func TestPoolNoFailoverOnDaemonError(t *testing.T) {
+	n1 := newPoolTestNode(100)
+	defer n1.server.Close()
+	n1.txStatus = "Failed"
+	n2 := newPoolTestNode(100)
+	defer n2.server.Close()
+
+	pool := createTestDaemonRpcPool(t, daemon.PoolConfig{}, n1, n2)
+
+	res, err := pool.SendRawTransaction("00", false, true)
+	var statusErr *daemon.RpcStatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, "Failed", statusErr.Status)
+	assert.NotNil(t, res)
+	assert.Equal(t, int32(1), n1.calls.Load())
+	assert.Equal(t, int32(0), n2.calls.Load())
+
+	// a busy node is skipped though
+	n1.txStatus = daemon.RPC_STATUS_BUSY
+	_, err = pool.SendRawTransaction("00", false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), n2.calls.Load())
+}
+
+func TestPoolNoFailoverOnClientHttpError(t *testing.T) {
+	n1 := newPoolTestNode(100)
+	defer n1.server.Close()
+	n1.httpStatus = http.StatusUnauthorized
+	n2 := newPoolTestNode(101)
+	defer n2.server.Close()
+
+	pool := createTestDaemonRpcPool(t, daemon.PoolConfig{}, n1, n2)
+
+	// wrong credentials aren't hidden behind the other nodes
+	_, err := pool.GetCurrentHeight()
+	var statusErr *utils.HttpStatusError
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+	}
+	assert.Equal(t, int32(0), n2.calls.Load())
+	assert.NotEqual(t, daemon.PoolNodeUnavailable, pool.Status()[0].State)
+
+	// the server errors are failed over
+	n1.httpStatus = http.StatusServiceUnavailable
+	res, err := pool.GetCurrentHeight()
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(101), res.Height)
+	}
+	assert.Equal(t, daemon.PoolNodeUnavailable, pool.Status()[0].State)
+}
+
+func TestPoolCheckHealth(t *testing.T) {
+	best := newPoolTestNode(1000)
+	defer best.server.Close()
+	lagging := newPoolTestNode(990)
+	defer lagging.server.Close()
+	almost := newPoolTestNode(999)
+	defer almost.server.Close()
+	syncing := newPoolTestNode(1200)
+	defer syncing.server.Close()
+	syncing.synchronized = false
+	stagenet := newPoolTestNode(1500)
+	defer stagenet.server.Close()
+	stagenet.nettype = "stagenet"
+
+	pool := createTestDaemonRpcPool(t, daemon.PoolConfig{Nettype: "mainnet"}, lagging, syncing, stagenet, best, almost)
+	pool.CheckHealth(context.Background())
+
+	status := pool.Status()
+	assert.Equal(t, daemon.PoolNodeLagging, status[0].State)
+	assert.ErrorIs(t, status[0].Err, daemon.ErrNodeLagging)
+	assert.Equal(t, daemon.PoolNodeUnavailable, status[1].State)
+	assert.ErrorIs(t, status[1].Err, daemon.ErrNotSynchronized)
+	assert.Equal(t, daemon.PoolNodeUnavailable, status[2].State)
+	assert.ErrorIs(t, status[2].Err, daemon.ErrNettypeMismatch)
+	assert.Equal(t, daemon.PoolNodeHealthy, status[3].State)
+	assert.Equal(t, uint64(1000), status[3].Height)
+	assert.Equal(t, daemon.PoolNodeHealthy, status[4].State)
+
+	res, err := pool.GetCurrentHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(1000), res.Height)
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+	n1 := newPoolTestNode(100)
+	defer n1.server.Close()
+	n2 := newPoolTestNode(100)
+	defer n2.server.Close()
+	n3 := newPoolTestNode(90)
+	defer n3.server.Close()
+
+	pool := createTestDaemonRpcPool(t, daemon.PoolConfig{Strategy: daemon.PoolStrategyRoundRobin}, n1, n2, n3)
+	pool.CheckHealth(context.Background())
+
+	for i := 0; i < 4; i++ {
+		if _, err := pool.GetCurrentHeight(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Equal(t, int32(2), n1.calls.Load())
+	assert.Equal(t, int32(2), n2.calls.Load())
+	assert.Equal(t, int32(0), n3.calls.Load())
+}
+
+func TestPoolLowestLatency(t *testing.T) {
+	slow := newPoolTestNode(100)
+	defer slow.server.Close()
+	slow.delay = 50 * time.Millisecond
+	fast := newPoolTestNode(100)
+	defer fast.server.Close()
+
+	pool := createTestDaemonRpcPool(t, daemon.PoolConfig{Strategy: daemon.PoolStrategyLowestLatency}, slow, fast)
+	pool.CheckHealth(context.Background())
+
+	status := pool.Status()
+	assert.Greater(t, status[0].Latency, status[1].Latency)
+
+	for i := 0; i < 3; i++ {
+		if _, err := pool.GetCurrentHeight(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.Equal(t, int32(0), slow.calls.Load())
+	assert.Equal(t, int32(3), fast.calls.Load())
+}
@@ -0,0 +1,276 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/stretchr/testify/assert"
+)
+
+// Answers the compared calls only, the rest of IDaemonRpcClient panics
+type quorumTestClient struct {
+	daemon.IDaemonRpcClient
+	height      uint64
+	blockHash   string
+	txHeight    uint64
+	txInPool    bool
+	untrusted   bool
+	err         error
+	headerCalls int
+	// the answer waits until after is closed or the call is canceled
+	after chan struct{}
+	// closed once the node has answered
+	answered chan struct{}
+}
+
+func (c *quorumTestClient) waitHelper(ctx context.Context) error {
+	if c.after == nil {
+		return nil
+	}
+
+	select {
+	case <-c.after:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *quorumTestClient) answeredHelper() {
+	if c.answered != nil {
+		close(c.answered)
+	}
+}
+
+func (c *quorumTestClient) GetCurrentHeightCtx(ctx context.Context) (*daemon.GetHeightResponse, error) {
+	defer c.answeredHelper()
+	if err := c.waitHelper(ctx); err != nil {
+		return nil, err
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &daemon.GetHeightResponse{Height: c.height, JsonRpcFooter: daemon.JsonRpcFooter{Status: "OK", Untrusted: c.untrusted}}, nil
+}
+
+func (c *quorumTestClient) GetBlockHeaderByHeightCtx(ctx context.Context, fillPowHash bool, height uint64) (*daemon.JsonRpcGenericResponse[daemon.GetBlockHeaderResult], error) {
+	c.headerCalls++
+	defer c.answeredHelper()
+	if err := c.waitHelper(ctx); err != nil {
+		return nil, err
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	res := &daemon.JsonRpcGenericResponse[daemon.GetBlockHeaderResult]{}
+	res.Result.BlockHeader.Hash = c.blockHash
+	res.Result.BlockHeader.Height = height
+	res.Result.Untrusted = c.untrusted
+	return res, nil
+}
+
+func (c *quorumTestClient) GetTransactionsCtx(ctx context.Context, txHashes []string, decodeAsJson bool, prune bool, split bool) (*daemon.GetTransactionsResponse, error) {
+	defer c.answeredHelper()
+	if err := c.waitHelper(ctx); err != nil {
+		return nil, err
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	res := &daemon.GetTransactionsResponse{JsonRpcFooter: daemon.JsonRpcFooter{Status: "OK", Untrusted: c.untrusted}}
+	for _, v := range txHashes {
+		res.Txs = append(res.Txs, daemon.MoneroTx1{TxHash: v, BlockHeight: c.txHeight, InPool: c.txInPool})
+	}
+	return res, nil
+}
+
+func createTestDaemonRpcQuorum(t *testing.T, config daemon.QuorumConfig, clients ...*quorumTestClient) *daemon.DaemonRpcQuorum {
+	res := make([]daemon.IDaemonRpcClient, len(clients))
+	for i, v := range clients {
+		res[i] = v
+	}
+
+	q, err := daemon.NewDaemonRpcQuorum(res, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return q
+}
+
+func TestQuorumBlockHeader(t *testing.T) {
+	// the liar answers first, so the quorum isn't decided without it
+	gate := make(chan struct{})
+	honest1 := &quorumTestClient{blockHash: "aa", after: gate}
+	liar := &quorumTestClient{blockHash: "bb", answered: gate}
+	honest2 := &quorumTestClient{blockHash: "aa", after: gate}
+
+	var report *daemon.QuorumReport
+	q := createTestDaemonRpcQuorum(t, daemon.QuorumConfig{OnDisagreement: func(r *daemon.QuorumReport) { report = r }}, liar, honest1, honest2)
+
+	var client daemon.IDaemonRpcClient = q
+	res, err := client.GetBlockHeaderByHeight(false, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "aa", res.Result.BlockHeader.Hash)
+	assert.Equal(t, 1, liar.headerCalls)
+
+	if assert.NotNil(t, report) {
+		assert.Equal(t, "get_block_header_by_height", report.Method)
+		assert.Equal(t, 2, report.Agreeing)
+		assert.Equal(t, 2, report.Required)
+		assert.Equal(t, daemon.QuorumNodeAnswer{Node: 0, Value: "bb", Agrees: false}, report.Answers[0])
+		assert.Equal(t, daemon.QuorumNodeAnswer{Node: 1, Value: "aa", Agrees: true}, report.Answers[1])
+	}
+}
+
+func TestQuorumNotReached(t *testing.T) {
+	down := errors.New("connection refused")
+	q := createTestDaemonRpcQuorum(t, daemon.QuorumConfig{},
+		&quorumTestClient{blockHash: "aa"},
+		&quorumTestClient{blockHash: "bb"},
+		&quorumTestClient{err: down},
+	)
+
+	_, err := q.GetBlockHeaderByHeight(false, 100)
+	assert.ErrorIs(t, err, daemon.ErrNoQuorum)
+
+	var quorumErr *daemon.QuorumError
+	if assert.True(t, errors.As(err, &quorumErr)) {
+		assert.Equal(t, 1, quorumErr.Report.Agreeing)
+		assert.Equal(t, down, quorumErr.Report.Answers[2].Err)
+	}
+}
+
+func TestQuorumTransactions(t *testing.T) {
+	hashes := []string{"d6e48158472848e6687173a91ae6eebfa3e1d778e65252ee99d7515d63090408"}
+
+	// the first node answers first, the last one can be skipped as soon as the others disagree
+	gate := make(chan struct{})
+	q := createTestDaemonRpcQuorum(t, daemon.QuorumConfig{Threshold: 3},
+		&quorumTestClient{txHeight: 2000, answered: gate},
+		&quorumTestClient{txHeight: 2000, after: gate},
+		&quorumTestClient{txInPool: true, after: gate},
+	)
+	_, err := q.GetTransactions(hashes, false, false, false)
+	var quorumErr *daemon.QuorumError
+	if assert.True(t, errors.As(err, &quorumErr)) {
+		assert.Equal(t, hashes[0]+":pool", quorumErr.Report.Answers[2].Value)
+		assert.Equal(t, hashes[0]+":2000", quorumErr.Report.Answers[0].Value)
+	}
+
+	q = createTestDaemonRpcQuorum(t, daemon.QuorumConfig{Threshold: 2},
+		&quorumTestClient{txHeight: 2000},
+		&quorumTestClient{txHeight: 2000},
+		&quorumTestClient{txInPool: true},
+	)
+	res, err := q.GetTransactions(hashes, false, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(2000), res.Txs[0].BlockHeight)
+}
+
+func TestQuorumHeight(t *testing.T) {
+	q := createTestDaemonRpcQuorum(t, daemon.QuorumConfig{MaxHeightDiff: 1},
+		&quorumTestClient{height: 1000},
+		&quorumTestClient{height: 1001},
+		&quorumTestClient{height: 5000},
+	)
+	res, err := q.GetCurrentHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(1000), res.Height)
+
+	q = createTestDaemonRpcQuorum(t, daemon.QuorumConfig{},
+		&quorumTestClient{height: 1000},
+		&quorumTestClient{height: 1001},
+		&quorumTestClient{height: 5000},
+	)
+	_, err = q.GetCurrentHeight()
+	assert.ErrorIs(t, err, daemon.ErrNoQuorum)
+}
+
+func TestQuorumUntrusted(t *testing.T) {
+	clients := []*quorumTestClient{
+		{height: 1000},
+		{height: 1000, untrusted: true},
+		{height: 1000, untrusted: true},
+	}
+
+	q := createTestDaemonRpcQuorum(t, daemon.QuorumConfig{}, clients...)
+	_, err := q.GetCurrentHeight()
+	var quorumErr *daemon.QuorumError
+	if assert.True(t, errors.As(err, &quorumErr)) {
+		assert.ErrorIs(t, quorumErr.Report.Answers[1].Err, daemon.ErrUntrustedAnswer)
+		assert.True(t, quorumErr.Report.Answers[1].Untrusted)
+		assert.Equal(t, "1000", quorumErr.Report.Answers[1].Value)
+	}
+
+	q = createTestDaemonRpcQuorum(t, daemon.QuorumConfig{AllowUntrusted: true}, clients...)
+	_, err = q.GetCurrentHeight()
+	assert.NoError(t, err)
+}
+
+func TestQuorumStalledNode(t *testing.T) {
+	// never answers unless canceled
+	stalled := func() *quorumTestClient { return &quorumTestClient{blockHash: "aa", after: make(chan struct{})} }
+
+	var report *daemon.QuorumReport
+	q := createTestDaemonRpcQuorum(t, daemon.QuorumConfig{OnDisagreement: func(r *daemon.QuorumReport) { report = r }},
+		stalled(), &quorumTestClient{blockHash: "aa"}, &quorumTestClient{blockHash: "aa"})
+	res, err := q.GetBlockHeaderByHeight(false, 100)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "aa", res.Result.BlockHeader.Hash)
+	}
+	// the skipped node doesn't disagree
+	assert.Nil(t, report)
+
+	// the agreement isn't possible anymore once two nodes disagree
+	q = createTestDaemonRpcQuorum(t, daemon.QuorumConfig{Threshold: 3},
+		stalled(), &quorumTestClient{blockHash: "aa"}, &quorumTestClient{blockHash: "bb"})
+	_, err = q.GetBlockHeaderByHeight(false, 100)
+	var quorumErr *daemon.QuorumError
+	if assert.True(t, errors.As(err, &quorumErr)) {
+		assert.ErrorIs(t, quorumErr.Report.Answers[0].Err, daemon.ErrQuorumNodeSkipped)
+	}
+
+	// the threshold needs the stalled node
+	q = createTestDaemonRpcQuorum(t, daemon.QuorumConfig{Threshold: 2, Timeout: 50 * time.Millisecond},
+		stalled(), &quorumTestClient{blockHash: "aa"})
+	_, err = q.GetBlockHeaderByHeight(false, 100)
+	if assert.True(t, errors.As(err, &quorumErr)) {
+		assert.ErrorIs(t, quorumErr.Report.Answers[0].Err, context.DeadlineExceeded)
+	}
+}
+
+func TestQuorumSetRpcConnection(t *testing.T) {
+	honest1 := &quorumTestClient{blockHash: "aa"}
+	liar := &quorumTestClient{blockHash: "bb"}
+	honest2 := &quorumTestClient{blockHash: "aa"}
+
+	// the first client keeps its connection (the test clients panic on SetRpcConnection)
+	q := createTestDaemonRpcQuorum(t, daemon.QuorumConfig{}, liar, honest1, honest2)
+	q.SetRpcConnection(daemon.NewRpcConnection(&url.URL{Scheme: "http", Host: "127.0.0.1:18081"}, "", ""))
+
+	res, err := q.GetBlockHeaderByHeight(false, 100)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "aa", res.Result.BlockHeader.Hash)
+	}
+}
+
+func TestQuorumConfig(t *testing.T) {
+	_, err := daemon.NewDaemonRpcQuorum(nil, daemon.QuorumConfig{})
+	assert.ErrorIs(t, err, daemon.ErrInvalidQuorumConfig)
+
+	_, err = daemon.NewDaemonRpcQuorum([]daemon.IDaemonRpcClient{&quorumTestClient{}}, daemon.QuorumConfig{Threshold: 2})
+	assert.ErrorIs(t, err, daemon.ErrInvalidQuorumConfig)
+}
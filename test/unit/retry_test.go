@@ -0,0 +1,215 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/utils"
+	"github.com/chekist32/go-monero/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRetryPolicy() *utils.RetryPolicy {
+	return &utils.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2}
+}
+
+// Answers with the failures first and then with the response
+func getFlakyTestServer(calls *atomic.Int32, failures []func(w http.ResponseWriter), res string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		if n <= len(failures) {
+			failures[n-1](w)
+			return
+		}
+		w.Write([]byte(res))
+	}))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &utils.RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 3}
+	assert.Equal(t, 100*time.Millisecond, p.Backoff(1))
+	assert.Equal(t, 300*time.Millisecond, p.Backoff(2))
+	assert.Equal(t, 900*time.Millisecond, p.Backoff(3))
+	assert.Equal(t, time.Second, p.Backoff(4))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.Backoff(2)
+		assert.True(t, d > 150*time.Millisecond && d <= 300*time.Millisecond, d)
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	transient := &utils.HttpStatusError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}
+	permanent := errors.New("permanent")
+
+	calls := 0
+	err := newTestRetryPolicy().Do(context.Background(), "m", true, utils.IsTransientError, func() error {
+		calls++
+		return transient
+	})
+	assert.Equal(t, transient, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = newTestRetryPolicy().Do(context.Background(), "m", true, utils.IsTransientError, func() error {
+		calls++
+		return permanent
+	})
+	assert.Equal(t, permanent, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	err = newTestRetryPolicy().Do(context.Background(), "m", false, utils.IsTransientError, func() error {
+		calls++
+		return transient
+	})
+	assert.Equal(t, transient, err)
+	assert.Equal(t, 1, calls)
+
+	p := newTestRetryPolicy()
+	p.AlsoRetry = []string{"m"}
+	calls = 0
+	p.Do(context.Background(), "m", false, utils.IsTransientError, func() error {
+		calls++
+		return transient
+	})
+	assert.Equal(t, 3, calls)
+
+	var nilPolicy *utils.RetryPolicy
+	calls = 0
+	nilPolicy.Do(context.Background(), "m", true, utils.IsTransientError, func() error {
+		calls++
+		return transient
+	})
+	assert.Equal(t, 1, calls)
+}
+
+func TestDaemonRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := getFlakyTestServer(&calls, []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) },
+		func(w http.ResponseWriter) {
+			w.Write([]byte(`{"hash": "", "height": 0, "status": "BUSY", "untrusted": false}`))
+		},
+	}, `{"hash": "", "height": 2287217, "status": "OK", "untrusted": false}`)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test_daemon.SetRetryPolicy(newTestRetryPolicy())
+
+	res, err := test_daemon.GetCurrentHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(2287217), res.Height)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestDaemonRetryExhausted(t *testing.T) {
+	var calls atomic.Int32
+	busy := func(w http.ResponseWriter) {
+		w.Write([]byte(`{"jsonrpc": "2.0", "id": "0", "error": {"code": -9, "message": "Core is busy"}}`))
+	}
+	server := getFlakyTestServer(&calls, []func(w http.ResponseWriter){busy, busy, busy}, "")
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test_daemon.SetRetryPolicy(newTestRetryPolicy())
+
+	_, err = test_daemon.GetBlockCount()
+	var rpcErr *daemon.MoneroRpcError
+	if assert.True(t, errors.As(err, &rpcErr)) {
+		assert.Equal(t, daemon.RPC_ERROR_CODE_CORE_BUSY, rpcErr.Code)
+	}
+	assert.True(t, daemon.IsRetryableError(err))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestDaemonNoRetryForNonIdempotent(t *testing.T) {
+	var calls atomic.Int32
+	server := getFlakyTestServer(&calls, []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) { w.WriteHeader(http.StatusInternalServerError) },
+	}, `{"jsonrpc": "2.0", "id": "0", "result": {"status": "OK"}}`)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test_daemon.SetRetryPolicy(newTestRetryPolicy())
+
+	_, err = test_daemon.SubmitBlock([]string{"0707e6bdfedc053771512f1bc27c62731ae9e8f2443db64ce742f4e57f5cf8d393de28551e441a0000000002fb830a01ffbf830a018cfe88bee283060274c0aae2ef5730e680308d9c00b6da59187ad0352efe3c71d36eeeb28782f29f2501bd56b952c3ddc3e350c2631d3a5086cac172c56893831228b17de296ff4669de020200000000"})
+	var statusErr *utils.HttpStatusError
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	_, err = test_daemon.RelayTx([]string{"9fd75c429cbe52da9a52f2ffc5fbd107fe7fd2099c0d8de274dc8a67e0c98613"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestWalletRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := getFlakyTestServer(&calls, []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) },
+		func(w http.ResponseWriter) {
+			w.Write([]byte(`{"id": 0, "jsonrpc": "2.0", "error": {"code": -3, "message": "daemon is busy"}}`))
+		},
+	}, `{"id": 0, "jsonrpc": "2.0", "result": {"height": 145545}}`)
+	defer server.Close()
+
+	w := wallet.New(wallet.Config{Address: server.URL, RetryPolicy: newTestRetryPolicy()})
+
+	res, err := w.GetHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uint64(145545), res.Height)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// transfer is never retried automatically
+	calls.Store(0)
+	_, err = w.Transfer(&wallet.RequestTransfer{})
+	assert.Error(t, err)
+	assert.True(t, wallet.IsRetryableError(err))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestWalletRetryCtx(t *testing.T) {
+	var calls atomic.Int32
+	server := getFlakyTestServer(&calls, []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) },
+	}, `{"id": 0, "jsonrpc": "2.0", "result": {"height": 145545}}`)
+	defer server.Close()
+
+	w := wallet.New(wallet.Config{Address: server.URL, RetryPolicy: &utils.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour, Multiplier: 2}})
+
+	// the backoff is cut short by the ctx
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := w.GetHeightCtx(ctx)
+	var statusErr *utils.HttpStatusError
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+		assert.Equal(t, "get_height", statusErr.Method)
+	}
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,365 @@
+package test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/daemon/epee"
+	"github.com/chekist32/go-monero/scanner"
+	"github.com/chekist32/go-monero/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	scannerTestTxPub    = "7302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364"
+	scannerTestViewKey  = "8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009"
+	scannerTestSpendKey = "38e9908d33d034de0ba1281aa7afe3907b795cea14852b3d8fe276e8931cb130"
+	scannerTestOwnedKey = "7e4f4427539b206740bed78b81b0dc10acb89aa1545880863f73264492ee0c16"
+	scannerTestOtherKey = "0993e6ca2d66871e4869adb2c3a524ad7205fcd3e0b3339daafaea76fc5518ee"
+	// 1122334455667788 encrypted with the test tx pub key and view key
+	scannerTestEncryptedPaymentID = "6fdb5dba8289cee2"
+)
+
+type scannerTestBlock struct {
+	hash    string
+	details daemon.BlockDetails
+	txs     []daemon.MoneroTx1
+}
+
+func scannerTestExtra(t *testing.T) []byte {
+	extra, err := hex.DecodeString("01" + scannerTestTxPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return extra
+}
+
+func decodeHexHelper(t *testing.T, str string) []byte {
+	res, err := hex.DecodeString(str)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func scannerTestMinerTx(t *testing.T, owned bool) daemon.MinerTx {
+	extra := scannerTestExtra(t)
+	extraInt := make([]int32, len(extra))
+	for i, v := range extra {
+		extraInt[i] = int32(v)
+	}
+
+	vout := []daemon.Vout1{{Amount: 100, Target: daemon.Target{TaggedKey: daemon.TaggedKey{Key: scannerTestOtherKey, ViewTag: "1b"}}}}
+	if owned {
+		vout = append(vout, daemon.Vout1{Amount: 550000000000, Target: daemon.Target{TaggedKey: daemon.TaggedKey{Key: scannerTestOwnedKey, ViewTag: "1a"}}})
+	}
+
+	return daemon.MinerTx{Version: 2, UnlockTime: 60, Vout: vout, Extra: extraInt}
+}
+
+func scannerTestTx(t *testing.T, hash string) daemon.MoneroTx1 {
+	return daemon.MoneroTx1{
+		TxHash:        hash,
+		OutputIndices: []uint64{1000, 1001},
+		TxInfo: daemon.MoneroTxInfo{
+			Version: 2,
+			Vout: []daemon.Vout1{
+				{Target: daemon.Target{TaggedKey: daemon.TaggedKey{Key: scannerTestOtherKey, ViewTag: "1b"}}},
+				{Target: daemon.Target{TaggedKey: daemon.TaggedKey{Key: scannerTestOwnedKey, ViewTag: "1a"}}},
+			},
+			Extra: append(scannerTestExtra(t), append([]byte{0x02, 0x09, 0x01}, decodeHexHelper(t, scannerTestEncryptedPaymentID)...)...),
+			RctSignatures: daemon.RctSignature{
+				Type:     6,
+				EcdhInfo: []daemon.EcdhInfo{{TruncAmount: "8b2fe303da838a84"}, {TruncAmount: "5db33f80fd4990bc"}},
+			},
+		},
+	}
+}
+
+func scannerTestChain(t *testing.T) []scannerTestBlock {
+	return []scannerTestBlock{
+		{
+			hash:    "0000000000000000000000000000000000000000000000000000000000000001",
+			details: daemon.BlockDetails{MinerTx: scannerTestMinerTx(t, true)},
+		},
+		{
+			hash: "0000000000000000000000000000000000000000000000000000000000000002",
+			details: daemon.BlockDetails{
+				PrevId:   "0000000000000000000000000000000000000000000000000000000000000001",
+				MinerTx:  scannerTestMinerTx(t, false),
+				TxHashes: []string{"d6e48158472848e6687173a91ae6eebfa3e1d778e65252ee99d7515d63090408"},
+			},
+			txs: []daemon.MoneroTx1{scannerTestTx(t, "d6e48158472848e6687173a91ae6eebfa3e1d778e65252ee99d7515d63090408")},
+		},
+		{
+			hash:    "0000000000000000000000000000000000000000000000000000000000000003",
+			details: daemon.BlockDetails{PrevId: "0000000000000000000000000000000000000000000000000000000000000002", MinerTx: scannerTestMinerTx(t, false)},
+		},
+	}
+}
+
+func getScannerTestServer(t *testing.T, chain []scannerTestBlock) *httptest.Server {
+	return getScannerTestServerWithPool(t, chain, nil)
+}
+
+func getScannerTestServerWithPool(t *testing.T, chain []scannerTestBlock, pool func() daemon.GetTransactionPoolResponse) *httptest.Server {
+	write := func(w http.ResponseWriter, v any) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(data)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/get_height":
+			write(w, daemon.GetHeightResponse{Height: uint64(len(chain)), JsonRpcFooter: defaultMoneroRpcFooter})
+
+		case "/get_transactions":
+			var req daemon.GetTransactionsParams
+			if err := json.Unmarshal(data, &req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			res := daemon.GetTransactionsResponse{JsonRpcFooter: defaultMoneroRpcFooter}
+			for _, h := range req.TxHashes {
+				found := false
+				for _, b := range chain {
+					for _, tx := range b.txs {
+						if tx.TxHash != h {
+							continue
+						}
+						txJson, _ := json.Marshal(tx.TxInfo)
+						tx.AsJson = string(txJson)
+						res.Txs = append(res.Txs, tx)
+						found = true
+					}
+				}
+				if !found {
+					res.MissedTx = append(res.MissedTx, h)
+				}
+			}
+			write(w, res)
+
+		case "/get_transaction_pool":
+			if pool == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			res := pool()
+			for i := range res.Transactions {
+				txJson, _ := json.Marshal(res.Transactions[i].TxInfo)
+				res.Transactions[i].TxJson = string(txJson)
+			}
+			res.JsonRpcFooter = defaultMoneroRpcFooter
+			write(w, res)
+
+		case "/get_o_indexes.bin":
+			// the miner txs only, the other txs come with their output indices
+			res, err := epee.Marshal(&daemon.GetOIndexesBinResponse{OIndexes: []uint64{500, 501}, BinRpcFooter: daemon.BinRpcFooter{Status: daemon.RPC_STATUS_OK}})
+			if err != nil {
+				t.Error(err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write(res)
+
+		case daemon.DEFAULT_MONERO_RPC_ENDPOINT:
+			var req daemon.JsonRpcGenericRequestBody[daemon.GetBlockByHeightParams]
+			if err := json.Unmarshal(data, &req); err != nil || req.Method != "get_block" || req.Params.Height >= uint64(len(chain)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			b := chain[req.Params.Height]
+			blockJson, _ := json.Marshal(b.details)
+			res := daemon.JsonRpcGenericResponse[daemon.GetBlockResult]{JsonRpcHeader: defaultMoneroRpcHeader}
+			res.Result.Json = string(blockJson)
+			res.Result.MinerTxHash = fmt.Sprintf("%064x", req.Params.Height+100)
+			res.Result.BlockHeader.Hash = b.hash
+			res.Result.BlockHeader.Height = req.Params.Height
+			write(w, res)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func createTestScannerKeys(t *testing.T) *utils.ViewOnlyKeyPair {
+	viewKey, err := utils.NewPrivateKey(scannerTestViewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendKey, err := utils.NewPublicKey(scannerTestSpendKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return utils.NewViewOnlyKeyPair(viewKey, spendKey)
+}
+
+func TestScannerScan(t *testing.T) {
+	server := getScannerTestServer(t, scannerTestChain(t))
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := scanner.NewScanner(test_daemon, createTestScannerKeys(t), []scanner.SubaddressIndex{{Major: 0, Minor: 0}, {Major: 1, Minor: 0}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs := make(chan scanner.OwnedOutput, 10)
+	if err := s.Scan(context.Background(), outputs); err != nil {
+		t.Fatal(err)
+	}
+	close(outputs)
+
+	var actual []scanner.OwnedOutput
+	for v := range outputs {
+		actual = append(actual, v)
+	}
+
+	expected := []scanner.OwnedOutput{
+		{
+			TxHash:      fmt.Sprintf("%064x", 100),
+			TxPubKey:    scannerTestTxPub,
+			BlockHash:   "0000000000000000000000000000000000000000000000000000000000000001",
+			Height:      0,
+			OutputIndex: 1,
+			GlobalIndex: 501,
+			PublicKey:   scannerTestOwnedKey,
+			Amount:      550000000000,
+			UnlockTime:  60,
+			Coinbase:    true,
+		},
+		{
+			TxHash:      "d6e48158472848e6687173a91ae6eebfa3e1d778e65252ee99d7515d63090408",
+			TxPubKey:    scannerTestTxPub,
+			BlockHash:   "0000000000000000000000000000000000000000000000000000000000000002",
+			Height:      1,
+			OutputIndex: 1,
+			GlobalIndex: 1001,
+			PublicKey:   scannerTestOwnedKey,
+			Amount:      utils.Float64ToXMR(0.55),
+			PaymentID:   "1122334455667788",
+		},
+	}
+
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, scanner.Checkpoint{Height: 3, Hash: "0000000000000000000000000000000000000000000000000000000000000003"}, s.Checkpoint())
+}
+
+func TestScannerScanRctV1(t *testing.T) {
+	// the RCT types before Bulletproofs2 keep the whole amount in the ecdh info
+	chain := scannerTestChain(t)
+	chain[1].txs[0].TxInfo.RctSignatures = daemon.RctSignature{
+		Type: 2,
+		EcdhInfo: []daemon.EcdhInfo{
+			{Mask: "0000000000000000000000000000000000000000000000000000000000000000", Amount: "0000000000000000000000000000000000000000000000000000000000000000"},
+			// 1234567890000 encrypted with the test tx pub key and view key
+			{Mask: "0000000000000000000000000000000000000000000000000000000000000000", Amount: "8b764330e8feff90dd49db451d777e45303182073df17696489d3bf0a32dfd08"},
+		},
+	}
+
+	server := getScannerTestServer(t, chain)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := scanner.NewScannerFromCheckpoint(test_daemon, createTestScannerKeys(t), nil, scanner.Checkpoint{Height: 1, Hash: chain[0].hash})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs := make(chan scanner.OwnedOutput, 10)
+	if err := s.Scan(context.Background(), outputs); err != nil {
+		t.Fatal(err)
+	}
+	close(outputs)
+
+	var amounts []uint64
+	for v := range outputs {
+		amounts = append(amounts, v.Amount)
+	}
+
+	assert.Equal(t, []uint64{1234567890000}, amounts)
+}
+
+func TestScannerResumeFromCheckpoint(t *testing.T) {
+	server := getScannerTestServer(t, scannerTestChain(t))
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint := scanner.Checkpoint{Height: 1, Hash: "0000000000000000000000000000000000000000000000000000000000000001"}
+	s, err := scanner.NewScannerFromCheckpoint(test_daemon, createTestScannerKeys(t), nil, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outputs := make(chan scanner.OwnedOutput, 10)
+	if err := s.Scan(context.Background(), outputs); err != nil {
+		t.Fatal(err)
+	}
+	close(outputs)
+
+	var heights []uint64
+	for v := range outputs {
+		heights = append(heights, v.Height)
+	}
+
+	assert.Equal(t, []uint64{1}, heights)
+	assert.Equal(t, uint64(3), s.Checkpoint().Height)
+}
+
+func TestScannerCheckpointMismatch(t *testing.T) {
+	server := getScannerTestServer(t, scannerTestChain(t))
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint := scanner.Checkpoint{Height: 1, Hash: "00000000000000000000000000000000000000000000000000000000000000ff"}
+	s, err := scanner.NewScannerFromCheckpoint(test_daemon, createTestScannerKeys(t), nil, checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Scan(context.Background(), make(chan scanner.OwnedOutput, 10))
+	assert.ErrorIs(t, err, scanner.ErrCheckpointMismatch)
+	assert.Equal(t, checkpoint, s.Checkpoint())
+}
@@ -0,0 +1,234 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/scanner"
+	"github.com/chekist32/go-monero/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	spentTestPrivSpendKey = "372fcc2abc6bc5015103aae4763822e45c4cfe775d163f97a9ebdd77b0d12c0c"
+	// key image of the owned output of the scanner test chain
+	spentTestKeyImage = "070be2cc1cf03ab7436df4582f66d2648aa80da9c38d32084841b4d896f853c4"
+
+	// The output 1 of the scanner test tx pays to this spend key with the one-time key pair of the generate_key_image vector
+	// from monero's tests/crypto/tests.txt, so its key image is known independently of this code
+	spentTestVectorSpendKey = "2dc73d3f24f87bba7b1abae0ced103f1c075f3f59b1bee5e5acba33f738bbc02"
+	spentTestVectorOutKey   = "e46b60ebfe610b8ba761032018471e5719bb77ea1cd945475c4a4abe7224bfd0"
+	spentTestVectorKeyImage = "a637203ec41eab772532d30420eac80612fce8e44f1758bc7e2cb1bdda815887"
+)
+
+func createTestSpentTrackerKeys(t *testing.T) *utils.FullKeyPair {
+	viewKey, err := utils.NewPrivateKey(scannerTestViewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendKey, err := utils.NewPrivateKey(spentTestPrivSpendKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return utils.NewFullKeyPair(viewKey, spendKey)
+}
+
+// The coinbase output of the block 0 gets spent in the block 2
+func spentTestChain(t *testing.T) []scannerTestBlock {
+	chain := scannerTestChain(t)[:1]
+	chain = append(chain,
+		scannerTestBlock{
+			hash:    "0000000000000000000000000000000000000000000000000000000000000002",
+			details: daemon.BlockDetails{PrevId: chain[0].hash, MinerTx: scannerTestMinerTx(t, false)},
+		},
+		scannerTestBlock{
+			hash: "0000000000000000000000000000000000000000000000000000000000000003",
+			details: daemon.BlockDetails{
+				PrevId:   "0000000000000000000000000000000000000000000000000000000000000002",
+				MinerTx:  scannerTestMinerTx(t, false),
+				TxHashes: []string{"aa00000000000000000000000000000000000000000000000000000000000000"},
+			},
+			txs: []daemon.MoneroTx1{{
+				TxHash: "aa00000000000000000000000000000000000000000000000000000000000000",
+				TxInfo: daemon.MoneroTxInfo{Version: 2, Vin: []daemon.Vin2{{Key: daemon.Key{KeyImage: spentTestKeyImage}}}},
+			}},
+		},
+	)
+
+	return chain
+}
+
+func TestSpentTrackerScan(t *testing.T) {
+	server := getScannerTestServer(t, spentTestChain(t))
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := createTestSpentTrackerKeys(t)
+	tracker := scanner.NewSpentTracker(test_daemon, keys)
+
+	s, err := scanner.NewScanner(test_daemon, keys.ViewOnlyKeyPair(), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.TrackSpent(tracker)
+
+	outputs := make(chan scanner.OwnedOutput, 10)
+	if err := s.Scan(context.Background(), outputs); err != nil {
+		t.Fatal(err)
+	}
+
+	out, ok := tracker.Get(spentTestKeyImage)
+	assert.True(t, ok)
+	assert.Equal(t, scanner.Spent, out.Status)
+	assert.Equal(t, "aa00000000000000000000000000000000000000000000000000000000000000", out.SpentTxHash)
+	assert.Equal(t, uint64(2), out.SpentHeight)
+
+	unspent, pending := tracker.Balance()
+	assert.Equal(t, uint64(0), unspent)
+	assert.Equal(t, uint64(0), pending)
+
+	tracker.Rewind(2)
+	out, _ = tracker.Get(spentTestKeyImage)
+	assert.Equal(t, scanner.Unspent, out.Status)
+
+	unspent, _ = tracker.Balance()
+	assert.Equal(t, uint64(550000000000), unspent)
+
+	// the same block processed without the scanner
+	if err := tracker.ProcessBlock(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+	out, _ = tracker.Get(spentTestKeyImage)
+	assert.Equal(t, scanner.Spent, out.Status)
+}
+
+func TestSpentTrackerMissedTxs(t *testing.T) {
+	chain := spentTestChain(t)
+	// the daemon doesn't know the spending tx
+	chain[2].txs = nil
+
+	server := getScannerTestServer(t, chain)
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := scanner.NewSpentTracker(test_daemon, createTestSpentTrackerKeys(t))
+	if _, err := tracker.Add(scanner.OwnedOutput{TxPubKey: scannerTestTxPub, OutputIndex: 1, Amount: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.ErrorIs(t, tracker.ProcessBlock(context.Background(), 2), scanner.ErrMissedTxs)
+	out, _ := tracker.Get(spentTestKeyImage)
+	assert.Equal(t, scanner.Unspent, out.Status)
+}
+
+func TestSpentTrackerUpdatePool(t *testing.T) {
+	var pool daemon.GetTransactionPoolResponse
+	server := getScannerTestServerWithPool(t, scannerTestChain(t), func() daemon.GetTransactionPoolResponse { return pool })
+	defer server.Close()
+
+	test_daemon, err := createTestDaemonRpcClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := scanner.NewSpentTracker(test_daemon, createTestSpentTrackerKeys(t))
+	out, err := tracker.Add(scanner.OwnedOutput{TxPubKey: scannerTestTxPub, OutputIndex: 1, Amount: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, spentTestKeyImage, out.KeyImage)
+
+	cases := []struct {
+		pool     daemon.GetTransactionPoolResponse
+		expected scanner.SpentStatus
+		pending  uint64
+	}{
+		{
+			pool:     daemon.GetTransactionPoolResponse{SpentKeyImages: []daemon.SpentKeyImage{{IdHash: spentTestKeyImage, TxsHashes: []string{"bb"}}}},
+			expected: scanner.PendingSpent,
+			pending:  100,
+		},
+		{
+			pool:     daemon.GetTransactionPoolResponse{SpentKeyImages: []daemon.SpentKeyImage{{IdHash: spentTestKeyImage, TxsHashes: []string{"bb", "cc"}}}},
+			expected: scanner.DoubleSpendSeen,
+			pending:  100,
+		},
+		{
+			pool: daemon.GetTransactionPoolResponse{
+				SpentKeyImages: []daemon.SpentKeyImage{{IdHash: spentTestKeyImage, TxsHashes: []string{"bb"}}},
+				Transactions:   []daemon.MoneroTx{{IdHash: "bb", DoubleSpendSeen: true}},
+			},
+			expected: scanner.DoubleSpendSeen,
+			pending:  100,
+		},
+		{
+			// the tx has been dropped from the pool
+			pool:     daemon.GetTransactionPoolResponse{},
+			expected: scanner.Unspent,
+		},
+	}
+
+	for _, v := range cases {
+		pool = v.pool
+		if err := tracker.UpdatePool(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		out, _ := tracker.Get(spentTestKeyImage)
+		assert.Equal(t, v.expected, out.Status)
+
+		_, pending := tracker.Balance()
+		assert.Equal(t, v.pending, pending)
+	}
+}
+
+func TestSpentTrackerKnownKeyImage(t *testing.T) {
+	viewKey, err := utils.NewPrivateKey(scannerTestViewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendKey, err := utils.NewPrivateKey(spentTestVectorSpendKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := utils.NewFullKeyPair(viewKey, spendKey)
+
+	txPub, err := utils.NewPublicKey(scannerTestTxPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outKey, err := utils.NewPublicKey(spentTestVectorOutKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owned, err := utils.OutputBelongsPublicSpendKey(keys.SpendKeyPair().PublicKey(), 1, outKey, txPub, viewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, owned)
+
+	tracker := scanner.NewSpentTracker(nil, keys)
+	out, err := tracker.Add(scanner.OwnedOutput{TxPubKey: scannerTestTxPub, OutputIndex: 1, PublicKey: spentTestVectorOutKey, Amount: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, spentTestVectorKeyImage, out.KeyImage)
+
+	tracker.ProcessTx("aa", &daemon.MoneroTxInfo{Vin: []daemon.Vin2{{Key: daemon.Key{KeyImage: spentTestVectorKeyImage}}}}, 5)
+	out1, _ := tracker.Get(spentTestVectorKeyImage)
+	assert.Equal(t, scanner.Spent, out1.Status)
+
+	unspent, _ := tracker.Balance()
+	assert.Equal(t, uint64(0), unspent)
+}
@@ -0,0 +1,159 @@
+package test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/chekist32/go-monero/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestSubaddressTable(t *testing.T, majors, minors uint32) *utils.SubaddressTable {
+	viewKey, err := utils.NewPrivateKey("8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendKey, err := utils.NewPublicKey("38e9908d33d034de0ba1281aa7afe3907b795cea14852b3d8fe276e8931cb130")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := utils.NewSubaddressTable(utils.NewViewOnlyKeyPair(viewKey, spendKey), majors, minors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return table
+}
+
+func TestSubaddressTableLookup(t *testing.T) {
+	table := createTestSubaddressTable(t, 4, 6)
+	assert.Equal(t, 24, table.Len())
+
+	cases := []struct {
+		addr     string
+		expected utils.SubaddressIndex
+	}{
+		{"72c2F4L6XMu28Wf4e5yiVfKJcb4uDzvM9DxSAydF9o766RUiVqXawkhUcz7y59EBRrDafZB8DezLbLSrtb5xPL7s6PZ2zoj", utils.SubaddressIndex{Major: 1, Minor: 0}},
+		{"74wdCFDsraBfreEwnfyyexK5d5ZkU48bK6Xd1UGjFTvNYes7gQJY47WUdA23hny1ynC2REEM9Rf1DGNuuwbDrsuAEHrwVmv", utils.SubaddressIndex{Major: 3, Minor: 5}},
+	}
+
+	for _, v := range cases {
+		t.Run(v.addr, func(t *testing.T) {
+			addr, err := utils.NewAddress(v.addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, ok := table.Lookup(addr.PublicSpendKey())
+			assert.True(t, ok)
+			assert.Equal(t, v.expected, actual)
+		})
+	}
+
+	primary, ok := table.Lookup(table.Keys().SpendPublicKey())
+	assert.True(t, ok)
+	assert.Equal(t, utils.SubaddressIndex{Major: 0, Minor: 0}, primary)
+}
+
+func TestSubaddressTableGrow(t *testing.T) {
+	table := createTestSubaddressTable(t, 2, 2)
+
+	addr, err := utils.NewAddress("74wdCFDsraBfreEwnfyyexK5d5ZkU48bK6Xd1UGjFTvNYes7gQJY47WUdA23hny1ynC2REEM9Rf1DGNuuwbDrsuAEHrwVmv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := table.Lookup(addr.PublicSpendKey())
+	assert.False(t, ok)
+
+	if err := table.Grow(4, 6); err != nil {
+		t.Fatal(err)
+	}
+	majors, minors := table.Window()
+	assert.Equal(t, uint32(4), majors)
+	assert.Equal(t, uint32(6), minors)
+	assert.Equal(t, 24, table.Len())
+
+	index, ok := table.Lookup(addr.PublicSpendKey())
+	assert.True(t, ok)
+	assert.Equal(t, utils.SubaddressIndex{Major: 3, Minor: 5}, index)
+
+	// the window never shrinks
+	if err := table.Grow(1, 1); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 24, table.Len())
+}
+
+func TestSubaddressTableDecryptOutput(t *testing.T) {
+	table := createTestSubaddressTable(t, 3, 10)
+
+	txPub, err := utils.NewPublicKey("7302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outKey, err := utils.NewPublicKey("7e4f4427539b206740bed78b81b0dc10acb89aa1545880863f73264492ee0c16")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	derived, err := utils.DeriveOutputPublicSpendKey(1, outKey, txPub, table.Keys().ViewKeyPair().PrivateKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, table.Keys().SpendPublicKey().Bytes(), derived.Bytes())
+
+	index, ok, err := table.OutputBelongs(1, outKey, txPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, ok)
+	assert.Equal(t, utils.SubaddressIndex{Major: 0, Minor: 0}, index)
+
+	index, ok, am, err := table.DecryptOutput(1, outKey, "5db33f80fd4990bc", txPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, ok)
+	assert.Equal(t, utils.SubaddressIndex{Major: 0, Minor: 0}, index)
+	assert.Equal(t, utils.Float64ToXMR(0.55), am)
+
+	_, ok, err = table.OutputBelongs(0, outKey, txPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, ok)
+}
+
+func TestSubaddressTableDecryptOutputAdditional(t *testing.T) {
+	table := createTestSubaddressTable(t, 1, 1)
+
+	extra, err := hex.DecodeString(additionalKeysTestExtra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txPub, additionalKeys, _ := utils.ParseExtraWithAdditionalKeys(extra)
+
+	outKey, err := utils.NewPublicKey("7e4f4427539b206740bed78b81b0dc10acb89aa1545880863f73264492ee0c16")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index, key, ok, am, err := table.DecryptOutputAdditional(1, outKey, "5db33f80fd4990bc", txPub, additionalKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, ok)
+	assert.Equal(t, utils.SubaddressIndex{Major: 0, Minor: 0}, index)
+	assert.Equal(t, additionalKeys[1].Bytes(), key.Bytes())
+	assert.Equal(t, utils.Float64ToXMR(0.55), am)
+
+	_, key, ok, err = table.OutputBelongsAdditional(1, outKey, txPub, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, ok)
+	assert.Nil(t, key)
+}
@@ -0,0 +1,329 @@
+package test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/tx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// coinbase tx of the block 2751506
+const txTestMinerTxBlob = "02cef8a70101ff92f8a7010180e0a596bb1103d7cbf826b665d7a532c316982dc8dbc24f285cbc18bbcc27c7164cd9b3277a85d034019f629d8b36bd16a2bfce3ea80c31dc4d8762c67165aec21845494e32b7582fe00211000000297a787a0000000000000000000000"
+
+func TestParseTransaction(t *testing.T) {
+	data, err := os.ReadFile("../performance/txs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var txs []daemon.MoneroTx1
+	if err := json.Unmarshal(data, &txs); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range txs {
+		res, err := tx.ParseTransactionHex(v.AsHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash, err := res.Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v.TxHash, hash.String())
+
+		prunableHash, err := res.PrunableHash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, v.PrunableHash, prunableHash.String())
+
+		info, err := daemonTxInfoHelper(v.AsJson)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, info.RctSignatures.TxnFee, res.RctSignatures.TxnFee)
+		assert.Equal(t, info.Extra, res.Extra)
+		assert.Len(t, res.Vin, len(info.Vin))
+		for i, in := range info.Vin {
+			assert.Equal(t, in.Key.KeyImage, res.Vin[i].Key.KeyImage.String())
+		}
+		assert.Len(t, res.Vout, len(info.Vout))
+		for i, out := range info.Vout {
+			assert.Equal(t, out.Target.TaggedKey.Key, res.Vout[i].Key.String())
+			assert.Equal(t, out.Target.TaggedKey.ViewTag, hex.EncodeToString([]byte{*res.Vout[i].ViewTag}))
+			// older daemons put the 8 byte amount to "amount"
+			assert.Equal(t, info.RctSignatures.EcdhInfo[i].TruncAmount+info.RctSignatures.EcdhInfo[i].Amount, res.EncryptedAmount(i))
+		}
+	}
+}
+
+func daemonTxInfoHelper(asJson string) (*daemon.MoneroTxInfo, error) {
+	var info daemon.MoneroTxInfo
+	if err := json.Unmarshal([]byte(asJson), &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+func TestParseTransactionCoinbase(t *testing.T) {
+	res, err := tx.ParseTransactionHex(txTestMinerTxBlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, uint64(2), res.Version)
+	assert.Equal(t, uint64(2751566), res.UnlockTime)
+	assert.Equal(t, uint64(2751506), res.Vin[0].Gen.Height)
+	assert.Equal(t, uint64(600000000000), res.Vout[0].Amount)
+	assert.Equal(t, "d7cbf826b665d7a532c316982dc8dbc24f285cbc18bbcc27c7164cd9b3277a85", res.Vout[0].Key.String())
+	assert.Equal(t, byte(0xd0), *res.Vout[0].ViewTag)
+	assert.Equal(t, tx.RCT_TYPE_NULL, res.RctSignatures.Type)
+	assert.False(t, res.Pruned)
+
+	hash, err := res.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "e49b854c5f339d7410a77f2a137281d8042a0ffc7ef9ab24cd670b67139b24cd", hash.String())
+}
+
+type txTestBuilder struct {
+	bytes.Buffer
+	fill byte
+}
+
+func (b *txTestBuilder) varint(v uint64) {
+	b.Write(binary.AppendUvarint(nil, v))
+}
+
+func (b *txTestBuilder) keys(n int) {
+	for i := 0; i < n; i++ {
+		b.fill++
+		b.Write(bytes.Repeat([]byte{b.fill}, 32))
+	}
+}
+
+// Builds a tx with the given number of inputs, outputs and ring members,
+// returns the blob and the size of the pruned blob
+func buildTestTx(version uint64, rctType byte, inputs, outputs, ringSize int) ([]byte, int) {
+	b := &txTestBuilder{}
+
+	b.varint(version)
+	b.varint(0)
+	b.varint(uint64(inputs))
+	for i := 0; i < inputs; i++ {
+		b.WriteByte(tx.TXIN_TO_KEY)
+		b.varint(0)
+		b.varint(uint64(ringSize))
+		for j := 0; j < ringSize; j++ {
+			b.varint(uint64(1000 + j))
+		}
+		b.keys(1)
+	}
+	b.varint(uint64(outputs))
+	for i := 0; i < outputs; i++ {
+		b.varint(0)
+		b.WriteByte(tx.TXOUT_TO_KEY)
+		b.keys(1)
+	}
+	b.varint(3)
+	b.Write([]byte{0x02, 0x01, 0x00})
+
+	if version == 1 {
+		pruned := b.Len()
+		for i := 0; i < inputs*ringSize; i++ {
+			b.keys(2)
+		}
+		return b.Bytes(), pruned
+	}
+
+	b.WriteByte(rctType)
+	b.varint(30000000)
+	if rctType == tx.RCT_TYPE_SIMPLE {
+		b.keys(inputs)
+	}
+	for i := 0; i < outputs; i++ {
+		if rctType >= tx.RCT_TYPE_BULLETPROOF2 {
+			b.Write([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+		} else {
+			b.keys(2)
+		}
+	}
+	b.keys(outputs)
+	pruned := b.Len()
+
+	switch rctType {
+	case tx.RCT_TYPE_FULL, tx.RCT_TYPE_SIMPLE:
+		for i := 0; i < outputs; i++ {
+			b.keys(64*3 + 1)
+		}
+	case tx.RCT_TYPE_BULLETPROOF:
+		b.Write([]byte{1, 0, 0, 0})
+		b.keys(6)
+		b.varint(7)
+		b.keys(7)
+		b.varint(7)
+		b.keys(7)
+		b.keys(3)
+	case tx.RCT_TYPE_BULLETPROOF2, tx.RCT_TYPE_CLSAG:
+		b.varint(1)
+		b.keys(6)
+		b.varint(7)
+		b.keys(7)
+		b.varint(7)
+		b.keys(7)
+		b.keys(3)
+	case tx.RCT_TYPE_BULLETPROOF_PLUS:
+		b.varint(1)
+		b.keys(6)
+		b.varint(7)
+		b.keys(7)
+		b.varint(7)
+		b.keys(7)
+	}
+
+	switch rctType {
+	case tx.RCT_TYPE_CLSAG, tx.RCT_TYPE_BULLETPROOF_PLUS:
+		for i := 0; i < inputs; i++ {
+			b.keys(ringSize + 2)
+		}
+	case tx.RCT_TYPE_FULL:
+		b.keys(ringSize*(inputs+1) + 1)
+	default:
+		for i := 0; i < inputs; i++ {
+			b.keys(ringSize*2 + 1)
+		}
+	}
+
+	if rctType >= tx.RCT_TYPE_BULLETPROOF {
+		b.keys(inputs)
+	}
+
+	return b.Bytes(), pruned
+}
+
+func TestParseTransactionTypes(t *testing.T) {
+	cases := []struct {
+		version uint64
+		rctType byte
+	}{
+		{version: 1},
+		{version: 2, rctType: tx.RCT_TYPE_FULL},
+		{version: 2, rctType: tx.RCT_TYPE_SIMPLE},
+		{version: 2, rctType: tx.RCT_TYPE_BULLETPROOF},
+		{version: 2, rctType: tx.RCT_TYPE_BULLETPROOF2},
+		{version: 2, rctType: tx.RCT_TYPE_CLSAG},
+		{version: 2, rctType: tx.RCT_TYPE_BULLETPROOF_PLUS},
+	}
+
+	for _, v := range cases {
+		t.Run("", func(t *testing.T) {
+			blob, prunedSize := buildTestTx(v.version, v.rctType, 2, 3, 11)
+
+			res, err := tx.ParseTransaction(blob)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.False(t, res.Pruned)
+			assert.Len(t, res.Vin, 2)
+			assert.Len(t, res.Vin[1].Key.KeyOffsets, 11)
+			assert.Len(t, res.Vout, 3)
+			assert.Equal(t, []byte{0x02, 0x01, 0x00}, res.Extra)
+
+			hash, err := res.Hash()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pruned, err := tx.ParseTransaction(blob[:prunedSize])
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.True(t, pruned.Pruned)
+			_, err = pruned.Hash()
+			assert.ErrorIs(t, err, tx.ErrPrunedTx)
+
+			if v.version == 1 {
+				assert.Len(t, res.Signatures[1], 11)
+				return
+			}
+
+			p := res.RctSignatures.Prunable
+			switch v.rctType {
+			case tx.RCT_TYPE_FULL:
+				assert.Len(t, p.RangeSigs, 3)
+				assert.Len(t, p.MGs, 1)
+				assert.Len(t, p.MGs[0].Ss[10], 3)
+			case tx.RCT_TYPE_SIMPLE:
+				assert.Len(t, p.RangeSigs, 3)
+				assert.Len(t, p.MGs, 2)
+				assert.Len(t, res.RctSignatures.PseudoOuts, 2)
+			case tx.RCT_TYPE_BULLETPROOF, tx.RCT_TYPE_BULLETPROOF2:
+				assert.Len(t, p.Bulletproofs, 1)
+				assert.Len(t, p.Bulletproofs[0].L, 7)
+				assert.Len(t, p.MGs, 2)
+				assert.Len(t, p.PseudoOuts, 2)
+			case tx.RCT_TYPE_CLSAG:
+				assert.Len(t, p.Bulletproofs, 1)
+				assert.Len(t, p.CLSAGs, 2)
+				assert.Len(t, p.CLSAGs[0].S, 11)
+			case tx.RCT_TYPE_BULLETPROOF_PLUS:
+				assert.Len(t, p.BulletproofsPlus, 1)
+				assert.Len(t, p.CLSAGs, 2)
+				assert.Len(t, p.PseudoOuts, 2)
+			}
+			if v.rctType >= tx.RCT_TYPE_BULLETPROOF2 {
+				assert.Equal(t, "0102030405060708", res.EncryptedAmount(0))
+			} else {
+				assert.Len(t, res.EncryptedAmount(0), 64)
+			}
+
+			prunableHash, err := res.PrunableHash()
+			if err != nil {
+				t.Fatal(err)
+			}
+			prunedHash, err := pruned.HashWithPrunableHash(prunableHash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, hash, prunedHash)
+		})
+	}
+}
+
+func TestParseTransactionErrors(t *testing.T) {
+	blob, _ := buildTestTx(2, tx.RCT_TYPE_CLSAG, 1, 2, 16)
+
+	cases := []struct {
+		blob   []byte
+		offset int
+	}{
+		{blob: blob[:len(blob)-1], offset: len(blob) - 32},
+		{blob: append(append([]byte{}, blob...), 0), offset: len(blob)},
+		// unsupported input tag
+		{blob: []byte{0x02, 0x00, 0x01, 0x00}, offset: 3},
+		// non canonical varint
+		{blob: []byte{0x82, 0x00}, offset: 0},
+	}
+
+	for _, v := range cases {
+		_, err := tx.ParseTransaction(v.blob)
+
+		var parseErr *tx.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("expected *tx.ParseError, got %v", err)
+		}
+		assert.Equal(t, v.offset, parseErr.Offset)
+	}
+}
@@ -38,6 +38,36 @@ func TestGetTxPublicKeyFromExtra(t *testing.T) {
 	assert.Equal(t, expected, txPub)
 }
 
+func TestGetFromExtraTrailingJunk(t *testing.T) {
+	// a valid pub key and an encrypted payment id followed by a nonce with a broken varint size
+	extra, err := hex.DecodeString("0166488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a81835020901a88dcbe6025e76ce0280")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = utils.ParseTxExtra(extra)
+	assert.Error(t, err)
+
+	txPub, err := utils.GetTxPublicKeyFromExtra(extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "66488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a81835", hex.EncodeToString(txPub.Bytes()))
+
+	payId, err := utils.GetPaymentIdFromExtra(extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "a88dcbe6025e76ce", hex.EncodeToString(payId))
+
+	// the parse error is returned when the field is missing
+	_, err = utils.GetTxPublicKeyFromExtra([]byte{0x02, 0x80})
+	var extraErr *utils.ExtraError
+	assert.ErrorAs(t, err, &extraErr)
+	_, err = utils.GetPaymentIdFromExtra(extra[:34])
+	assert.ErrorAs(t, err, &extraErr)
+}
+
 func TestOutputBelongsViewTag(t *testing.T) {
 	txPub, err := utils.NewPublicKey("7302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364")
 	if err != nil {
@@ -230,3 +260,201 @@ func TestPaymentID(t *testing.T) {
 	assert.Equal(t, len(PaymentID256), 32)
 	assert.Equal(t, len(PaymentID64), 8)
 }
+
+// main tx pub key that doesn't derive the test output + additional tx pub keys (tag 0x04) where the second one does
+const additionalKeysTestExtra = "0166488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a81835" +
+	"04020993e6ca2d66871e4869adb2c3a524ad7205fcd3e0b3339daafaea76fc5518ee7302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364" +
+	"020901a88dcbe6025e76ce"
+
+func TestGetAdditionalTxPublicKeysFromExtra(t *testing.T) {
+	extra, err := hex.DecodeString(additionalKeysTestExtra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := utils.GetAdditionalTxPublicKeysFromExtra(extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, keys, 2)
+	assert.Equal(t, "0993e6ca2d66871e4869adb2c3a524ad7205fcd3e0b3339daafaea76fc5518ee", hex.EncodeToString(keys[0].Bytes()))
+	assert.Equal(t, "7302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364", hex.EncodeToString(keys[1].Bytes()))
+
+	noAdditional, err := hex.DecodeString("01c4865e47b9392d52e6f4957d0a6f6a9feda0ef0ac4807e1127bea56cb3ba583e020901a88dcbe6025e76ce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err = utils.GetAdditionalTxPublicKeysFromExtra(noAdditional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, keys)
+
+	_, err = utils.GetAdditionalTxPublicKeysFromExtra(extra[:50])
+	assert.Error(t, err)
+}
+
+func TestParseExtraWithAdditionalKeys(t *testing.T) {
+	extra, err := hex.DecodeString(additionalKeysTestExtra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txPub, additionalKeys, _ := utils.ParseExtraWithAdditionalKeys(extra)
+
+	assert.Equal(t, "66488b56658159e08f88f90afca5cb9ea3e3fbe460b0328b83a6ef7ca7a81835", hex.EncodeToString(txPub.Bytes()))
+	assert.Len(t, additionalKeys, 2)
+}
+
+func TestDecryptOutputPublicSpendKeyAdditional(t *testing.T) {
+	extra, err := hex.DecodeString(additionalKeysTestExtra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txPub, additionalKeys, _ := utils.ParseExtraWithAdditionalKeys(extra)
+
+	viewKey, err := utils.NewPrivateKey("8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outKey, err := utils.NewPublicKey("7e4f4427539b206740bed78b81b0dc10acb89aa1545880863f73264492ee0c16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendKey, err := utils.NewPublicKey("38e9908d33d034de0ba1281aa7afe3907b795cea14852b3d8fe276e8931cb130")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, utils.GetOutputTxPublicKeys(1, txPub, additionalKeys), 2)
+	assert.Len(t, utils.GetOutputTxPublicKeys(5, txPub, additionalKeys), 1)
+
+	// the main key alone doesn't find the output
+	res, _, err := utils.DecryptOutputPublicSpendKey(spendKey, 1, outKey, "5db33f80fd4990bc", txPub, viewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, res)
+
+	res, err = utils.OutputBelongsViewTagAdditional("1a", 1, txPub, additionalKeys, viewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, res)
+
+	res, err = utils.OutputBelongsPublicSpendKeyAdditional(spendKey, 1, outKey, txPub, additionalKeys, viewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, res)
+
+	res, am, err := utils.DecryptOutputPublicSpendKeyAdditional(spendKey, 1, outKey, "5db33f80fd4990bc", txPub, additionalKeys, viewKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, res)
+	assert.Equal(t, utils.Float64ToXMR(0.55), am)
+}
+
+func TestComputeKeyImage(t *testing.T) {
+	cases := []struct {
+		txPub        string
+		outIndex     uint32
+		major, minor uint32
+		expected     string
+	}{
+		{
+			// stagenet output 7e4f4427539b206740bed78b81b0dc10acb89aa1545880863f73264492ee0c16
+			txPub:    "7302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364",
+			outIndex: 1,
+			expected: "070be2cc1cf03ab7436df4582f66d2648aa80da9c38d32084841b4d896f853c4",
+		},
+		{
+			txPub:    "7302dd77bf4095baf868de43b7a32f4a36fe9d8b48ccfff537157a4a786fa364",
+			outIndex: 0,
+			major:    1,
+			minor:    0,
+			expected: "acfb52dbcdc573fb964fc3f8037eafd7a93896bbb3672c3f8381c178886f823b",
+		},
+	}
+
+	viewKey, err := utils.NewPrivateKey("8aa763d1c8d9da4ca75cb6ca22a021b5cca376c1367be8d62bcc9cdf4b926009")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spendKey, err := utils.NewPrivateKey("372fcc2abc6bc5015103aae4763822e45c4cfe775d163f97a9ebdd77b0d12c0c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := utils.NewFullKeyPair(viewKey, spendKey)
+
+	for _, v := range cases {
+		t.Run("", func(t *testing.T) {
+			txPub, err := utils.NewPublicKey(v.txPub)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ki, err := utils.ComputeKeyImage(keys, txPub, v.outIndex, v.major, v.minor)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, v.expected, hex.EncodeToString(ki.Bytes()))
+		})
+	}
+}
+
+func TestGenerateKeyImage(t *testing.T) {
+	// generate_key_image from monero's tests/crypto/tests.txt, the top bit of Keccak(P) is set
+	key, err := utils.NewPrivateKey("981d477fb18897fa1f784c89721a9d600bf283f06b89cb018a077f41dcefef0f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "e46b60ebfe610b8ba761032018471e5719bb77ea1cd945475c4a4abe7224bfd0", hex.EncodeToString(utils.GetPublicKeyFromPrivate(key).Bytes()))
+
+	ki, err := utils.GenerateKeyImage(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "a637203ec41eab772532d30420eac80612fce8e44f1758bc7e2cb1bdda815887", hex.EncodeToString(ki.Bytes()))
+}
+
+func TestSignature(t *testing.T) {
+	key, err := utils.NewRandomPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := utils.GetPublicKeyFromPrivate(key)
+
+	hash, err := utils.Keccak256Hash([]byte("0005f8c1b1d0a2e3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := utils.GenerateSignature(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, sig, 64)
+	assert.True(t, utils.CheckSignature(hash, pub, sig))
+
+	other, err := utils.Keccak256Hash([]byte("0005f8c1b1d0a2e4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, utils.CheckSignature(other, pub, sig))
+
+	otherKey, err := utils.NewRandomPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, utils.CheckSignature(hash, utils.GetPublicKeyFromPrivate(otherKey), sig))
+
+	sig[40] ^= 1
+	assert.False(t, utils.CheckSignature(hash, pub, sig))
+
+	_, err = utils.GenerateSignature(hash[:31], key)
+	assert.Error(t, err)
+}
@@ -0,0 +1,207 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/stretchr/testify/assert"
+)
+
+// A chain whose block hashes are made of the height and the name of the fork
+type watcherTestClient struct {
+	daemon.IDaemonRpcClient
+	mu     sync.Mutex
+	chain  []daemon.BlockHeader
+	pool   []string
+	mined  map[string]uint64
+	polled chan struct{}
+}
+
+func newWatcherTestClient(height uint64) *watcherTestClient {
+	c := &watcherTestClient{mined: make(map[string]uint64), polled: make(chan struct{}, 1)}
+	c.extendHelper(0, height, "main")
+	return c
+}
+
+// Replaces the blocks from the height on with the count blocks of the fork
+func (c *watcherTestClient) extendHelper(from uint64, count uint64, fork string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.chain = c.chain[:from]
+	for h := from; h < from+count; h++ {
+		header := daemon.BlockHeader{Height: h, Hash: fmt.Sprintf("%s%d", fork, h)}
+		if h > 0 {
+			header.PrevHash = c.chain[h-1].Hash
+		}
+		c.chain = append(c.chain, header)
+	}
+}
+
+func (c *watcherTestClient) setPoolHelper(pool []string, mined map[string]uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pool = pool
+	for k, v := range mined {
+		c.mined[k] = v
+	}
+}
+
+func (c *watcherTestClient) GetLastBlockHeaderCtx(ctx context.Context, fillPowHash bool) (*daemon.JsonRpcGenericResponse[daemon.GetBlockHeaderResult], error) {
+	c.mu.Lock()
+	defer func() {
+		c.mu.Unlock()
+		select {
+		case c.polled <- struct{}{}:
+		default:
+		}
+	}()
+
+	res := &daemon.JsonRpcGenericResponse[daemon.GetBlockHeaderResult]{}
+	res.Result.BlockHeader = c.chain[len(c.chain)-1]
+	return res, nil
+}
+
+func (c *watcherTestClient) GetBlockHeadersRangeCtx(ctx context.Context, fillPowHash bool, startHeight uint64, endHeight uint64) (*daemon.JsonRpcGenericResponse[daemon.GetBlockHeadersRangeResult], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if endHeight-startHeight >= 1000 {
+		return nil, daemon.ErrWrongParam
+	}
+	res := &daemon.JsonRpcGenericResponse[daemon.GetBlockHeadersRangeResult]{}
+	res.Result.Headers = append(res.Result.Headers, c.chain[startHeight:endHeight+1]...)
+	return res, nil
+}
+
+func (c *watcherTestClient) GetTransactionPoolCtx(ctx context.Context) (*daemon.GetTransactionPoolResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := &daemon.GetTransactionPoolResponse{}
+	for _, v := range c.pool {
+		res.Transactions = append(res.Transactions, daemon.MoneroTx{IdHash: v})
+	}
+	return res, nil
+}
+
+func (c *watcherTestClient) GetTransactionsCtx(ctx context.Context, txHashes []string, decodeAsJson bool, prune bool, split bool) (*daemon.GetTransactionsResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := &daemon.GetTransactionsResponse{}
+	for _, v := range txHashes {
+		if h, ok := c.mined[v]; ok {
+			res.Txs = append(res.Txs, daemon.MoneroTx1{TxHash: v, BlockHeight: h})
+		} else {
+			res.MissedTx = append(res.MissedTx, v)
+		}
+	}
+	return res, nil
+}
+
+func watcherTestNextEventHelper(t *testing.T, w *daemon.Watcher) daemon.WatcherEvent {
+	select {
+	case e := <-w.Events():
+		return e
+	case err := <-w.Errors():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+	return nil
+}
+
+func watcherTestHashesHelper(headers []daemon.BlockHeader) []string {
+	var res []string
+	for _, v := range headers {
+		res = append(res, v.Hash)
+	}
+	return res
+}
+
+func TestWatcher(t *testing.T) {
+	client := newWatcherTestClient(2000)
+	client.setPoolHelper([]string{"tx1", "tx2"}, nil)
+
+	w := daemon.NewWatcher(client, daemon.WatcherConfig{Interval: 5 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	// the initial state isn't reported
+	<-client.polled
+	client.extendHelper(2000, 2, "main")
+	assert.Equal(t, daemon.NewBlockEvent{Header: daemon.BlockHeader{Height: 2000, Hash: "main2000", PrevHash: "main1999"}}, watcherTestNextEventHelper(t, w))
+	assert.Equal(t, "main2001", watcherTestNextEventHelper(t, w).(daemon.NewBlockEvent).Header.Hash)
+
+	client.setPoolHelper([]string{"tx2", "tx3"}, map[string]uint64{"tx1": 2001})
+	assert.Equal(t, daemon.TxAddedToPoolEvent{Tx: daemon.MoneroTx{IdHash: "tx3"}}, watcherTestNextEventHelper(t, w))
+	assert.Equal(t, daemon.TxConfirmedEvent{TxHash: "tx1", Height: 2001}, watcherTestNextEventHelper(t, w))
+
+	client.setPoolHelper(nil, nil)
+	assert.Equal(t, daemon.TxRemovedFromPoolEvent{TxHash: "tx2"}, watcherTestNextEventHelper(t, w))
+	assert.Equal(t, daemon.TxRemovedFromPoolEvent{TxHash: "tx3"}, watcherTestNextEventHelper(t, w))
+
+	// 12 blocks are replaced by 13 other ones, deeper than a single step back
+	client.extendHelper(1990, 13, "alt")
+	reorg, ok := watcherTestNextEventHelper(t, w).(daemon.ReorgEvent)
+	if assert.True(t, ok) {
+		assert.Len(t, reorg.Detached, 12)
+		assert.Equal(t, "main1990", reorg.Detached[0].Hash)
+		assert.Equal(t, "main2001", reorg.Detached[11].Hash)
+		assert.Len(t, reorg.Attached, 13)
+		assert.Equal(t, "alt1990", reorg.Attached[0].Hash)
+		assert.Equal(t, "main1989", reorg.Attached[0].PrevHash)
+	}
+	var attached []daemon.BlockHeader
+	for i := 0; i < 13; i++ {
+		attached = append(attached, watcherTestNextEventHelper(t, w).(daemon.NewBlockEvent).Header)
+	}
+	assert.Equal(t, watcherTestHashesHelper(reorg.Attached), watcherTestHashesHelper(attached))
+
+	// a reorg to a chain of the same height
+	client.extendHelper(2002, 1, "other")
+	reorg = watcherTestNextEventHelper(t, w).(daemon.ReorgEvent)
+	assert.Equal(t, []string{"alt2002"}, watcherTestHashesHelper(reorg.Detached))
+	assert.Equal(t, []string{"other2002"}, watcherTestHashesHelper(reorg.Attached))
+	assert.Equal(t, "other2002", watcherTestNextEventHelper(t, w).(daemon.NewBlockEvent).Header.Hash)
+
+	cancel()
+	for range w.Events() {
+	}
+	assert.ErrorIs(t, w.Run(context.Background()), daemon.ErrWatcherStarted)
+}
+
+func TestWatcherReorgTooDeep(t *testing.T) {
+	client := newWatcherTestClient(100)
+
+	w := daemon.NewWatcher(client, daemon.WatcherConfig{Interval: 5 * time.Millisecond, MaxReorgDepth: 5, IgnorePool: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	// the watcher remembers only the last 5 blocks
+	<-client.polled
+	for i := uint64(0); i < 5; i++ {
+		client.extendHelper(100+i, 1, "main")
+		watcherTestNextEventHelper(t, w)
+	}
+
+	client.extendHelper(90, 16, "alt")
+	reorg := watcherTestNextEventHelper(t, w).(daemon.ReorgEvent)
+	assert.Equal(t, []string{"main100", "main101", "main102", "main103", "main104"}, watcherTestHashesHelper(reorg.Detached))
+	assert.Equal(t, "alt100", reorg.Attached[0].Hash)
+	assert.Equal(t, "alt105", reorg.Attached[len(reorg.Attached)-1].Hash)
+
+	for range reorg.Attached {
+		_, ok := (<-w.Events()).(daemon.NewBlockEvent)
+		assert.True(t, ok)
+	}
+	assert.ErrorIs(t, <-w.Errors(), daemon.ErrReorgTooDeep)
+}
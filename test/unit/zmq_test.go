@@ -0,0 +1,259 @@
+package test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/chekist32/go-monero/daemon"
+	"github.com/chekist32/go-monero/daemon/zmq"
+	"github.com/stretchr/testify/assert"
+)
+
+// A stand-in for the monerod ZMQ publisher: a ZMTP 3.0 PUB socket that serves one subscriber per connection.
+// The connections are passed to the test once the subscriber has sent its subscriptions.
+type zmqTestPublisher struct {
+	listener net.Listener
+	conns    chan net.Conn
+}
+
+func zmqTestReadFrameHelper(r io.Reader) (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	size := uint64(header[1])
+	if header[0]&0x02 != 0 {
+		var rest [7]byte
+		if _, err := io.ReadFull(r, rest[:]); err != nil {
+			return 0, nil, err
+		}
+		size = binary.BigEndian.Uint64(append([]byte{header[1]}, rest[:]...))
+	}
+
+	body := make([]byte, size)
+	_, err := io.ReadFull(r, body)
+	return header[0], body, err
+}
+
+func zmqTestWriteFrameHelper(w io.Writer, flags byte, body []byte) {
+	if len(body) > 255 {
+		w.Write(binary.BigEndian.AppendUint64([]byte{flags | 0x02}, uint64(len(body))))
+	} else {
+		w.Write([]byte{flags, byte(len(body))})
+	}
+	w.Write(body)
+}
+
+func newZmqTestPublisher(t *testing.T, topics int) *zmqTestPublisher {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &zmqTestPublisher{listener, make(chan net.Conn, 4)}
+	go func() {
+	accept:
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			greeting := make([]byte, 64)
+			greeting[0], greeting[9], greeting[10] = 0xff, 0x7f, 3
+			copy(greeting[12:], "NULL")
+			conn.Write(greeting)
+			if _, err := io.ReadFull(conn, make([]byte, 64)); err != nil {
+				conn.Close()
+				continue
+			}
+
+			if _, _, err := zmqTestReadFrameHelper(conn); err != nil {
+				conn.Close()
+				continue
+			}
+			ready := append([]byte{5}, "READY"...)
+			ready = append(ready, 11)
+			ready = append(ready, "Socket-Type"...)
+			ready = append(ready, 0, 0, 0, 3)
+			zmqTestWriteFrameHelper(conn, 0x04, append(ready, "PUB"...))
+
+			for i := 0; i < topics; i++ {
+				if _, sub, err := zmqTestReadFrameHelper(conn); err != nil || sub[0] != 1 {
+					conn.Close()
+					continue accept
+				}
+			}
+			p.conns <- conn
+		}
+	}()
+
+	return p
+}
+
+func (p *zmqTestPublisher) nextConnHelper(t *testing.T) net.Conn {
+	select {
+	case conn := <-p.conns:
+		return conn
+	case <-time.After(5 * time.Second):
+		t.Fatal("the subscriber hasn't connected")
+		return nil
+	}
+}
+
+func zmqTestPublishHelper(conn net.Conn, topic zmq.Topic, data string) {
+	zmqTestWriteFrameHelper(conn, 0, []byte(string(topic)+":"+data))
+}
+
+func newTestZmqSubscriber(t *testing.T, p *zmqTestPublisher, topics []zmq.Topic) (*zmq.Subscriber, context.CancelFunc) {
+	s, err := zmq.NewSubscriber(zmq.Config{Address: "tcp://" + p.listener.Addr().String(), Topics: topics, ReconnectInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.Run(ctx)
+	return s, cancel
+}
+
+const zmqTestFullTx = `{
+	"version": 2,
+	"unlock_time": 0,
+	"inputs": [{"to_key": {"amount": 0, "key_offsets": [101453094, 1233454, 72221], "key_image": "3b5db5cc5c4f5b05c6ddca8e3bd30d2cd8e56c9a6dc6ec1ae3a9c5bd25a7da57"}}],
+	"outputs": [
+		{"amount": 0, "to_tagged_key": {"key": "2dee302749c79fef24c6129397308a35844de5710674c4ddfacc71eb00e5e1ef", "view_tag": "4c"}}
+	],
+	"extra": "01a3b5",
+	"signatures": [],
+	"ringct": {
+		"type": 6,
+		"encrypted": [{"mask": "0000000000000000000000000000000000000000000000000000000000000000", "amount": "401150d04a7559e80000000000000000000000000000000000000000000000"}],
+		"commitments": ["9f00a45bd1925d25b9fd031f494b1aeb1e091a28d2d98d61c4391a3163195158"],
+		"fee": 122960000,
+		"prunable": {
+			"range_proofs": [],
+			"bulletproofs": [],
+			"bulletproofs_plus": [{"V": [], "A": "aa", "A1": "a1", "B": "bb", "r1": "r1", "s1": "s1", "d1": "d1", "L": ["l"], "R": ["r"]}],
+			"mlsags": [],
+			"clsags": [{"s": ["s0"], "c1": "c1", "D": "dd"}],
+			"pseudo_outs": ["pp"]
+		}
+	}
+}`
+
+func TestZmqSubscriber(t *testing.T) {
+	p := newZmqTestPublisher(t, 4)
+	defer p.listener.Close()
+
+	s, cancel := newTestZmqSubscriber(t, p, nil)
+	conn := p.nextConnHelper(t)
+
+	zmqTestPublishHelper(conn, zmq.TOPIC_JSON_MINIMAL_CHAIN_MAIN, `{"first_height": 3200000, "first_prev_id": "aa", "ids": ["bb", "cc"]}`)
+	zmqTestPublishHelper(conn, zmq.TOPIC_JSON_MINIMAL_TXPOOL_ADD, `[{"id": "dd", "blob_size": 1500, "weight": 1500, "fee": 30000}]`)
+	zmqTestPublishHelper(conn, zmq.TOPIC_JSON_FULL_TXPOOL_ADD, "["+zmqTestFullTx+"]")
+	zmqTestPublishHelper(conn, zmq.TOPIC_JSON_FULL_CHAIN_MAIN, `[{
+		"major_version": 16,
+		"minor_version": 16,
+		"timestamp": 1720000000,
+		"prev_id": "aa",
+		"nonce": 12345,
+		"miner_tx": {
+			"version": 2,
+			"unlock_time": 3200060,
+			"inputs": [{"gen": {"height": 3200000}}],
+			"outputs": [{"amount": 600000000000, "to_tagged_key": {"key": "ee", "view_tag": "01"}}],
+			"extra": [1, 2, 3],
+			"signatures": [],
+			"ringct": {"type": 0}
+		},
+		"tx_hashes": ["dd"]
+	}]`)
+
+	assert.Equal(t, &zmq.MinimalChainMain{FirstHeight: 3200000, FirstPrevId: "aa", Ids: []string{"bb", "cc"}}, <-s.ChainMain())
+	assert.Equal(t, []zmq.MinimalTxPoolAdd{{Id: "dd", BlobSize: 1500, Weight: 1500, Fee: 30000}}, <-s.TxPoolAdd())
+
+	txs := <-s.FullTxPoolAdd()
+	if assert.Len(t, txs, 1) {
+		assert.Equal(t, daemon.MoneroTxInfo{
+			Version: 2,
+			Vin:     []daemon.Vin2{{Key: daemon.Key{KeyOffsets: []int64{101453094, 1233454, 72221}, KeyImage: "3b5db5cc5c4f5b05c6ddca8e3bd30d2cd8e56c9a6dc6ec1ae3a9c5bd25a7da57"}}},
+			Vout:    []daemon.Vout1{{Target: daemon.Target{TaggedKey: daemon.TaggedKey{Key: "2dee302749c79fef24c6129397308a35844de5710674c4ddfacc71eb00e5e1ef", ViewTag: "4c"}}}},
+			Extra:   []byte{0x01, 0xa3, 0xb5},
+			RctSignatures: daemon.RctSignature{
+				Type:     6,
+				TxnFee:   122960000,
+				EcdhInfo: []daemon.EcdhInfo{{TruncAmount: "401150d04a7559e8"}},
+				OutPk:    []string{"9f00a45bd1925d25b9fd031f494b1aeb1e091a28d2d98d61c4391a3163195158"},
+			},
+			RctsigPrunable: daemon.RctsigPrunable{
+				CLSAGs:     []daemon.CLSAG{{D: "dd", C1: "c1", S: []string{"s0"}}},
+				Bpp:        []daemon.Bpp{{A: "aa", A1: "a1", B: "bb", L: []string{"l"}, R: []string{"r"}, R1: "r1", D1: "d1", S1: "s1"}},
+				Nbp:        1,
+				PseudoOuts: []string{"pp"},
+			},
+		}, txs[0])
+	}
+
+	blocks := <-s.FullChainMain()
+	if assert.Len(t, blocks, 1) {
+		assert.Equal(t, daemon.BlockDetails{
+			MajorVersion: 16,
+			MinorVersion: 16,
+			Timestamp:    1720000000,
+			PrevId:       "aa",
+			Nonce:        12345,
+			MinerTx: daemon.MinerTx{
+				Version:    2,
+				UnlockTime: 3200060,
+				Vin:        []daemon.Vin1{{Gen: daemon.Gen{Height: 3200000}}},
+				Vout:       []daemon.Vout1{{Amount: 600000000000, Target: daemon.Target{TaggedKey: daemon.TaggedKey{Key: "ee", ViewTag: "01"}}}},
+				Extra:      []int32{1, 2, 3},
+			},
+			TxHashes: []string{"dd"},
+		}, blocks[0])
+	}
+
+	// the malformed notifications are reported and skipped
+	zmqTestPublishHelper(conn, zmq.TOPIC_JSON_MINIMAL_CHAIN_MAIN, `{"first_height": "x"}`)
+	assert.Error(t, <-s.Errors())
+
+	cancel()
+	_, ok := <-s.ChainMain()
+	assert.False(t, ok)
+	assert.ErrorIs(t, s.Run(context.Background()), zmq.ErrSubscriberStarted)
+}
+
+func TestZmqSubscriberReconnect(t *testing.T) {
+	p := newZmqTestPublisher(t, 1)
+	defer p.listener.Close()
+
+	s, cancel := newTestZmqSubscriber(t, p, []zmq.Topic{zmq.TOPIC_JSON_MINIMAL_TXPOOL_ADD})
+	defer cancel()
+
+	conn := p.nextConnHelper(t)
+	zmqTestPublishHelper(conn, zmq.TOPIC_JSON_MINIMAL_TXPOOL_ADD, `[{"id": "aa"}]`)
+	assert.Equal(t, "aa", (<-s.TxPoolAdd())[0].Id)
+	conn.Close()
+
+	// the lost connection is reported and reestablished
+	assert.Error(t, <-s.Errors())
+	conn = p.nextConnHelper(t)
+	defer conn.Close()
+	zmqTestPublishHelper(conn, zmq.TOPIC_JSON_MINIMAL_TXPOOL_ADD, `[{"id": "bb"}]`)
+	assert.Equal(t, "bb", (<-s.TxPoolAdd())[0].Id)
+}
+
+func TestZmqSubscriberConfig(t *testing.T) {
+	_, err := zmq.NewSubscriber(zmq.Config{Address: "udp://127.0.0.1:18083"})
+	assert.ErrorIs(t, err, zmq.ErrUnsupportedAddress)
+
+	_, err = zmq.NewSubscriber(zmq.Config{Address: "127.0.0.1:18083", Topics: []zmq.Topic{"json-minimal-txpool_remove"}})
+	assert.ErrorIs(t, err, zmq.ErrUnknownTopic)
+
+	_, err = zmq.NewSubscriber(zmq.Config{Address: "ipc:///tmp/monerod.sock"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,191 @@
+package tx
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+)
+
+type BlockHeader struct {
+	MajorVersion uint64
+	MinorVersion uint64
+	Timestamp    uint64
+	PrevId       Hash
+	Nonce        uint32
+}
+
+// Block is a decoded binary block. TxHashes doesn't include the miner tx.
+type Block struct {
+	BlockHeader
+	MinerTx  *Transaction
+	TxHashes []Hash
+
+	header []byte
+}
+
+func (r *reader) hash() (Hash, error) {
+	k, err := r.key()
+	return Hash(k), err
+}
+
+func (h *BlockHeader) parseHelper(r *reader) error {
+	var err error
+	if h.MajorVersion, err = r.varint(); err != nil {
+		return err
+	}
+	if h.MinorVersion, err = r.varint(); err != nil {
+		return err
+	}
+	if h.Timestamp, err = r.varint(); err != nil {
+		return err
+	}
+	if h.PrevId, err = r.hash(); err != nil {
+		return err
+	}
+	if h.Nonce, err = r.uint32(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Decodes a binary block (e.g. GetBlock's Blob)
+func ParseBlock(blob []byte) (*Block, error) {
+	r := &reader{data: blob}
+	b := &Block{}
+
+	if err := b.BlockHeader.parseHelper(r); err != nil {
+		return nil, err
+	}
+	b.header = append([]byte(nil), blob[:r.pos]...)
+
+	var err error
+	if b.MinerTx, err = parseTransactionHelper(r, false); err != nil {
+		return nil, err
+	}
+
+	n, err := r.count(HASH_SIZE)
+	if err != nil {
+		return nil, err
+	}
+	b.TxHashes = make([]Hash, n)
+	for i := range b.TxHashes {
+		if b.TxHashes[i], err = r.hash(); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.pos != len(r.data) {
+		return nil, r.errorHelper(ErrTrailingData)
+	}
+
+	return b, nil
+}
+
+// Decodes a hex encoded binary block
+func ParseBlockHex(blob string) (*Block, error) {
+	data, err := hex.DecodeString(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseBlock(data)
+}
+
+func hashPairHelper(a, b Hash) (Hash, error) {
+	data := make([]byte, 0, 2*HASH_SIZE)
+	data = append(data, a[:]...)
+	data = append(data, b[:]...)
+
+	return keccak256HashHelper(data)
+}
+
+// Computes the Merkle tree hash the same way Monero does (crypto/tree-hash.c)
+func TreeHash(hashes []Hash) (Hash, error) {
+	switch len(hashes) {
+	case 0:
+		return Hash{}, errors.New("can't compute the tree hash of zero hashes")
+	case 1:
+		return hashes[0], nil
+	case 2:
+		return hashPairHelper(hashes[0], hashes[1])
+	}
+
+	/**
+		cnt is the largest power of two less than the number of hashes.
+		The first 2*cnt-count hashes are taken as is and the rest are hashed in pairs,
+		so the next level has exactly cnt hashes, which are then halved until two are left.
+	**/
+	cnt := 2
+	for cnt < len(hashes) {
+		cnt <<= 1
+	}
+	cnt >>= 1
+
+	ints := make([]Hash, cnt)
+	offset := 2*cnt - len(hashes)
+	copy(ints, hashes[:offset])
+
+	var err error
+	for i, j := offset, offset; j < cnt; i, j = i+2, j+1 {
+		if ints[j], err = hashPairHelper(hashes[i], hashes[i+1]); err != nil {
+			return Hash{}, err
+		}
+	}
+
+	for cnt > 2 {
+		cnt >>= 1
+		for i, j := 0, 0; j < cnt; i, j = i+2, j+1 {
+			if ints[j], err = hashPairHelper(ints[i], ints[i+1]); err != nil {
+				return Hash{}, err
+			}
+		}
+	}
+
+	return hashPairHelper(ints[0], ints[1])
+}
+
+// Returns the height stored in the coinbase input of the miner tx
+func (b *Block) Height() (uint64, error) {
+	if len(b.MinerTx.Vin) != 1 || b.MinerTx.Vin[0].Gen == nil {
+		return 0, errors.New("the miner tx doesn't have a coinbase input")
+	}
+
+	return b.MinerTx.Vin[0].Gen.Height, nil
+}
+
+// Returns the blob the block id is computed from (the same as GetBlockTemplate's BlockhashingBlob):
+// header || tree hash of the miner tx and the block txs || varint(number of txs including the miner tx).
+func (b *Block) HashingBlob() ([]byte, error) {
+	minerTxHash, err := b.MinerTx.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := TreeHash(append([]Hash{minerTxHash}, b.TxHashes...))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, 0, len(b.header)+HASH_SIZE+binary.MaxVarintLen64)
+	res = append(res, b.header...)
+	res = append(res, root[:]...)
+	res = binary.AppendUvarint(res, uint64(len(b.TxHashes)+1))
+
+	return res, nil
+}
+
+// Computes the block id: H(varint(len(hashing blob)) || hashing blob).
+// Note that monerod hardcodes the id of the mainnet block 202612, whose tree hash was computed with a bug,
+// so the value returned for that block differs from the one the daemon reports.
+func (b *Block) Hash() (Hash, error) {
+	blob, err := b.HashingBlob()
+	if err != nil {
+		return Hash{}, err
+	}
+
+	data := binary.AppendUvarint(nil, uint64(len(blob)))
+	data = append(data, blob...)
+
+	return keccak256HashHelper(data)
+}
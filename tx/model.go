@@ -0,0 +1,170 @@
+package tx
+
+import (
+	"encoding/hex"
+)
+
+const (
+	HASH_SIZE int = 32
+	KEY_SIZE  int = 32
+
+	TXIN_GEN                  byte = 0xff
+	TXIN_TO_SCRIPT            byte = 0x00
+	TXIN_TO_SCRIPTHASH        byte = 0x01
+	TXIN_TO_KEY               byte = 0x02
+	TXOUT_TO_SCRIPT           byte = 0x00
+	TXOUT_TO_SCRIPTHASH       byte = 0x01
+	TXOUT_TO_KEY              byte = 0x02
+	TXOUT_TO_TAGGED_KEY       byte = 0x03
+	RCT_TYPE_NULL             byte = 0
+	RCT_TYPE_FULL             byte = 1
+	RCT_TYPE_SIMPLE           byte = 2
+	RCT_TYPE_BULLETPROOF      byte = 3
+	RCT_TYPE_BULLETPROOF2     byte = 4
+	RCT_TYPE_CLSAG            byte = 5
+	RCT_TYPE_BULLETPROOF_PLUS byte = 6
+
+	// the number of the Borromean ring signatures in a pre-Bulletproof range proof
+	BORROMEAN_SIZE int = 64
+	// the size of a pre-RingCT ring signature element (c, r)
+	SIGNATURE_SIZE int = 64
+)
+
+type Hash [HASH_SIZE]byte
+
+func (h Hash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+type Key [KEY_SIZE]byte
+
+func (k Key) String() string {
+	return hex.EncodeToString(k[:])
+}
+
+// Coinbase input
+type InputGen struct {
+	Height uint64
+}
+
+type InputToKey struct {
+	Amount uint64
+	// relative global output indices of the ring members
+	KeyOffsets []uint64
+	KeyImage   Key
+}
+
+// Exactly one of the fields is set
+type Input struct {
+	Gen *InputGen
+	Key *InputToKey
+}
+
+type Output struct {
+	Amount uint64
+	Key    Key
+	// set for the txout_to_tagged_key outputs only
+	ViewTag *byte
+}
+
+// Pre-RingCT ring signature element
+type Signature struct {
+	C Key
+	R Key
+}
+
+// For the types >= RCT_TYPE_BULLETPROOF2 only the first 8 bytes of Amount are used and Mask is zero
+type EcdhInfo struct {
+	Mask   Key
+	Amount Key
+}
+
+type RctSigBase struct {
+	Type   byte
+	TxnFee uint64
+	// RCT_TYPE_SIMPLE only, the newer types keep them in the prunable part
+	PseudoOuts []Key
+	EcdhInfo   []EcdhInfo
+	OutPk      []Key
+}
+
+type BoroSig struct {
+	S0 [BORROMEAN_SIZE]Key
+	S1 [BORROMEAN_SIZE]Key
+	Ee Key
+}
+
+type RangeSig struct {
+	Asig BoroSig
+	Ci   [BORROMEAN_SIZE]Key
+}
+
+// A2, B and T are the scalars a, b and t
+type Bulletproof struct {
+	A    Key
+	S    Key
+	T1   Key
+	T2   Key
+	Taux Key
+	Mu   Key
+	L    []Key
+	R    []Key
+	A2   Key
+	B    Key
+	T    Key
+}
+
+type BulletproofPlus struct {
+	A  Key
+	A1 Key
+	B  Key
+	R1 Key
+	S1 Key
+	D1 Key
+	L  []Key
+	R  []Key
+}
+
+type MgSig struct {
+	Ss [][]Key
+	Cc Key
+}
+
+type Clsag struct {
+	S  []Key
+	C1 Key
+	D  Key
+}
+
+type RctSigPrunable struct {
+	RangeSigs        []RangeSig
+	Bulletproofs     []Bulletproof
+	BulletproofsPlus []BulletproofPlus
+	MGs              []MgSig
+	CLSAGs           []Clsag
+	PseudoOuts       []Key
+}
+
+type RctSignatures struct {
+	RctSigBase
+	// nil if the tx is pruned
+	Prunable *RctSigPrunable
+}
+
+// Transaction is a decoded binary tx.
+// Version 1 txs have Signatures set, version 2 ones have RctSignatures set.
+type Transaction struct {
+	Version       uint64
+	UnlockTime    uint64
+	Vin           []Input
+	Vout          []Output
+	Extra         []byte
+	Signatures    [][]Signature
+	RctSignatures *RctSignatures
+	// the prunable part (signatures and range proofs) isn't present in the blob
+	Pruned bool
+
+	blob      []byte
+	prefixEnd int
+	baseEnd   int
+}
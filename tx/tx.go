@@ -0,0 +1,699 @@
+package tx
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/chekist32/go-monero/utils"
+)
+
+var (
+	ErrUnexpectedEOF  = errors.New("unexpected end of blob")
+	ErrInvalidVarint  = errors.New("invalid varint")
+	ErrTrailingData   = errors.New("trailing data after the tx")
+	ErrPrunedTx       = errors.New("the tx is pruned, its prunable part is unknown")
+	ErrUnsupportedTag = errors.New("unsupported variant tag")
+)
+
+// ParseError is returned when the blob is malformed.
+// Offset is the position of the byte the parsing failed at.
+type ParseError struct {
+	Offset int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("invalid tx blob at byte %d: %v", e.Offset, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Reads the Monero binary serialization format
+type reader struct {
+	data []byte
+	pos  int
+}
+
+func (r *reader) errorHelper(err error) error {
+	return &ParseError{Offset: r.pos, Err: err}
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, r.errorHelper(ErrUnexpectedEOF)
+	}
+
+	b := r.data[r.pos]
+	r.pos++
+
+	return b, nil
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n < 0 || len(r.data)-r.pos < n {
+		return nil, r.errorHelper(ErrUnexpectedEOF)
+	}
+
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+
+	return b, nil
+}
+
+func (r *reader) varint() (uint64, error) {
+	var res uint64
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		if r.pos+i >= len(r.data) {
+			return 0, &ParseError{Offset: r.pos + i, Err: ErrUnexpectedEOF}
+		}
+
+		b := r.data[r.pos+i]
+		if (i == binary.MaxVarintLen64-1 && b > 1) || (i > 0 && b == 0) {
+			// overflow or non canonical representation
+			return 0, r.errorHelper(ErrInvalidVarint)
+		}
+
+		res |= uint64(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			r.pos += i + 1
+			return res, nil
+		}
+	}
+
+	return 0, r.errorHelper(ErrInvalidVarint)
+}
+
+func (r *reader) uint32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// Reads a varint that is used as an element count, each element takes at least elemSize bytes
+func (r *reader) count(elemSize int) (int, error) {
+	start := r.pos
+
+	n, err := r.varint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(r.data)-r.pos)/uint64(elemSize) {
+		return 0, &ParseError{Offset: start, Err: fmt.Errorf("invalid element count: %d", n)}
+	}
+
+	return int(n), nil
+}
+
+func (r *reader) key() (Key, error) {
+	var k Key
+
+	b, err := r.bytes(KEY_SIZE)
+	if err != nil {
+		return k, err
+	}
+	copy(k[:], b)
+
+	return k, nil
+}
+
+func (r *reader) keys(n int) ([]Key, error) {
+	if n > (len(r.data)-r.pos)/KEY_SIZE {
+		return nil, r.errorHelper(ErrUnexpectedEOF)
+	}
+
+	res := make([]Key, n)
+	for i := range res {
+		copy(res[i][:], r.data[r.pos:r.pos+KEY_SIZE])
+		r.pos += KEY_SIZE
+	}
+
+	return res, nil
+}
+
+// Reads a varint prefixed vector of keys
+func (r *reader) keyVector() ([]Key, error) {
+	n, err := r.count(KEY_SIZE)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.keys(n)
+}
+
+func (r *reader) keyArray(res []Key) error {
+	for i := range res {
+		k, err := r.key()
+		if err != nil {
+			return err
+		}
+		res[i] = k
+	}
+
+	return nil
+}
+
+func parseInputHelper(r *reader) (Input, error) {
+	tagPos := r.pos
+	tag, err := r.byte()
+	if err != nil {
+		return Input{}, err
+	}
+
+	switch tag {
+	case TXIN_GEN:
+		height, err := r.varint()
+		if err != nil {
+			return Input{}, err
+		}
+		return Input{Gen: &InputGen{Height: height}}, nil
+
+	case TXIN_TO_KEY:
+		in := &InputToKey{}
+		if in.Amount, err = r.varint(); err != nil {
+			return Input{}, err
+		}
+
+		n, err := r.count(1)
+		if err != nil {
+			return Input{}, err
+		}
+		in.KeyOffsets = make([]uint64, n)
+		for i := range in.KeyOffsets {
+			if in.KeyOffsets[i], err = r.varint(); err != nil {
+				return Input{}, err
+			}
+		}
+
+		if in.KeyImage, err = r.key(); err != nil {
+			return Input{}, err
+		}
+		return Input{Key: in}, nil
+
+	default:
+		return Input{}, &ParseError{Offset: tagPos, Err: fmt.Errorf("%w: input %#02x", ErrUnsupportedTag, tag)}
+	}
+}
+
+func parseOutputHelper(r *reader) (Output, error) {
+	var out Output
+
+	amount, err := r.varint()
+	if err != nil {
+		return out, err
+	}
+	out.Amount = amount
+
+	tagPos := r.pos
+	tag, err := r.byte()
+	if err != nil {
+		return out, err
+	}
+
+	switch tag {
+	case TXOUT_TO_KEY:
+		if out.Key, err = r.key(); err != nil {
+			return out, err
+		}
+	case TXOUT_TO_TAGGED_KEY:
+		if out.Key, err = r.key(); err != nil {
+			return out, err
+		}
+		viewTag, err := r.byte()
+		if err != nil {
+			return out, err
+		}
+		out.ViewTag = &viewTag
+	default:
+		return out, &ParseError{Offset: tagPos, Err: fmt.Errorf("%w: output %#02x", ErrUnsupportedTag, tag)}
+	}
+
+	return out, nil
+}
+
+func (t *Transaction) parsePrefixHelper(r *reader) error {
+	var err error
+	if t.Version, err = r.varint(); err != nil {
+		return err
+	}
+	if t.Version != 1 && t.Version != 2 {
+		return &ParseError{Offset: 0, Err: fmt.Errorf("unsupported tx version: %d", t.Version)}
+	}
+	if t.UnlockTime, err = r.varint(); err != nil {
+		return err
+	}
+
+	n, err := r.count(1)
+	if err != nil {
+		return err
+	}
+	t.Vin = make([]Input, n)
+	for i := range t.Vin {
+		if t.Vin[i], err = parseInputHelper(r); err != nil {
+			return err
+		}
+	}
+
+	n, err = r.count(1)
+	if err != nil {
+		return err
+	}
+	t.Vout = make([]Output, n)
+	for i := range t.Vout {
+		if t.Vout[i], err = parseOutputHelper(r); err != nil {
+			return err
+		}
+	}
+
+	n, err = r.count(1)
+	if err != nil {
+		return err
+	}
+	extra, err := r.bytes(n)
+	if err != nil {
+		return err
+	}
+	t.Extra = append([]byte(nil), extra...)
+
+	return nil
+}
+
+// Returns the number of the ring members, which is the same for all the inputs of a RingCT tx
+func (t *Transaction) ringSizeHelper() int {
+	if len(t.Vin) == 0 || t.Vin[0].Key == nil {
+		return 0
+	}
+
+	return len(t.Vin[0].Key.KeyOffsets)
+}
+
+func (t *Transaction) parseSignaturesHelper(r *reader) error {
+	t.Signatures = make([][]Signature, len(t.Vin))
+	for i, in := range t.Vin {
+		if in.Key == nil {
+			continue
+		}
+
+		t.Signatures[i] = make([]Signature, len(in.Key.KeyOffsets))
+		for j := range t.Signatures[i] {
+			b, err := r.bytes(SIGNATURE_SIZE)
+			if err != nil {
+				return err
+			}
+			copy(t.Signatures[i][j].C[:], b[:KEY_SIZE])
+			copy(t.Signatures[i][j].R[:], b[KEY_SIZE:])
+		}
+	}
+
+	return nil
+}
+
+func (t *Transaction) parseRctSigBaseHelper(r *reader) (*RctSigBase, error) {
+	typePos := r.pos
+	rctType, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	base := &RctSigBase{Type: rctType}
+	if rctType == RCT_TYPE_NULL {
+		return base, nil
+	}
+	if rctType > RCT_TYPE_BULLETPROOF_PLUS {
+		return nil, &ParseError{Offset: typePos, Err: fmt.Errorf("unsupported RingCT type: %d", rctType)}
+	}
+
+	if base.TxnFee, err = r.varint(); err != nil {
+		return nil, err
+	}
+
+	if rctType == RCT_TYPE_SIMPLE {
+		if base.PseudoOuts, err = r.keys(len(t.Vin)); err != nil {
+			return nil, err
+		}
+	}
+
+	base.EcdhInfo = make([]EcdhInfo, len(t.Vout))
+	for i := range base.EcdhInfo {
+		if rctType >= RCT_TYPE_BULLETPROOF2 {
+			b, err := r.bytes(8)
+			if err != nil {
+				return nil, err
+			}
+			copy(base.EcdhInfo[i].Amount[:], b)
+			continue
+		}
+
+		if base.EcdhInfo[i].Mask, err = r.key(); err != nil {
+			return nil, err
+		}
+		if base.EcdhInfo[i].Amount, err = r.key(); err != nil {
+			return nil, err
+		}
+	}
+
+	if base.OutPk, err = r.keys(len(t.Vout)); err != nil {
+		return nil, err
+	}
+
+	return base, nil
+}
+
+func parseBulletproofHelper(r *reader) (Bulletproof, error) {
+	var bp Bulletproof
+
+	for _, k := range []*Key{&bp.A, &bp.S, &bp.T1, &bp.T2, &bp.Taux, &bp.Mu} {
+		v, err := r.key()
+		if err != nil {
+			return bp, err
+		}
+		*k = v
+	}
+
+	var err error
+	if bp.L, err = r.keyVector(); err != nil {
+		return bp, err
+	}
+	if bp.R, err = r.keyVector(); err != nil {
+		return bp, err
+	}
+
+	for _, k := range []*Key{&bp.A2, &bp.B, &bp.T} {
+		v, err := r.key()
+		if err != nil {
+			return bp, err
+		}
+		*k = v
+	}
+
+	return bp, nil
+}
+
+func parseBulletproofPlusHelper(r *reader) (BulletproofPlus, error) {
+	var bp BulletproofPlus
+
+	for _, k := range []*Key{&bp.A, &bp.A1, &bp.B, &bp.R1, &bp.S1, &bp.D1} {
+		v, err := r.key()
+		if err != nil {
+			return bp, err
+		}
+		*k = v
+	}
+
+	var err error
+	if bp.L, err = r.keyVector(); err != nil {
+		return bp, err
+	}
+	if bp.R, err = r.keyVector(); err != nil {
+		return bp, err
+	}
+
+	return bp, nil
+}
+
+func (t *Transaction) parseRctSigPrunableHelper(r *reader, rctType byte) (*RctSigPrunable, error) {
+	prunable := &RctSigPrunable{}
+	ringSize := t.ringSizeHelper()
+
+	switch rctType {
+	case RCT_TYPE_BULLETPROOF_PLUS:
+		n, err := r.count(6 * KEY_SIZE)
+		if err != nil {
+			return nil, err
+		}
+		prunable.BulletproofsPlus = make([]BulletproofPlus, n)
+		for i := range prunable.BulletproofsPlus {
+			if prunable.BulletproofsPlus[i], err = parseBulletproofPlusHelper(r); err != nil {
+				return nil, err
+			}
+		}
+
+	case RCT_TYPE_BULLETPROOF, RCT_TYPE_BULLETPROOF2, RCT_TYPE_CLSAG:
+		var n int
+		if rctType == RCT_TYPE_BULLETPROOF {
+			// the first Bulletproof txs used a fixed size count
+			v, err := r.uint32()
+			if err != nil {
+				return nil, err
+			}
+			if uint64(v) > uint64(len(r.data)-r.pos)/uint64(9*KEY_SIZE) {
+				return nil, &ParseError{Offset: r.pos - 4, Err: fmt.Errorf("invalid element count: %d", v)}
+			}
+			n = int(v)
+		} else {
+			v, err := r.count(9 * KEY_SIZE)
+			if err != nil {
+				return nil, err
+			}
+			n = v
+		}
+
+		prunable.Bulletproofs = make([]Bulletproof, n)
+		for i := range prunable.Bulletproofs {
+			bp, err := parseBulletproofHelper(r)
+			if err != nil {
+				return nil, err
+			}
+			prunable.Bulletproofs[i] = bp
+		}
+
+	default:
+		prunable.RangeSigs = make([]RangeSig, len(t.Vout))
+		for i := range prunable.RangeSigs {
+			rs := &prunable.RangeSigs[i]
+			if err := r.keyArray(rs.Asig.S0[:]); err != nil {
+				return nil, err
+			}
+			if err := r.keyArray(rs.Asig.S1[:]); err != nil {
+				return nil, err
+			}
+			ee, err := r.key()
+			if err != nil {
+				return nil, err
+			}
+			rs.Asig.Ee = ee
+			if err := r.keyArray(rs.Ci[:]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if rctType == RCT_TYPE_CLSAG || rctType == RCT_TYPE_BULLETPROOF_PLUS {
+		prunable.CLSAGs = make([]Clsag, len(t.Vin))
+		for i := range prunable.CLSAGs {
+			var err error
+			if prunable.CLSAGs[i].S, err = r.keys(ringSize); err != nil {
+				return nil, err
+			}
+			if prunable.CLSAGs[i].C1, err = r.key(); err != nil {
+				return nil, err
+			}
+			if prunable.CLSAGs[i].D, err = r.key(); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		// RCT_TYPE_FULL has a single MLSAG over all the inputs
+		mgs, cols := len(t.Vin), 2
+		if rctType == RCT_TYPE_FULL {
+			mgs, cols = 1, len(t.Vin)+1
+		}
+
+		prunable.MGs = make([]MgSig, mgs)
+		for i := range prunable.MGs {
+			prunable.MGs[i].Ss = make([][]Key, ringSize)
+			for j := range prunable.MGs[i].Ss {
+				ss, err := r.keys(cols)
+				if err != nil {
+					return nil, err
+				}
+				prunable.MGs[i].Ss[j] = ss
+			}
+
+			cc, err := r.key()
+			if err != nil {
+				return nil, err
+			}
+			prunable.MGs[i].Cc = cc
+		}
+	}
+
+	if rctType >= RCT_TYPE_BULLETPROOF {
+		var err error
+		if prunable.PseudoOuts, err = r.keys(len(t.Vin)); err != nil {
+			return nil, err
+		}
+	}
+
+	return prunable, nil
+}
+
+// Decodes a tx starting at the current reader position.
+// If allowPruned is set, a blob that ends right after the prunable part would start is treated as a pruned tx.
+func parseTransactionHelper(r *reader, allowPruned bool) (*Transaction, error) {
+	start := r.pos
+	t := &Transaction{}
+
+	if err := t.parsePrefixHelper(r); err != nil {
+		return nil, err
+	}
+	t.prefixEnd = r.pos - start
+
+	if t.Version == 1 {
+		t.baseEnd = t.prefixEnd
+		if allowPruned && r.pos == len(r.data) && t.ringSizeHelper() != 0 {
+			t.Pruned = true
+		} else if err := t.parseSignaturesHelper(r); err != nil {
+			return nil, err
+		}
+	} else {
+		base, err := t.parseRctSigBaseHelper(r)
+		if err != nil {
+			return nil, err
+		}
+		t.RctSignatures = &RctSignatures{RctSigBase: *base}
+		t.baseEnd = r.pos - start
+
+		if base.Type != RCT_TYPE_NULL {
+			if allowPruned && r.pos == len(r.data) {
+				t.Pruned = true
+			} else if t.RctSignatures.Prunable, err = t.parseRctSigPrunableHelper(r, base.Type); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	t.blob = append([]byte(nil), r.data[start:r.pos]...)
+
+	return t, nil
+}
+
+// Decodes a binary tx. Both the full and the pruned blobs are supported.
+func ParseTransaction(blob []byte) (*Transaction, error) {
+	r := &reader{data: blob}
+
+	t, err := parseTransactionHelper(r, true)
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(r.data) {
+		return nil, r.errorHelper(ErrTrailingData)
+	}
+
+	return t, nil
+}
+
+// Decodes a hex encoded binary tx (e.g. GetTransactions' AsHex or PrunedAsHex)
+func ParseTransactionHex(blob string) (*Transaction, error) {
+	data, err := hex.DecodeString(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseTransaction(data)
+}
+
+func keccak256HashHelper(data []byte) (Hash, error) {
+	var h Hash
+
+	hash, err := utils.Keccak256Hash(data)
+	if err != nil {
+		return h, err
+	}
+	copy(h[:], hash)
+
+	return h, nil
+}
+
+// Returns the blob the tx has been decoded from
+func (t *Transaction) Blob() []byte {
+	return t.blob
+}
+
+// Returns the hash of the tx prefix (everything but the signatures)
+func (t *Transaction) PrefixHash() (Hash, error) {
+	return keccak256HashHelper(t.blob[:t.prefixEnd])
+}
+
+// Returns the hash of the prunable part. Version 2 txs only.
+func (t *Transaction) PrunableHash() (Hash, error) {
+	if t.Version == 1 {
+		return Hash{}, errors.New("version 1 txs don't have a prunable hash")
+	}
+	if t.RctSignatures.Type == RCT_TYPE_NULL {
+		return Hash{}, nil
+	}
+	if t.Pruned {
+		return Hash{}, ErrPrunedTx
+	}
+
+	return keccak256HashHelper(t.blob[t.baseEnd:])
+}
+
+// Computes the tx hash. Pruned txs need HashWithPrunableHash.
+func (t *Transaction) Hash() (Hash, error) {
+	if t.Version == 1 {
+		if t.Pruned {
+			return Hash{}, ErrPrunedTx
+		}
+		return keccak256HashHelper(t.blob)
+	}
+
+	prunable, err := t.PrunableHash()
+	if err != nil {
+		return Hash{}, err
+	}
+
+	return t.HashWithPrunableHash(prunable)
+}
+
+// Computes the hash of a version 2 tx using the given prunable hash (e.g. GetTransactions' PrunableHash),
+// so it works for pruned txs as well.
+func (t *Transaction) HashWithPrunableHash(prunable Hash) (Hash, error) {
+	if t.Version == 1 {
+		return Hash{}, errors.New("version 1 txs are hashed as a whole")
+	}
+
+	/** H(H(prefix) || H(rct base) || H(rct prunable)) **/
+	prefix, err := t.PrefixHash()
+	if err != nil {
+		return Hash{}, err
+	}
+
+	base, err := keccak256HashHelper(t.blob[t.prefixEnd:t.baseEnd])
+	if err != nil {
+		return Hash{}, err
+	}
+
+	data := make([]byte, 0, 3*HASH_SIZE)
+	data = append(data, prefix[:]...)
+	data = append(data, base[:]...)
+	data = append(data, prunable[:]...)
+
+	return keccak256HashHelper(data)
+}
+
+// Parses the extra field of the tx
+func (t *Transaction) ParsedExtra() (*utils.TxExtra, error) {
+	return utils.ParseTxExtra(t.Extra)
+}
+
+// Returns the encrypted amount of the output in the same hex form as the daemon's JSON (amount or trunc_amount)
+func (t *Transaction) EncryptedAmount(outIndex int) string {
+	if t.RctSignatures == nil || outIndex >= len(t.RctSignatures.EcdhInfo) {
+		return ""
+	}
+
+	amount := t.RctSignatures.EcdhInfo[outIndex].Amount
+	if t.RctSignatures.Type >= RCT_TYPE_BULLETPROOF2 {
+		return hex.EncodeToString(amount[:8])
+	}
+
+	return hex.EncodeToString(amount[:])
+}
@@ -0,0 +1,402 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	EXTRA_NONCE_PAYMENT_ID           byte = 0x00
+	EXTRA_NONCE_ENCRYPTED_PAYMENT_ID byte = 0x01
+
+	EXTRA_PADDING_MAX_SIZE int = 255
+	EXTRA_NONCE_MAX_SIZE   int = 255
+	PAYMENT_ID_SIZE        int = 32
+	SHORT_PAYMENT_ID_SIZE  int = 8
+)
+
+var (
+	ErrInvalidVarint = errors.New("invalid varint")
+	ErrExtraTooShort = errors.New("unexpected end of extra")
+)
+
+// ExtraError is returned when the extra field is malformed.
+// Offset is the position of the byte the parsing failed at.
+type ExtraError struct {
+	Offset int
+	Err    error
+}
+
+func (e *ExtraError) Error() string {
+	return fmt.Sprintf("invalid extra at byte %d: %v", e.Offset, e.Err)
+}
+
+func (e *ExtraError) Unwrap() error {
+	return e.Err
+}
+
+// TxExtraField is a single tag-length-value entry of the tx extra field.
+type TxExtraField interface {
+	// Returns the tag the field is prefixed with
+	Tag() byte
+	// Serializes the field including the tag
+	Bytes() []byte
+}
+
+// Zero padding (tag 0x00). Size includes the tag byte. Padding can only be the last field.
+type TxExtraPadding struct {
+	Size int
+}
+
+func (f *TxExtraPadding) Tag() byte {
+	return EXTRA_TAG_PADDING
+}
+
+func (f *TxExtraPadding) Bytes() []byte {
+	return make([]byte, f.Size)
+}
+
+// Tx public key (tag 0x01)
+type TxExtraPubKey struct {
+	Key *PublicKey
+}
+
+func (f *TxExtraPubKey) Tag() byte {
+	return EXTRA_TAG_PUBKEY
+}
+
+func (f *TxExtraPubKey) Bytes() []byte {
+	return append([]byte{EXTRA_TAG_PUBKEY}, f.Key.Bytes()...)
+}
+
+// Arbitrary data (tag 0x02), normally used to carry a payment id
+type TxExtraNonce struct {
+	Nonce []byte
+}
+
+func (f *TxExtraNonce) Tag() byte {
+	return EXTRA_TAG_NONCE
+}
+
+func (f *TxExtraNonce) Bytes() []byte {
+	res := binary.AppendUvarint([]byte{EXTRA_TAG_NONCE}, uint64(len(f.Nonce)))
+	return append(res, f.Nonce...)
+}
+
+// Returns the unencrypted 32 byte payment id if the nonce contains one
+func (f *TxExtraNonce) PaymentID() ([]byte, bool) {
+	if len(f.Nonce) != 1+PAYMENT_ID_SIZE || f.Nonce[0] != EXTRA_NONCE_PAYMENT_ID {
+		return nil, false
+	}
+
+	return f.Nonce[1:], true
+}
+
+// Returns the encrypted 8 byte payment id if the nonce contains one
+func (f *TxExtraNonce) EncryptedPaymentID() ([]byte, bool) {
+	if len(f.Nonce) != 1+SHORT_PAYMENT_ID_SIZE || f.Nonce[0] != EXTRA_NONCE_ENCRYPTED_PAYMENT_ID {
+		return nil, false
+	}
+
+	return f.Nonce[1:], true
+}
+
+// Creates a nonce with the unencrypted 32 byte payment id
+func NewTxExtraNoncePaymentID(payId []byte) (*TxExtraNonce, error) {
+	if len(payId) != PAYMENT_ID_SIZE {
+		return nil, fmt.Errorf("invalid payment id size: %d", len(payId))
+	}
+
+	return &TxExtraNonce{Nonce: append([]byte{EXTRA_NONCE_PAYMENT_ID}, payId...)}, nil
+}
+
+// Creates a nonce with the encrypted 8 byte payment id
+func NewTxExtraNonceEncryptedPaymentID(payId []byte) (*TxExtraNonce, error) {
+	if len(payId) != SHORT_PAYMENT_ID_SIZE {
+		return nil, fmt.Errorf("invalid encrypted payment id size: %d", len(payId))
+	}
+
+	return &TxExtraNonce{Nonce: append([]byte{EXTRA_NONCE_ENCRYPTED_PAYMENT_ID}, payId...)}, nil
+}
+
+// Merge mining tag (tag 0x03)
+type TxExtraMergeMining struct {
+	Depth      uint64
+	MerkleRoot [KEY_SIZE]byte
+}
+
+func (f *TxExtraMergeMining) Tag() byte {
+	return EXTRA_TAG_MERGE_MINING
+}
+
+func (f *TxExtraMergeMining) Bytes() []byte {
+	data := binary.AppendUvarint(nil, f.Depth)
+	data = append(data, f.MerkleRoot[:]...)
+
+	res := binary.AppendUvarint([]byte{EXTRA_TAG_MERGE_MINING}, uint64(len(data)))
+	return append(res, data...)
+}
+
+// Additional tx public keys (tag 0x04), one per output
+type TxExtraAdditionalPubKeys struct {
+	Keys []*PublicKey
+}
+
+func (f *TxExtraAdditionalPubKeys) Tag() byte {
+	return EXTRA_TAG_ADDITIONAL_PUBKEYS
+}
+
+func (f *TxExtraAdditionalPubKeys) Bytes() []byte {
+	res := binary.AppendUvarint([]byte{EXTRA_TAG_ADDITIONAL_PUBKEYS}, uint64(len(f.Keys)))
+	for _, k := range f.Keys {
+		res = append(res, k.Bytes()...)
+	}
+
+	return res
+}
+
+// MinerGate tag (tag 0xde)
+type TxExtraMinergate struct {
+	Data []byte
+}
+
+func (f *TxExtraMinergate) Tag() byte {
+	return EXTRA_TAG_MINERGATE
+}
+
+func (f *TxExtraMinergate) Bytes() []byte {
+	res := binary.AppendUvarint([]byte{EXTRA_TAG_MINERGATE}, uint64(len(f.Data)))
+	return append(res, f.Data...)
+}
+
+// TxExtra is the parsed tx extra field.
+type TxExtra struct {
+	Fields []TxExtraField
+}
+
+// Reads a canonically encoded varint and returns its value and size
+func readVarintHelper(data []byte) (uint64, int, error) {
+	var res uint64
+	for i := 0; i < len(data) && i < binary.MaxVarintLen64; i++ {
+		b := data[i]
+		if i == binary.MaxVarintLen64-1 && b > 1 {
+			// overflow
+			break
+		}
+		if i > 0 && b == 0 {
+			// non canonical representation
+			break
+		}
+
+		res |= uint64(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			return res, i + 1, nil
+		}
+	}
+
+	return 0, 0, ErrInvalidVarint
+}
+
+// Reads a varint size prefix and checks that the data of that size follows
+func readSizeHelper(extra []byte, offset int, elemSize int) (int, int, error) {
+	l, n, err := readVarintHelper(extra[offset:])
+	if err != nil {
+		return 0, 0, &ExtraError{Offset: offset, Err: err}
+	}
+	offset += n
+
+	if l > uint64((len(extra)-offset)/elemSize) {
+		return 0, 0, &ExtraError{Offset: offset, Err: ErrExtraTooShort}
+	}
+
+	return int(l), offset, nil
+}
+
+func parseMergeMiningHelper(data []byte, offset int) (*TxExtraMergeMining, error) {
+	depth, n, err := readVarintHelper(data)
+	if err != nil {
+		return nil, &ExtraError{Offset: offset, Err: err}
+	}
+	if len(data)-n != KEY_SIZE {
+		return nil, &ExtraError{Offset: offset + n, Err: fmt.Errorf("invalid merge mining tag size: %d", len(data))}
+	}
+
+	f := &TxExtraMergeMining{Depth: depth}
+	copy(f.MerkleRoot[:], data[n:])
+
+	return f, nil
+}
+
+// Parses the extra field into the list of its fields.
+// If the extra is malformed, an *ExtraError is returned together with the fields that precede the malformed one,
+// so the returned TxExtra is never nil.
+func ParseTxExtra(extra []byte) (*TxExtra, error) {
+	res := &TxExtra{}
+
+	for i := 0; i < len(extra); {
+		tag := extra[i]
+		i++
+
+		switch tag {
+		case EXTRA_TAG_PADDING:
+			size := len(extra) - i + 1
+			if size > EXTRA_PADDING_MAX_SIZE {
+				return res, &ExtraError{Offset: i - 1, Err: fmt.Errorf("padding is too long: %d", size)}
+			}
+			for ; i < len(extra); i++ {
+				if extra[i] != 0 {
+					return res, &ExtraError{Offset: i, Err: errors.New("padding contains non zero bytes")}
+				}
+			}
+			res.Fields = append(res.Fields, &TxExtraPadding{Size: size})
+		case EXTRA_TAG_PUBKEY:
+			if len(extra)-i < KEY_SIZE {
+				return res, &ExtraError{Offset: i, Err: ErrExtraTooShort}
+			}
+			key, err := newPublicKeyHelper(extra[i : i+KEY_SIZE])
+			if err != nil {
+				return res, &ExtraError{Offset: i, Err: err}
+			}
+			res.Fields = append(res.Fields, &TxExtraPubKey{Key: key})
+			i += KEY_SIZE
+		case EXTRA_TAG_NONCE, EXTRA_TAG_MINERGATE, EXTRA_TAG_MERGE_MINING:
+			size, start, err := readSizeHelper(extra, i, 1)
+			if err != nil {
+				return res, err
+			}
+			i = start + size
+			data := append([]byte(nil), extra[start:i]...)
+
+			switch tag {
+			case EXTRA_TAG_NONCE:
+				if size > EXTRA_NONCE_MAX_SIZE {
+					return res, &ExtraError{Offset: start, Err: fmt.Errorf("nonce is too long: %d", size)}
+				}
+				res.Fields = append(res.Fields, &TxExtraNonce{Nonce: data})
+			case EXTRA_TAG_MINERGATE:
+				res.Fields = append(res.Fields, &TxExtraMinergate{Data: data})
+			default:
+				f, err := parseMergeMiningHelper(data, start)
+				if err != nil {
+					return res, err
+				}
+				res.Fields = append(res.Fields, f)
+			}
+		case EXTRA_TAG_ADDITIONAL_PUBKEYS:
+			count, start, err := readSizeHelper(extra, i, KEY_SIZE)
+			if err != nil {
+				return res, err
+			}
+			i = start
+
+			f := &TxExtraAdditionalPubKeys{Keys: make([]*PublicKey, 0, count)}
+			for j := 0; j < count; j++ {
+				key, err := newPublicKeyHelper(extra[i : i+KEY_SIZE])
+				if err != nil {
+					return res, &ExtraError{Offset: i, Err: err}
+				}
+				f.Keys = append(f.Keys, key)
+				i += KEY_SIZE
+			}
+			res.Fields = append(res.Fields, f)
+		default:
+			return res, &ExtraError{Offset: i - 1, Err: fmt.Errorf("unknown tag: %#02x", tag)}
+		}
+	}
+
+	return res, nil
+}
+
+// Serializes the fields back into the extra field
+func (e *TxExtra) Bytes() []byte {
+	res := make([]byte, 0)
+	for _, f := range e.Fields {
+		res = append(res, f.Bytes()...)
+	}
+
+	return res
+}
+
+// Returns the first tx pub key or nil if there's none
+func (e *TxExtra) TxPublicKey() *PublicKey {
+	for _, f := range e.Fields {
+		if v, ok := f.(*TxExtraPubKey); ok {
+			return v.Key
+		}
+	}
+
+	return nil
+}
+
+// Returns the additional tx pub keys or nil if there're none
+func (e *TxExtra) AdditionalTxPublicKeys() []*PublicKey {
+	for _, f := range e.Fields {
+		if v, ok := f.(*TxExtraAdditionalPubKeys); ok {
+			return v.Keys
+		}
+	}
+
+	return nil
+}
+
+// Returns the first nonce or nil if there's none
+func (e *TxExtra) Nonce() *TxExtraNonce {
+	for _, f := range e.Fields {
+		if v, ok := f.(*TxExtraNonce); ok {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// Returns the unencrypted 32 byte payment id if the extra contains one
+func (e *TxExtra) PaymentID() ([]byte, bool) {
+	nonce := e.Nonce()
+	if nonce == nil {
+		return nil, false
+	}
+
+	return nonce.PaymentID()
+}
+
+// Returns the encrypted 8 byte payment id if the extra contains one
+func (e *TxExtra) EncryptedPaymentID() ([]byte, bool) {
+	nonce := e.Nonce()
+	if nonce == nil {
+		return nil, false
+	}
+
+	return nonce.EncryptedPaymentID()
+}
+
+// Returns the plaintext payment id or nil if there's none.
+// The encrypted one is decrypted with the tx pub key and the private view key.
+// The all-zero dummy payment id is returned as nil.
+func (e *TxExtra) DecryptedPaymentID(viewKey *PrivateKey) ([]byte, error) {
+	if payId, ok := e.PaymentID(); ok {
+		return payId, nil
+	}
+
+	encrypted, ok := e.EncryptedPaymentID()
+	if !ok {
+		return nil, nil
+	}
+
+	txPub := e.TxPublicKey()
+	if txPub == nil {
+		return nil, errors.New("no tx pub key to decrypt the payment id")
+	}
+
+	payId, err := DecryptPaymentID(encrypted, txPub, viewKey)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(payId, make([]byte, SHORT_PAYMENT_ID_SIZE)) {
+		return nil, nil
+	}
+
+	return payId, nil
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrUnauthorized = errors.New("unauthorized, check the rpc login")
+	ErrForbidden    = errors.New("forbidden, the method may be restricted")
+)
+
+// Returned when the rpc server responds with a non-2xx HTTP status.
+// Method is the rpc method (or the endpoint) that was called.
+type HttpStatusError struct {
+	Method     string
+	StatusCode int
+	Status     string
+}
+
+func (e *HttpStatusError) Error() string {
+	if e.Method == "" {
+		return e.Status
+	}
+	return fmt.Sprintf("%s: %s", e.Method, e.Status)
+}
+
+// Makes errors.Is(err, ErrUnauthorized) and errors.Is(err, ErrForbidden) work for the 401 and 403 statuses
+func (e *HttpStatusError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	}
+
+	return false
+}
+
+func NewHttpStatusError(method string, res *http.Response) *HttpStatusError {
+	return &HttpStatusError{method, res.StatusCode, res.Status}
+}
@@ -33,6 +33,16 @@ func NewPrivateKey(keyStr string) (*PrivateKey, error) {
 	return newPrivateKeyHelper(keyBytes)
 }
 
+// Generates a random Private Key
+func NewRandomPrivateKey() (*PrivateKey, error) {
+	sc, err := randomScalarHelper()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{key: sc}, nil
+}
+
 type PublicKey struct {
 	key *edwards25519.Point
 }
@@ -60,6 +70,33 @@ func NewPublicKey(keyStr string) (*PublicKey, error) {
 	return newPublicKeyHelper(keyBytes)
 }
 
+type KeyImage struct {
+	key *edwards25519.Point
+}
+
+func (k *KeyImage) Bytes() []byte {
+	return k.key.Bytes()
+}
+
+func newKeyImageHelper(keyBytes []byte) (*KeyImage, error) {
+	key, err := new(edwards25519.Point).SetBytes(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyImage{key: key}, nil
+}
+
+// Creates Key Image from a hex string representation
+func NewKeyImage(keyStr string) (*KeyImage, error) {
+	keyBytes, err := hex.DecodeString(keyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKeyImageHelper(keyBytes)
+}
+
 type KeyPair struct {
 	priv *PrivateKey
 	pub  *PublicKey
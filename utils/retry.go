@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	DEFAULT_RETRY_MAX_ATTEMPTS    int           = 4
+	DEFAULT_RETRY_INITIAL_BACKOFF time.Duration = 500 * time.Millisecond
+	DEFAULT_RETRY_MAX_BACKOFF     time.Duration = 10 * time.Second
+	DEFAULT_RETRY_MULTIPLIER      float64       = 2
+	DEFAULT_RETRY_JITTER          float64       = 0.2
+)
+
+// RetryPolicy describes how the failed rpc calls are retried.
+// By default, only the idempotent methods are retried, the rest are sent once no matter what the policy says,
+// unless they are listed in AlsoRetry.
+type RetryPolicy struct {
+	// The total number of attempts, including the first one. The calls aren't retried if it is less than 2.
+	MaxAttempts int
+	// The delay before the second attempt
+	InitialBackoff time.Duration
+	// The upper bound of the delay
+	MaxBackoff time.Duration
+	// The delay is multiplied by it after each attempt
+	Multiplier float64
+	// The fraction of the delay (from 0 to 1) that is randomized, so the clients don't retry in lockstep
+	Jitter float64
+	// Tells whether the error is worth retrying. The default of the client package is used if nil.
+	Retryable func(err error) bool
+	// The non-idempotent methods that are retried anyway, e.g. "relay_tx" if the caller knows relaying twice is harmless
+	AlsoRetry []string
+}
+
+// Returns the policy with the DEFAULT_RETRY_* values
+func NewDefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    DEFAULT_RETRY_MAX_ATTEMPTS,
+		InitialBackoff: DEFAULT_RETRY_INITIAL_BACKOFF,
+		MaxBackoff:     DEFAULT_RETRY_MAX_BACKOFF,
+		Multiplier:     DEFAULT_RETRY_MULTIPLIER,
+		Jitter:         DEFAULT_RETRY_JITTER,
+	}
+}
+
+// Returns the delay before the attempt following the given one (starting from 1)
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d -= d * math.Min(p.Jitter, 1) * rand.Float64()
+	}
+
+	return time.Duration(d)
+}
+
+func (p *RetryPolicy) allowsHelper(method string, idempotent bool) bool {
+	if idempotent {
+		return true
+	}
+
+	for _, v := range p.AlsoRetry {
+		if v == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Calls fn until it succeeds, fails with an error that isn't retryable, the attempts are exhausted or the ctx is done.
+// The policy may be nil, then fn is called once. The error of the last attempt is returned.
+func (p *RetryPolicy) Do(ctx context.Context, method string, idempotent bool, retryable func(err error) bool, fn func() error) error {
+	if p == nil || !p.allowsHelper(method, idempotent) {
+		return fn()
+	}
+	if p.Retryable != nil {
+		retryable = p.Retryable
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// Reports the transport failures (the network errors, the connections closed in the middle of the response)
+// and the 5xx and 429 HTTP statuses, which are the errors worth retrying no matter what rpc server is called.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *HttpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"sync"
+)
+
+type SubaddressIndex struct {
+	Major uint32 `json:"major"`
+	Minor uint32 `json:"minor"`
+}
+
+// SubaddressTable maps the public spend keys of (sub)addresses to their indices,
+// so an output can be checked against all of them with a single scalar multiplication.
+type SubaddressTable struct {
+	keys   *ViewOnlyKeyPair
+	table  map[[KEY_SIZE]byte]SubaddressIndex
+	majors uint32
+	minors uint32
+	mu     sync.RWMutex
+}
+
+// Creates a SubaddressTable filled with all the subaddresses in the [0, majors) x [0, minors) window.
+// (0, 0) is the primary address.
+func NewSubaddressTable(keys *ViewOnlyKeyPair, majors, minors uint32) (*SubaddressTable, error) {
+	t := &SubaddressTable{keys: keys, table: make(map[[KEY_SIZE]byte]SubaddressIndex)}
+	if err := t.Grow(majors, minors); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *SubaddressTable) subaddressPublicSpendKeyHelper(index SubaddressIndex) (*PublicKey, error) {
+	if index.Major == 0 && index.Minor == 0 {
+		return t.keys.SpendPublicKey(), nil
+	}
+
+	// the network type only affects the address prefix, not the keys
+	addr, err := GenerateSubaddress(t.keys.ViewKeyPair().PrivateKey(), t.keys.SpendPublicKey(), index.Major, index.Minor, Mainnet)
+	if err != nil {
+		return nil, err
+	}
+
+	return addr.PublicSpendKey(), nil
+}
+
+func (t *SubaddressTable) addHelper(index SubaddressIndex) error {
+	key, err := t.subaddressPublicSpendKeyHelper(index)
+	if err != nil {
+		return err
+	}
+
+	var k [KEY_SIZE]byte
+	copy(k[:], key.Bytes())
+	t.table[k] = index
+
+	return nil
+}
+
+// Returns the key pair the table has been built from.
+func (t *SubaddressTable) Keys() *ViewOnlyKeyPair {
+	return t.keys
+}
+
+// Adds a single (sub)address to the table without changing the window.
+func (t *SubaddressTable) Add(index SubaddressIndex) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.addHelper(index)
+}
+
+// Grows the window to [0, majors) x [0, minors). The window never shrinks.
+func (t *SubaddressTable) Grow(majors, minors uint32) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if majors < t.majors {
+		majors = t.majors
+	}
+	if minors < t.minors {
+		minors = t.minors
+	}
+
+	for major := uint32(0); major < majors; major++ {
+		// only the part that is not in the table yet
+		minor := uint32(0)
+		if major < t.majors {
+			minor = t.minors
+		}
+
+		for ; minor < minors; minor++ {
+			if err := t.addHelper(SubaddressIndex{major, minor}); err != nil {
+				return err
+			}
+		}
+	}
+
+	t.majors, t.minors = majors, minors
+
+	return nil
+}
+
+// Returns the current window size.
+func (t *SubaddressTable) Window() (majors, minors uint32) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.majors, t.minors
+}
+
+// Returns the number of (sub)addresses in the table.
+func (t *SubaddressTable) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.table)
+}
+
+// Looks up the (sub)address index by its public spend key.
+func (t *SubaddressTable) Lookup(spendKey *PublicKey) (SubaddressIndex, bool) {
+	var k [KEY_SIZE]byte
+	copy(k[:], spendKey.Bytes())
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	index, ok := t.table[k]
+	return index, ok
+}
+
+// Checks whether the output belongs to any of the (sub)addresses in the table and if so, returns its index
+func (t *SubaddressTable) OutputBelongs(outIndex uint32, outKey *PublicKey, txPub *PublicKey) (SubaddressIndex, bool, error) {
+	B, err := DeriveOutputPublicSpendKey(outIndex, outKey, txPub, t.keys.ViewKeyPair().PrivateKey())
+	if err != nil {
+		return SubaddressIndex{}, false, err
+	}
+
+	index, ok := t.Lookup(B)
+	return index, ok, nil
+}
+
+// Checks whether the output belongs to any of the (sub)addresses in the table and if so, returns its index and a decrypted amount
+func (t *SubaddressTable) DecryptOutput(outIndex uint32, outKey *PublicKey, amount string, txPub *PublicKey) (SubaddressIndex, bool, uint64, error) {
+	B, Si, err := deriveOutputPublicSpendKeyHelper(outIndex, outKey, txPub, t.keys.ViewKeyPair().PrivateKey())
+	if err != nil {
+		return SubaddressIndex{}, false, 0, err
+	}
+
+	index, ok := t.Lookup(&PublicKey{key: B})
+	if !ok {
+		return SubaddressIndex{}, false, 0, nil
+	}
+
+	amountDec, err := decryptOutputAmountHelper(Si, amount)
+	if err != nil {
+		return SubaddressIndex{}, false, 0, err
+	}
+
+	return index, true, amountDec, nil
+}
+
+// Same as OutputBelongs, but also tries the per-output additional tx pub key.
+// Returns the tx pub key the output has been derived from.
+func (t *SubaddressTable) OutputBelongsAdditional(outIndex uint32, outKey *PublicKey, txPub *PublicKey, additionalKeys []*PublicKey) (SubaddressIndex, *PublicKey, bool, error) {
+	for _, key := range GetOutputTxPublicKeys(outIndex, txPub, additionalKeys) {
+		index, ok, err := t.OutputBelongs(outIndex, outKey, key)
+		if err != nil {
+			return SubaddressIndex{}, nil, false, err
+		}
+		if ok {
+			return index, key, true, nil
+		}
+	}
+
+	return SubaddressIndex{}, nil, false, nil
+}
+
+// Same as DecryptOutput, but also tries the per-output additional tx pub key.
+// Returns the tx pub key the output has been derived from.
+func (t *SubaddressTable) DecryptOutputAdditional(outIndex uint32, outKey *PublicKey, amount string, txPub *PublicKey, additionalKeys []*PublicKey) (SubaddressIndex, *PublicKey, bool, uint64, error) {
+	for _, key := range GetOutputTxPublicKeys(outIndex, txPub, additionalKeys) {
+		index, ok, am, err := t.DecryptOutput(outIndex, outKey, amount, key)
+		if err != nil {
+			return SubaddressIndex{}, nil, false, 0, err
+		}
+		if ok {
+			return index, key, true, am, nil
+		}
+	}
+
+	return SubaddressIndex{}, nil, false, 0, nil
+}
@@ -12,6 +12,7 @@ import (
 	"unicode/utf8"
 
 	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
 	"github.com/btcsuite/btcutil/base58"
 	"golang.org/x/crypto/sha3"
 )
@@ -20,12 +21,20 @@ const (
 	ATOMIC_UNIT                     float64 = 1e12
 	CHECKSUM_SIZE                   int     = 4
 	KEY_SIZE                        int     = 32
+	ENCRYPTED_AMOUNT_SIZE           int     = 8
 	BASE58_FULL_BLOCK_SIZE          int     = 8
 	BASE58_ENCODED_BLOCK_SIZE       int     = 11
 	INTEGRATED_ADDRESS_SIZE         int     = 106
 	INTEGRATED_ADDRESS_DECODED_SIZE int     = 77
 	ADDRESS_SIZE                    int     = 95
 	ADDRESS_DECODED_SIZE            int     = 69
+
+	EXTRA_TAG_PADDING            byte = 0x00
+	EXTRA_TAG_PUBKEY             byte = 0x01
+	EXTRA_TAG_NONCE              byte = 0x02
+	EXTRA_TAG_MERGE_MINING       byte = 0x03
+	EXTRA_TAG_ADDITIONAL_PUBKEYS byte = 0x04
+	EXTRA_TAG_MINERGATE          byte = 0xde
 )
 
 var (
@@ -33,7 +42,7 @@ var (
 	view_tag_prefix     []byte = []byte("view_tag")
 	amount_prefix       []byte = []byte("amount")
 	subaddr_prefix      []byte = []byte("SubAddr\x00")
-	default_extra_tags  []byte = []byte{0x01, 0x02}
+	payment_id_suffix   byte   = 0x8d
 )
 
 /********************************************** Cryptography Related Mehtods ***************************************************/
@@ -66,6 +75,97 @@ func GetPrivateViewKeyFromPrivateSpendKey(spend *PrivateKey) (*PrivateKey, error
 	return &PrivateKey{key: sc}, nil
 }
 
+func randomScalarHelper() (*edwards25519.Scalar, error) {
+	buf := make([]byte, 64)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	return new(edwards25519.Scalar).SetUniformBytes(buf)
+}
+
+func signatureChallengeHelper(hash []byte, pubKey *PublicKey, comm *edwards25519.Point) (*edwards25519.Scalar, error) {
+	buf := make([]byte, 0, 3*KEY_SIZE)
+	buf = append(buf, hash...)
+	buf = append(buf, pubKey.Bytes()...)
+	buf = append(buf, comm.Bytes()...)
+
+	data, err := Keccak256Hash(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return keccak256HashToScalar(data)
+}
+
+// Signs the 32 byte hash with the CryptoNote (non-ring) signature, the one monerod uses e.g. for the RPC payment requests.
+// The result is c || r, 64 bytes.
+func GenerateSignature(hash []byte, privKey *PrivateKey) ([]byte, error) {
+	if len(hash) != KEY_SIZE {
+		return nil, errors.New("invalid hash size")
+	}
+	pubKey := GetPublicKeyFromPrivate(privKey)
+
+	/** Signature - (c, r)
+		k - random Scalar
+		c = Hs(hash || A || k*G)
+		r = k - c*a
+
+		a - Private Key, A - Public Key
+	**/
+	zero := edwards25519.NewScalar()
+	for {
+		k, err := randomScalarHelper()
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := signatureChallengeHelper(hash, pubKey, new(edwards25519.Point).ScalarBaseMult(k))
+		if err != nil {
+			return nil, err
+		}
+		if c.Equal(zero) == 1 {
+			continue
+		}
+
+		r := new(edwards25519.Scalar).Subtract(k, new(edwards25519.Scalar).Multiply(c, privKey.key))
+		if r.Equal(zero) == 1 {
+			continue
+		}
+
+		return append(c.Bytes(), r.Bytes()...), nil
+	}
+}
+
+// Verifies the signature made by GenerateSignature
+func CheckSignature(hash []byte, pubKey *PublicKey, sig []byte) bool {
+	if len(hash) != KEY_SIZE || len(sig) != 2*KEY_SIZE {
+		return false
+	}
+
+	c, err := new(edwards25519.Scalar).SetCanonicalBytes(sig[:KEY_SIZE])
+	if err != nil || c.Equal(edwards25519.NewScalar()) == 1 {
+		return false
+	}
+	r, err := new(edwards25519.Scalar).SetCanonicalBytes(sig[KEY_SIZE:])
+	if err != nil {
+		return false
+	}
+
+	// k*G = c*A + r*G
+	comm := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(c, pubKey.key, r)
+	if comm.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return false
+	}
+
+	expected, err := signatureChallengeHelper(hash, pubKey, comm)
+	if err != nil {
+		return false
+	}
+
+	return expected.Equal(c) == 1
+}
+
 /********************************************** Tx Related Mehtods ***************************************************/
 
 func calculateSharedKeyHelper(viewKey *PrivateKey, txPub *PublicKey) *edwards25519.Point {
@@ -133,6 +233,37 @@ func calculateSharedKeyConcatOutIndexHash(S *edwards25519.Point, outIndex uint32
 	return sc, nil
 }
 
+func deriveOutputPublicSpendKeyHelper(outIndex uint32, outKey *PublicKey, txPub *PublicKey, viewKey *PrivateKey) (*edwards25519.Point, *edwards25519.Scalar, error) {
+	S := calculateSharedKeyHelper(viewKey, txPub)
+
+	Si, err := calculateSharedKeyConcatOutIndexHash(S, outIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	/** P - Si*G - Point
+
+		P - Output Public Key - Point
+		Si - Keccak256Hash of Shared key + Output index - Hs(S||i) - Scalar
+		G - Base Point of Ed25519 Elliptic Curve - Point
+	**/
+	B := new(edwards25519.Point).ScalarBaseMult(Si)
+	B.Subtract(outKey.key, B)
+
+	return B, Si, nil
+}
+
+// Derives the public spend key the output has been sent to (P - Hs(8vR||i)*G).
+// If the output is ours, the result is the public spend key of one of our (sub)addresses.
+func DeriveOutputPublicSpendKey(outIndex uint32, outKey *PublicKey, txPub *PublicKey, viewKey *PrivateKey) (*PublicKey, error) {
+	B, _, err := deriveOutputPublicSpendKeyHelper(outIndex, outKey, txPub, viewKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicKey{key: B}, nil
+}
+
 // Checks whether the output belongs to the specific private view and public spend keys
 func OutputBelongsPublicSpendKey(spendKey *PublicKey, outIndex uint32, outKey *PublicKey, txPub *PublicKey, viewKey *PrivateKey) (bool, error) {
 	S := calculateSharedKeyHelper(viewKey, txPub)
@@ -159,6 +290,14 @@ func decryptOutputAmountHelper(Si *edwards25519.Scalar, amount string) (uint64,
 		return 0, err
 	}
 
+	switch len(amountDecoded) {
+	case KEY_SIZE:
+		return decryptOutputAmountV1Helper(Si, amountDecoded)
+	case ENCRYPTED_AMOUNT_SIZE:
+	default:
+		return 0, fmt.Errorf("invalid encrypted amount size: %d", len(amountDecoded))
+	}
+
 	// keccak("amount"||Hs(8aR||i))
 	aSi, err := Keccak256Hash(append(amount_prefix, Si.Bytes()...))
 	if err != nil {
@@ -169,6 +308,39 @@ func decryptOutputAmountHelper(Si *edwards25519.Scalar, amount string) (uint64,
 	return binary.LittleEndian.Uint64(xor(amountDecoded, aSi)), nil
 }
 
+// The ecdh info of the RCT types before Bulletproofs2 keeps the whole amount scalar
+func decryptOutputAmountV1Helper(Si *edwards25519.Scalar, amount []byte) (uint64, error) {
+	/** ecdh.amount - Hs(Hs(Si)) - Scalar
+
+		Si - Keccak256Hash of Shared key + Output index - Hs(8aR||i) - Scalar
+	**/
+	a, err := new(edwards25519.Scalar).SetCanonicalBytes(amount)
+	if err != nil {
+		return 0, err
+	}
+
+	mask := Si
+	for i := 0; i < 2; i++ {
+		hash, err := Keccak256Hash(mask.Bytes())
+		if err != nil {
+			return 0, err
+		}
+		mask, err = keccak256HashToScalar(hash)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	res := a.Subtract(a, mask).Bytes()
+	for _, v := range res[8:] {
+		if v != 0 {
+			return 0, errors.New("invalid encrypted amount")
+		}
+	}
+
+	return binary.LittleEndian.Uint64(res[:8]), nil
+}
+
 // Checks whether the output belongs to the specific private view and public spend keys and if so, returns a decrypted amount
 func DecryptOutputPublicSpendKey(spendKey *PublicKey, outIndex uint32, outKey *PublicKey, amount string, txPub *PublicKey, viewKey *PrivateKey) (bool, uint64, error) {
 	S := calculateSharedKeyHelper(viewKey, txPub)
@@ -231,6 +403,144 @@ func DecryptOutputViewTag(viewTag string, outIndex uint32, amount string, txPub
 	return true, amountDec, nil
 }
 
+func xorPaymentIDHelper(payId []byte, S *edwards25519.Point) ([]byte, error) {
+	if len(payId) != SHORT_PAYMENT_ID_SIZE {
+		return nil, fmt.Errorf("invalid encrypted payment id size: %d", len(payId))
+	}
+
+	/** H(8vR||0x8d) - 32 bytes
+
+		H - Keccak256Hash - Function
+		8vR - Shared Key - Point
+	**/
+	hash, err := Keccak256Hash(append(S.Bytes(), payment_id_suffix))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, SHORT_PAYMENT_ID_SIZE)
+	for i := range res {
+		res[i] = payId[i] ^ hash[i]
+	}
+
+	return res, nil
+}
+
+// Decrypts the 8 byte payment id of an integrated address payment using the tx pub key and the private view key
+func DecryptPaymentID(encrypted []byte, txPub *PublicKey, viewKey *PrivateKey) ([]byte, error) {
+	return xorPaymentIDHelper(encrypted, calculateSharedKeyHelper(viewKey, txPub))
+}
+
+// Encrypts the 8 byte payment id using the tx private key and the recipient's public view key
+func EncryptPaymentID(payId []byte, viewPub *PublicKey, txKey *PrivateKey) ([]byte, error) {
+	// 8rV == 8vR
+	return xorPaymentIDHelper(payId, calculateSharedKeyHelper(txKey, viewPub))
+}
+
+// Computes the key image of the output sent to the (sub)address with the given major and minor indices
+func ComputeKeyImage(keys *FullKeyPair, txPub *PublicKey, outIndex uint32, subaddrMajor, subaddrMinor uint32) (*KeyImage, error) {
+	/** x = Hs(8aR||i) + b (+ m for subaddresses) - Scalar
+
+		a - Private View Key - Scalar
+		b - Private Spend Key - Scalar
+		m - Subaddress secret key - Hs(b"SubAddr\0" || a || major || minor) - Scalar
+	**/
+	x, err := calculateSharedKeyConcatOutIndexHash(calculateSharedKeyHelper(keys.ViewKeyPair().PrivateKey(), txPub), outIndex)
+	if err != nil {
+		return nil, err
+	}
+	x.Add(x, keys.SpendKeyPair().PrivateKey().key)
+
+	if subaddrMajor != 0 || subaddrMinor != 0 {
+		m, err := subaddressSecretKeyHelper(keys.ViewKeyPair().PrivateKey(), subaddrMajor, subaddrMinor)
+		if err != nil {
+			return nil, err
+		}
+		x.Add(x, m)
+	}
+
+	return GenerateKeyImage(&PrivateKey{key: x})
+}
+
+// Computes the key image of the output with the given one-time private key, the same as monerod's generate_key_image
+func GenerateKeyImage(outputKey *PrivateKey) (*KeyImage, error) {
+	/** I = x * Hp(P) - Point
+
+		x - Output Private Key - Scalar
+		P - Output Public Key - x*G - Point
+		Hp - Hash to point - Function
+	**/
+	P := new(edwards25519.Point).ScalarBaseMult(outputKey.key)
+	Hp, err := hashToPointHelper(P.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyImage{key: Hp.ScalarMult(outputKey.key, Hp)}, nil
+}
+
+// Returns the tx pub keys the output with the given index can be derived from:
+// the main tx pub key and, if present, the additional one with the same index
+func GetOutputTxPublicKeys(outIndex uint32, txPub *PublicKey, additionalKeys []*PublicKey) []*PublicKey {
+	keys := make([]*PublicKey, 0, 2)
+	if txPub != nil {
+		keys = append(keys, txPub)
+	}
+	if int(outIndex) < len(additionalKeys) && additionalKeys[outIndex] != nil {
+		keys = append(keys, additionalKeys[outIndex])
+	}
+
+	return keys
+}
+
+// Checks whether the output belongs to the specific private view key by comparing view tags.
+// Both the main and the per-output additional tx pub keys are tried.
+func OutputBelongsViewTagAdditional(viewTag string, outIndex uint32, txPub *PublicKey, additionalKeys []*PublicKey, viewKey *PrivateKey) (bool, error) {
+	for _, key := range GetOutputTxPublicKeys(outIndex, txPub, additionalKeys) {
+		res, err := OutputBelongsViewTag(viewTag, outIndex, key, viewKey)
+		if err != nil {
+			return false, err
+		}
+		if res {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Checks whether the output belongs to the specific private view and public spend keys.
+// Both the main and the per-output additional tx pub keys are tried.
+func OutputBelongsPublicSpendKeyAdditional(spendKey *PublicKey, outIndex uint32, outKey *PublicKey, txPub *PublicKey, additionalKeys []*PublicKey, viewKey *PrivateKey) (bool, error) {
+	for _, key := range GetOutputTxPublicKeys(outIndex, txPub, additionalKeys) {
+		res, err := OutputBelongsPublicSpendKey(spendKey, outIndex, outKey, key, viewKey)
+		if err != nil {
+			return false, err
+		}
+		if res {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Checks whether the output belongs to the specific private view and public spend keys and if so, returns a decrypted amount.
+// Both the main and the per-output additional tx pub keys are tried.
+func DecryptOutputPublicSpendKeyAdditional(spendKey *PublicKey, outIndex uint32, outKey *PublicKey, amount string, txPub *PublicKey, additionalKeys []*PublicKey, viewKey *PrivateKey) (bool, uint64, error) {
+	for _, key := range GetOutputTxPublicKeys(outIndex, txPub, additionalKeys) {
+		res, am, err := DecryptOutputPublicSpendKey(spendKey, outIndex, outKey, amount, key, viewKey)
+		if err != nil {
+			return false, 0, err
+		}
+		if res {
+			return true, am, nil
+		}
+	}
+
+	return false, 0, nil
+}
+
 /********************************************** Monero Address Related Mehtods ***************************************************/
 
 func decodeMoneroAddressBase58Helper(addr string) []byte {
@@ -338,8 +648,11 @@ func EncodeMoneroAddress(addr []byte) ([]byte, error) {
 	return encodeMoneroAddressBase58Helper(addr), nil
 }
 
-// Generates a Monero subaddress base on the primary private view and public spend keys, NetworkType, major and minor indices
-func GenerateSubaddress(viewKey *PrivateKey, spendKey *PublicKey, major, minor uint32, nt NetworkType) (*SubAddress, error) {
+func subaddressSecretKeyHelper(viewKey *PrivateKey, major, minor uint32) (*edwards25519.Scalar, error) {
+	/** m = Hs(b"SubAddr\0" || v || major || minor) - Scalar
+
+		v - Private View Key - Scalar
+	**/
 	index := append(uint32ToLittleEndianBytes(major), uint32ToLittleEndianBytes(minor)...)
 
 	Shash, err := Keccak256Hash(append(append(subaddr_prefix, viewKey.Bytes()...), index...))
@@ -347,7 +660,12 @@ func GenerateSubaddress(viewKey *PrivateKey, spendKey *PublicKey, major, minor u
 		return nil, err
 	}
 
-	Sscalar, err := keccak256HashToScalar(Shash)
+	return keccak256HashToScalar(Shash)
+}
+
+// Generates a Monero subaddress base on the primary private view and public spend keys, NetworkType, major and minor indices
+func GenerateSubaddress(viewKey *PrivateKey, spendKey *PublicKey, major, minor uint32, nt NetworkType) (*SubAddress, error) {
+	Sscalar, err := subaddressSecretKeyHelper(viewKey, major, minor)
 	if err != nil {
 		return nil, err
 	}
@@ -378,55 +696,86 @@ func GenerateSubaddress(viewKey *PrivateKey, spendKey *PublicKey, major, minor u
 
 /********************************************** Parsing Related Mehtods ***************************************************/
 
-func parseExtraChecksHelper(extra []byte, tags []byte, size int) error {
-	tag := []byte{extra[0]}
-	if !bytes.Contains(tags, tag) {
-		return errors.New("Invalid extra tag: " + hex.EncodeToString(tag))
-	}
+// Parses the extra field and returns a tx pub key.
+// A malformed field after the key doesn't make it fail, the same as in monerod.
+func GetTxPublicKeyFromExtra(extra []byte) (*PublicKey, error) {
+	txExtra, err := ParseTxExtra(extra)
 
-	if len(extra) < size {
-		return errors.New("Invalid extra size: " + string(len(extra)))
+	key := txExtra.TxPublicKey()
+	if key == nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New("No tx pub key in extra")
 	}
 
-	return nil
+	return key, nil
 }
 
-// Parses the extra field and returns a tx pub key
-func GetTxPublicKeyFromExtra(extra []byte) (*PublicKey, error) {
-	if err := parseExtraChecksHelper(extra, default_extra_tags, 33); err != nil {
-		return nil, err
-	}
+// Parses the extra field and returns the additional tx pub keys (tag 0x04), one per output.
+// Returns an empty slice if the extra doesn't contain them.
+// A malformed field after the keys doesn't make it fail.
+func GetAdditionalTxPublicKeysFromExtra(extra []byte) ([]*PublicKey, error) {
+	txExtra, err := ParseTxExtra(extra)
 
-	key, err := new(edwards25519.Point).SetBytes(extra[1:33])
-	if err != nil {
-		return nil, err
+	keys := txExtra.AdditionalTxPublicKeys()
+	if keys == nil {
+		if err != nil {
+			return nil, err
+		}
+		keys = []*PublicKey{}
 	}
 
-	return &PublicKey{key: key}, nil
+	return keys, nil
 }
 
-// Parses the extra field and returns a payment id
+// Parses the extra field and returns a payment id (either an encrypted 8 byte or an unencrypted 32 byte one).
+// A malformed field after the payment id doesn't make it fail.
 func GetPaymentIdFromExtra(extra []byte) ([]byte, error) {
-	if err := parseExtraChecksHelper(extra, default_extra_tags, 44); err != nil {
+	txExtra, err := ParseTxExtra(extra)
+
+	if payId, ok := txExtra.EncryptedPaymentID(); ok {
+		return payId, nil
+	}
+	if payId, ok := txExtra.PaymentID(); ok {
+		return payId, nil
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	return extra[36:44], nil
+	return nil, errors.New("No payment id in extra")
 }
 
-// Parses the extra field and returns a tx pub key (or nil if error) and a payment id (or nil if error)
+// Parses the extra field and returns a tx pub key (or nil if error) and a payment id (or nil if error).
+// The fields that precede a malformed one are still returned.
 func ParseExtra(extra []byte) (txKey *PublicKey, payId []byte) {
-	txKey, err := GetTxPublicKeyFromExtra(extra)
-	if err != nil {
-		txKey = nil
-	}
+	txKey, _, payId = ParseExtraWithAdditionalKeys(extra)
+	return txKey, payId
+}
 
-	payId, err = GetPaymentIdFromExtra(extra)
-	if err != nil {
-		payId = nil
+// Parses the extra field and returns a tx pub key (or nil if error), the additional tx pub keys (or nil if error)
+// and a payment id (or nil if error). The fields that precede a malformed one are still returned.
+func ParseExtraWithAdditionalKeys(extra []byte) (txKey *PublicKey, additionalKeys []*PublicKey, payId []byte) {
+	// a partially parsed extra is still useful
+	txExtra, _ := ParseTxExtra(extra)
+
+	payId, ok := txExtra.EncryptedPaymentID()
+	if !ok {
+		payId, _ = txExtra.PaymentID()
 	}
 
-	return txKey, payId
+	return txExtra.TxPublicKey(), txExtra.AdditionalTxPublicKeys(), payId
+}
+
+// Same as ParseExtraWithAdditionalKeys, but returns a plaintext payment id.
+// The encrypted 8 byte one is decrypted with the main tx pub key and the private view key,
+// the all-zero dummy one that wallets add to the txs without a payment id is returned as nil.
+func ParseExtraWithDecryptedPaymentID(extra []byte, viewKey *PrivateKey) (txKey *PublicKey, additionalKeys []*PublicKey, payId []byte) {
+	txExtra, _ := ParseTxExtra(extra)
+	payId, _ = txExtra.DecryptedPaymentID(viewKey)
+
+	return txExtra.TxPublicKey(), txExtra.AdditionalTxPublicKeys(), payId
 }
 
 func parseJson[R any](data []byte) (*R, error) {
@@ -481,6 +830,125 @@ func keccak256HashToScalar(hash []byte) (*edwards25519.Scalar, error) {
 	return res, nil
 }
 
+func newFieldElementHelper(v uint32) *field.Element {
+	b := make([]byte, 32)
+	binary.LittleEndian.PutUint32(b, v)
+
+	e, _ := new(field.Element).SetBytes(b)
+	return e
+}
+
+func fieldSqrtHelper(v *field.Element) *field.Element {
+	r, _ := new(field.Element).SqrtRatio(v, new(field.Element).One())
+	return r
+}
+
+var (
+	// Montgomery curve constant A = 486662
+	fe_a = newFieldElementHelper(486662)
+	// -A
+	fe_ma = new(field.Element).Negate(fe_a)
+	// -A^2
+	fe_ma2 = new(field.Element).Negate(new(field.Element).Square(fe_a))
+	// sqrt(-1)
+	fe_sqrtm1 = fieldSqrtHelper(new(field.Element).Negate(new(field.Element).One()))
+	// A * (A + 2)
+	fe_a_a2 = new(field.Element).Multiply(fe_a, new(field.Element).Add(fe_a, newFieldElementHelper(2)))
+	// sqrt(-2 * A * (A + 2))
+	fe_fffb1 = fieldSqrtHelper(new(field.Element).Negate(new(field.Element).Add(fe_a_a2, fe_a_a2)))
+	// sqrt(2 * A * (A + 2))
+	fe_fffb2 = fieldSqrtHelper(new(field.Element).Add(fe_a_a2, fe_a_a2))
+	// sqrt(-sqrt(-1) * A * (A + 2))
+	fe_fffb3 = fieldSqrtHelper(new(field.Element).Negate(new(field.Element).Multiply(fe_sqrtm1, fe_a_a2)))
+	// sqrt(sqrt(-1) * A * (A + 2))
+	fe_fffb4 = fieldSqrtHelper(new(field.Element).Multiply(fe_sqrtm1, fe_a_a2))
+)
+
+// Maps 32 bytes to a curve point, the same way monerod's ge_fromfe_frombytes_vartime does
+func geFromFeFromBytesHelper(s []byte) (*edwards25519.Point, error) {
+	// SetBytes ignores the top bit, monerod keeps it: 2^255 = 19 (mod p)
+	u, err := new(field.Element).SetBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	if s[31]&0x80 != 0 {
+		u.Add(u, newFieldElementHelper(19))
+	}
+
+	// v = 2 * u^2
+	v := new(field.Element).Square(u)
+	v.Add(v, v)
+	// w = 2 * u^2 + 1
+	w := new(field.Element).Add(v, new(field.Element).One())
+	// x = w^2 - 2 * A^2 * u^2
+	x := new(field.Element).Square(w)
+	x.Add(x, new(field.Element).Multiply(fe_ma2, v))
+
+	// X = (w / x)^((p + 3) / 8) = w * x^3 * (w * x^7)^((p - 5) / 8)
+	x3 := new(field.Element).Multiply(new(field.Element).Square(x), x)
+	x7 := new(field.Element).Multiply(new(field.Element).Square(x3), x)
+	X := new(field.Element).Pow22523(new(field.Element).Multiply(w, x7))
+	X.Multiply(X, w)
+	X.Multiply(X, x3)
+
+	// x = X^2 * x
+	x.Multiply(new(field.Element).Square(X), x)
+
+	zero := new(field.Element).Zero()
+	z := new(field.Element).Set(fe_ma)
+	var sign int
+	if new(field.Element).Subtract(w, x).Equal(zero) == 1 {
+		X.Multiply(X, fe_fffb2)
+		X.Multiply(X, u)
+		z.Multiply(z, v)
+	} else if new(field.Element).Add(w, x).Equal(zero) == 1 {
+		X.Multiply(X, fe_fffb1)
+		X.Multiply(X, u)
+		z.Multiply(z, v)
+	} else {
+		x.Multiply(x, fe_sqrtm1)
+		if new(field.Element).Subtract(w, x).Equal(zero) == 1 {
+			X.Multiply(X, fe_fffb4)
+		} else {
+			X.Multiply(X, fe_fffb3)
+		}
+		sign = 1
+	}
+
+	if X.IsNegative() != sign {
+		X.Negate(X)
+	}
+
+	// projective (X : Y : Z) to the compressed affine representation
+	Z := new(field.Element).Add(z, w)
+	Y := new(field.Element).Subtract(z, w)
+	X.Multiply(X, Z)
+
+	zInv := new(field.Element).Invert(Z)
+	X.Multiply(X, zInv)
+	Y.Multiply(Y, zInv)
+
+	enc := Y.Bytes()
+	enc[31] |= byte(X.IsNegative() << 7)
+
+	return new(edwards25519.Point).SetBytes(enc)
+}
+
+// Hashes data to a curve point - Hp(data) = 8 * ge_fromfe_frombytes_vartime(H(data))
+func hashToPointHelper(data []byte) (*edwards25519.Point, error) {
+	hash, err := Keccak256Hash(data)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := geFromFeFromBytesHelper(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.MultByCofactor(p), nil
+}
+
 /********************************************** Other Mehtods ***************************************************/
 
 // Converts the raw atomic XMR balance to a more human readable format.
@@ -2,167 +2,244 @@ package wallet
 
 import (
 	"bytes"
-	"fmt"
+	"context"
 	"net/http"
 
+	"github.com/chekist32/go-monero/utils"
 	"github.com/gorilla/rpc/v2/json2"
 )
 
 // Client is a monero-wallet-rpc client.
+// The Ctx variants of the methods take a context that bounds the call together with its retries.
 type Client interface {
 	// Return the wallet's balance.
 	GetBalance(*RequestGetBalance) (*ResponseGetBalance, error)
+	GetBalanceCtx(context.Context, *RequestGetBalance) (*ResponseGetBalance, error)
 	// Return the wallet's addresses for an account. Optionally filter for specific set of subaddresses.
 	GetAddress(*RequestGetAddress) (*ResponseGetAddress, error)
+	GetAddressCtx(context.Context, *RequestGetAddress) (*ResponseGetAddress, error)
 	// Get account and address indexes from a specific (sub)address
 	GetAddressIndex(*RequestGetAddressIndex) (*ResponseGetAddressIndex, error)
+	GetAddressIndexCtx(context.Context, *RequestGetAddressIndex) (*ResponseGetAddressIndex, error)
 	// Create a new address for an account. Optionally, label the new address.
 	CreateAddress(*RequestCreateAddress) (*ResponseCreateAddress, error)
+	CreateAddressCtx(context.Context, *RequestCreateAddress) (*ResponseCreateAddress, error)
 	// Label an address.
 	LabelAddress(*RequestLabelAddress) error
+	LabelAddressCtx(context.Context, *RequestLabelAddress) error
 	// Validate an address.
 	ValidateAddress(*RequestValidateAddress) (*ResponseValidateAddress, error)
+	ValidateAddressCtx(context.Context, *RequestValidateAddress) (*ResponseValidateAddress, error)
 	// Get all accounts for a wallet. Optionally filter accounts by tag.
 	GetAccounts(*RequestGetAccounts) (*ResponseGetAccounts, error)
+	GetAccountsCtx(context.Context, *RequestGetAccounts) (*ResponseGetAccounts, error)
 	// Create a new account with an optional label.
 	CreateAccount(*RequestCreateAccount) (*ResponseCreateAccount, error)
+	CreateAccountCtx(context.Context, *RequestCreateAccount) (*ResponseCreateAccount, error)
 	// Label an account.
 	LabelAccount(*RequestLabelAccount) error
+	LabelAccountCtx(context.Context, *RequestLabelAccount) error
 	// Get a list of user-defined account tags.
 	GetAccountTags() (*ResponseGetAccountTags, error)
+	GetAccountTagsCtx(context.Context) (*ResponseGetAccountTags, error)
 	// Apply a filtering tag to a list of accounts.
 	TagAccounts(*RequestTagAccounts) error
+	TagAccountsCtx(context.Context, *RequestTagAccounts) error
 	// Remove filtering tag from a list of accounts.
 	UntagAccounts(*RequestUntagAccounts) error
+	UntagAccountsCtx(context.Context, *RequestUntagAccounts) error
 	// Set description for an account tag.
 	SetAccountTagDescription(*RequestSetAccountTagDescription) error
+	SetAccountTagDescriptionCtx(context.Context, *RequestSetAccountTagDescription) error
 	// Returns the wallet's current block height.
 	GetHeight() (*ResponseGetHeight, error)
+	GetHeightCtx(context.Context) (*ResponseGetHeight, error)
 	// Send monero to a number of recipients.
 	Transfer(*RequestTransfer) (*ResponseTransfer, error)
+	TransferCtx(context.Context, *RequestTransfer) (*ResponseTransfer, error)
 	// Same as transfer, but can split into more than one tx if necessary.
 	TransferSplit(*RequestTransferSplit) (*ResponseTransferSplit, error)
+	TransferSplitCtx(context.Context, *RequestTransferSplit) (*ResponseTransferSplit, error)
 	// Sign a transaction created on a read-only wallet (in cold-signing process)
 	SignTransfer(*RequestSignTransfer) (*ResponseSignTransfer, error)
+	SignTransferCtx(context.Context, *RequestSignTransfer) (*ResponseSignTransfer, error)
 	// Submit a previously signed transaction on a read-only wallet (in cold-signing process).
 	SubmitTransfer(*RequestSubmitTransfer) (*ResponseSubmitTransfer, error)
+	SubmitTransferCtx(context.Context, *RequestSubmitTransfer) (*ResponseSubmitTransfer, error)
 	// Send all dust outputs back to the wallet's, to make them easier to spend (and mix).
 	SweepDust(*RequestSweepDust) (*ResponseSweepDust, error)
+	SweepDustCtx(context.Context, *RequestSweepDust) (*ResponseSweepDust, error)
 	// Send all unlocked balance to an address.
 	SweepAll(*RequestSweepAll) (*ResponseSweepAll, error)
+	SweepAllCtx(context.Context, *RequestSweepAll) (*ResponseSweepAll, error)
 	// Send all of a specific unlocked output to an address.
 	SweepSingle(*RequestSweepSingle) (*ResponseSweepSingle, error)
+	SweepSingleCtx(context.Context, *RequestSweepSingle) (*ResponseSweepSingle, error)
 	// Relay a transaction previously created with "do_not_relay":true.
 	RelayTx(*RequestRelayTx) (*ResponseRelayTx, error)
+	RelayTxCtx(context.Context, *RequestRelayTx) (*ResponseRelayTx, error)
 	// Save the wallet file.
 	Store() error
+	StoreCtx(context.Context) error
 	// Get a list of incoming payments using a given payment id.
 	GetPayments(*RequestGetPayments) (*ResponseGetPayments, error)
+	GetPaymentsCtx(context.Context, *RequestGetPayments) (*ResponseGetPayments, error)
 	// Get a list of incoming payments using a given payment id, or a list of payments ids, from a given height.
 	// This method is the preferred method over get_payments because it has the same functionality but is more extendable.
 	// Either is fine for looking up transactions by a single payment ID.
 	GetBulkPayments(*RequestGetBulkPayments) (*ResponseGetBulkPayments, error)
+	GetBulkPaymentsCtx(context.Context, *RequestGetBulkPayments) (*ResponseGetBulkPayments, error)
 	// Return a list of incoming transfers to the wallet.
 	IncomingTransfers(*RequestIncomingTransfers) (*ResponseIncomingTransfers, error)
+	IncomingTransfersCtx(context.Context, *RequestIncomingTransfers) (*ResponseIncomingTransfers, error)
 	// Return the spend or view private key.
 	QueryKey(*RequestQueryKey) (*ResponseQueryKey, error)
+	QueryKeyCtx(context.Context, *RequestQueryKey) (*ResponseQueryKey, error)
 	// Make an integrated address from the wallet address and a payment id.
 	MakeIntegratedAddress(*RequestMakeIntegratedAddress) (*ResponseMakeIntegratedAddress, error)
+	MakeIntegratedAddressCtx(context.Context, *RequestMakeIntegratedAddress) (*ResponseMakeIntegratedAddress, error)
 	// Retrieve the standard address and payment id corresponding to an integrated address.
 	SplitIntegratedAddress(*RequestSplitIntegratedAddress) (*ResponseSplitIntegratedAddress, error)
+	SplitIntegratedAddressCtx(context.Context, *RequestSplitIntegratedAddress) (*ResponseSplitIntegratedAddress, error)
 	// Stops the wallet, storing the current state.
 	StopWallet() error
+	StopWalletCtx(context.Context) error
 	// Rescan the blockchain from scratch, losing any information which can not be recovered from the blockchain itself.
 	// This includes destination addresses, tx secret keys, tx notes, etc.
 	RescanBlockchain() error
+	RescanBlockchainCtx(context.Context) error
 	// Set arbitrary string notes for transactions.
 	SetTxNotes(*RequestSetTxNotes) error
+	SetTxNotesCtx(context.Context, *RequestSetTxNotes) error
 	// Get string notes for transactions.
 	GetTxNotes(*RequestGetTxNotes) (*ResponseGetTxNotes, error)
+	GetTxNotesCtx(context.Context, *RequestGetTxNotes) (*ResponseGetTxNotes, error)
 	// Set arbitrary attribute.
 	SetAttribute(*RequestSetAttribute) error
+	SetAttributeCtx(context.Context, *RequestSetAttribute) error
 	// Get attribute value by name.
 	GetAttribute(*RequestGetAttribute) (*ResponseGetAttribute, error)
+	GetAttributeCtx(context.Context, *RequestGetAttribute) (*ResponseGetAttribute, error)
 	// Get transaction secret key from transaction id.
 	GetTxKey(*RequestGetTxKey) (*ResponseGetTxKey, error)
+	GetTxKeyCtx(context.Context, *RequestGetTxKey) (*ResponseGetTxKey, error)
 	// Check a transaction in the blockchain with its secret key.
 	CheckTxKey(*RequestCheckTxKey) (*ResponseCheckTxKey, error)
+	CheckTxKeyCtx(context.Context, *RequestCheckTxKey) (*ResponseCheckTxKey, error)
 	// Get transaction signature to prove it.
 	GetTxProof(*RequestGetTxProof) (*ResponseGetTxProof, error)
+	GetTxProofCtx(context.Context, *RequestGetTxProof) (*ResponseGetTxProof, error)
 	// Prove a transaction by checking its signature.
 	CheckTxProof(*RequestCheckTxProof) (*ResponseCheckTxProof, error)
+	CheckTxProofCtx(context.Context, *RequestCheckTxProof) (*ResponseCheckTxProof, error)
 	// Generate a signature to prove a spend. Unlike proving a transaction, it does not requires the destination public address.
 	GetSpendProof(*RequestGetSpendProof) (*ResponseGetSpendProof, error)
+	GetSpendProofCtx(context.Context, *RequestGetSpendProof) (*ResponseGetSpendProof, error)
 	// Prove a spend using a signature. Unlike proving a transaction, it does not requires the destination public address.
 	CheckSpendProof(*RequestCheckSpendProof) (*ResponseCheckSpendProof, error)
+	CheckSpendProofCtx(context.Context, *RequestCheckSpendProof) (*ResponseCheckSpendProof, error)
 	// Generate a signature to prove of an available amount in a wallet.
 	GetReserveProof(*RequestGetReserveProof) (*ResponseGetReserveProof, error)
+	GetReserveProofCtx(context.Context, *RequestGetReserveProof) (*ResponseGetReserveProof, error)
 	// Proves a wallet has a disposable reserve using a signature.
 	CheckReserveProof(*RequestCheckReserveProof) (*ResponseCheckReserveProof, error)
+	CheckReserveProofCtx(context.Context, *RequestCheckReserveProof) (*ResponseCheckReserveProof, error)
 	// Returns a list of transfers.
 	GetTransfers(*RequestGetTransfers) (*ResponseGetTransfers, error)
+	GetTransfersCtx(context.Context, *RequestGetTransfers) (*ResponseGetTransfers, error)
 	// Show information about a transfer to/from this address.
 	GetTransferByTxID(*RequestGetTransferByTxID) (*ResponseGetTransferByTxID, error)
+	GetTransferByTxIDCtx(context.Context, *RequestGetTransferByTxID) (*ResponseGetTransferByTxID, error)
 	// Sign a string.
 	Sign(*RequestSign) (*ResponseSign, error)
+	SignCtx(context.Context, *RequestSign) (*ResponseSign, error)
 	// Verify a signature on a string.
 	Verify(*RequestVerify) (*ResponseVerify, error)
+	VerifyCtx(context.Context, *RequestVerify) (*ResponseVerify, error)
 	// Export all outputs in hex format.
 	ExportOutputs() (*ResponseExportOutputs, error)
+	ExportOutputsCtx(context.Context) (*ResponseExportOutputs, error)
 	// Import outputs in hex format.
 	ImportOutputs(*RequestImportOutputs) (*ResponseImportOutputs, error)
+	ImportOutputsCtx(context.Context, *RequestImportOutputs) (*ResponseImportOutputs, error)
 	// Export a signed set of key images.
 	ExportKeyImages() (*ResponseExportKeyImages, error)
+	ExportKeyImagesCtx(context.Context) (*ResponseExportKeyImages, error)
 	// Import signed key images list and verify their spent status.
 	ImportKeyImages(*RequestImportKeyImages) (*ResponseImportKeyImages, error)
+	ImportKeyImagesCtx(context.Context, *RequestImportKeyImages) (*ResponseImportKeyImages, error)
 	// Create a payment URI using the official URI spec.
 	MakeURI(*RequestMakeURI) (*ResponseMakeURI, error)
+	MakeURICtx(context.Context, *RequestMakeURI) (*ResponseMakeURI, error)
 	// Parse a payment URI to get payment information.
 	ParseURI(*RequestParseURI) (*ResponseParseURI, error)
+	ParseURICtx(context.Context, *RequestParseURI) (*ResponseParseURI, error)
 	// Retrieves entries from the address book.
 	GetAddressBook(*RequestGetAddressBook) (*ResponseGetAddressBook, error)
+	GetAddressBookCtx(context.Context, *RequestGetAddressBook) (*ResponseGetAddressBook, error)
 	// Add an entry to the address book.
 	AddAddressBook(*RequestAddAddressBook) (*ResponseAddAddressBook, error)
+	AddAddressBookCtx(context.Context, *RequestAddAddressBook) (*ResponseAddAddressBook, error)
 	// Delete an entry from the address book.
 	DeleteAddressBook(*RequestDeleteAddressBook) error
+	DeleteAddressBookCtx(context.Context, *RequestDeleteAddressBook) error
 	// Refresh a wallet after openning.
 	Refresh(*RequestRefresh) (*ResponseRefresh, error)
+	RefreshCtx(context.Context, *RequestRefresh) (*ResponseRefresh, error)
 	// Rescan the blockchain for spent outputs.
 	RescanSpent() error
+	RescanSpentCtx(context.Context) error
 	// Start mining in the Monero daemon.
 	StartMining(*RequestStartMining) error
+	StartMiningCtx(context.Context, *RequestStartMining) error
 	// Stop mining in the Monero daemon.
 	StopMining() error
+	StopMiningCtx(context.Context) error
 	// Get a list of available languages for your wallet's seed.
 	GetLanguages() (*ResponseGetLanguages, error)
+	GetLanguagesCtx(context.Context) (*ResponseGetLanguages, error)
 	// Create a new wallet. You need to have set the argument "–wallet-dir" when launching monero-wallet-rpc to make this work.
 	CreateWallet(*RequestCreateWallet) error
+	CreateWalletCtx(context.Context, *RequestCreateWallet) error
 	// Restores a wallet from a given wallet address, view key, and optional spend key. You need to have set the argument "–wallet-dir" when launching monero-wallet-rpc to make this work.
 	GenerateFromKeys(*RequestGenerateFromKeys) (*ResponseGenerateFromKeys, error)
+	GenerateFromKeysCtx(context.Context, *RequestGenerateFromKeys) (*ResponseGenerateFromKeys, error)
 	// Open a wallet. You need to have set the argument "–wallet-dir" when launching monero-wallet-rpc to make this work.
 	OpenWallet(*RequestOpenWallet) error
+	OpenWalletCtx(context.Context, *RequestOpenWallet) error
 	// Close the currently opened wallet, after trying to save it.
 	CloseWallet() error
+	CloseWalletCtx(context.Context) error
 	// Change a wallet password.
 	ChangeWalletPassword(*RequestChangeWalletPassword) error
+	ChangeWalletPasswordCtx(context.Context, *RequestChangeWalletPassword) error
 	// Check if a wallet is a multisig one.
 	IsMultisig() (*ResponseIsMultisig, error)
+	IsMultisigCtx(context.Context) (*ResponseIsMultisig, error)
 	// Prepare a wallet for multisig by generating a multisig string to share with peers.
 	PrepareMultisig() (*ResponsePrepareMultisig, error)
+	PrepareMultisigCtx(context.Context) (*ResponsePrepareMultisig, error)
 	// Make a wallet multisig by importing peers multisig string.
 	MakeMultisig(*RequestMakeMultisig) (*ResponseMakeMultisig, error)
+	MakeMultisigCtx(context.Context, *RequestMakeMultisig) (*ResponseMakeMultisig, error)
 	// Export multisig info for other participants.
 	ExportMultisigInfo() (*ResponseExportMultisigInfo, error)
+	ExportMultisigInfoCtx(context.Context) (*ResponseExportMultisigInfo, error)
 	// Import multisig info from other participants.
 	ImportMultisigInfo(*RequestImportMultisigInfo) (*ResponseImportMultisigInfo, error)
+	ImportMultisigInfoCtx(context.Context, *RequestImportMultisigInfo) (*ResponseImportMultisigInfo, error)
 	// Turn this wallet into a multisig wallet, extra step for N-1/N wallets.
 	FinalizeMultisig(*RequestFinalizeMultisig) (*ResponseFinalizeMultisig, error)
+	FinalizeMultisigCtx(context.Context, *RequestFinalizeMultisig) (*ResponseFinalizeMultisig, error)
 	// Sign a transaction in multisig.
 	SignMultisig(*RequestSignMultisig) (*ResponseSignMultisig, error)
+	SignMultisigCtx(context.Context, *RequestSignMultisig) (*ResponseSignMultisig, error)
 	// Submit a signed multisig transaction.
 	SubmitMultisig(*RequestSubmitMultisig) (*ResponseSubmitMultisig, error)
+	SubmitMultisigCtx(context.Context, *RequestSubmitMultisig) (*ResponseSubmitMultisig, error)
 	// Get RPC version Major & Minor integer-format, where Major is the first 16 bits and Minor the last 16 bits.
 	GetVersion() (*ResponseGetVersion, error)
+	GetVersionCtx(context.Context) (*ResponseGetVersion, error)
 }
 
 // New returns a new monero-wallet-rpc client.
@@ -170,6 +247,7 @@ func New(cfg Config) Client {
 	cl := &client{
 		addr:    cfg.Address,
 		headers: cfg.CustomHeaders,
+		retry:   cfg.RetryPolicy,
 	}
 	if cfg.Transport == nil {
 		cl.httpcl = http.DefaultClient
@@ -185,16 +263,23 @@ type client struct {
 	httpcl  *http.Client
 	addr    string
 	headers map[string]string
+	retry   *utils.RetryPolicy
 }
 
 // Helper function
-func (c *client) do(method string, in, out interface{}) error {
+func (c *client) do(ctx context.Context, method string, in, out interface{}) error {
 	payload, err := json2.EncodeClientRequest(method, in)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.addr+"/json_rpc", bytes.NewBuffer(payload))
+	return c.retry.Do(ctx, method, idempotentMethods[method], IsRetryableError, func() error {
+		return c.doOnce(ctx, method, payload, out)
+	})
+}
+
+func (c *client) doOnce(ctx context.Context, method string, payload []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.addr+"/json_rpc", bytes.NewBuffer(payload))
 	if err != nil {
 		return err
 	}
@@ -207,10 +292,10 @@ func (c *client) do(method string, in, out interface{}) error {
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("http status %v", resp.StatusCode)
+		return utils.NewHttpStatusError(method, resp)
 	}
-	defer resp.Body.Close()
 
 	// in theory this is only done to catch
 	// any monero related errors if
@@ -224,7 +309,11 @@ func (c *client) do(method string, in, out interface{}) error {
 
 // Methods
 func (c *client) GetBalance(req *RequestGetBalance) (resp *ResponseGetBalance, err error) {
-	err = c.do("get_balance", &req, &resp)
+	return c.GetBalanceCtx(context.Background(), req)
+}
+
+func (c *client) GetBalanceCtx(ctx context.Context, req *RequestGetBalance) (resp *ResponseGetBalance, err error) {
+	err = c.do(ctx, "get_balance", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -232,7 +321,11 @@ func (c *client) GetBalance(req *RequestGetBalance) (resp *ResponseGetBalance, e
 }
 
 func (c *client) GetAddress(req *RequestGetAddress) (resp *ResponseGetAddress, err error) {
-	err = c.do("get_address", &req, &resp)
+	return c.GetAddressCtx(context.Background(), req)
+}
+
+func (c *client) GetAddressCtx(ctx context.Context, req *RequestGetAddress) (resp *ResponseGetAddress, err error) {
+	err = c.do(ctx, "get_address", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -240,7 +333,11 @@ func (c *client) GetAddress(req *RequestGetAddress) (resp *ResponseGetAddress, e
 }
 
 func (c *client) GetAddressIndex(req *RequestGetAddressIndex) (resp *ResponseGetAddressIndex, err error) {
-	err = c.do("get_address_index", &req, &resp)
+	return c.GetAddressIndexCtx(context.Background(), req)
+}
+
+func (c *client) GetAddressIndexCtx(ctx context.Context, req *RequestGetAddressIndex) (resp *ResponseGetAddressIndex, err error) {
+	err = c.do(ctx, "get_address_index", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +345,11 @@ func (c *client) GetAddressIndex(req *RequestGetAddressIndex) (resp *ResponseGet
 }
 
 func (c *client) CreateAddress(req *RequestCreateAddress) (resp *ResponseCreateAddress, err error) {
-	err = c.do("create_address", &req, &resp)
+	return c.CreateAddressCtx(context.Background(), req)
+}
+
+func (c *client) CreateAddressCtx(ctx context.Context, req *RequestCreateAddress) (resp *ResponseCreateAddress, err error) {
+	err = c.do(ctx, "create_address", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -256,7 +357,11 @@ func (c *client) CreateAddress(req *RequestCreateAddress) (resp *ResponseCreateA
 }
 
 func (c *client) LabelAddress(req *RequestLabelAddress) (err error) {
-	err = c.do("label_address", req, nil)
+	return c.LabelAddressCtx(context.Background(), req)
+}
+
+func (c *client) LabelAddressCtx(ctx context.Context, req *RequestLabelAddress) (err error) {
+	err = c.do(ctx, "label_address", req, nil)
 	if err != nil {
 		return err
 	}
@@ -264,7 +369,11 @@ func (c *client) LabelAddress(req *RequestLabelAddress) (err error) {
 }
 
 func (c *client) ValidateAddress(req *RequestValidateAddress) (resp *ResponseValidateAddress, err error) {
-	err = c.do("validate_address", &req, &resp)
+	return c.ValidateAddressCtx(context.Background(), req)
+}
+
+func (c *client) ValidateAddressCtx(ctx context.Context, req *RequestValidateAddress) (resp *ResponseValidateAddress, err error) {
+	err = c.do(ctx, "validate_address", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -272,7 +381,11 @@ func (c *client) ValidateAddress(req *RequestValidateAddress) (resp *ResponseVal
 }
 
 func (c *client) GetAccounts(req *RequestGetAccounts) (resp *ResponseGetAccounts, err error) {
-	err = c.do("get_accounts", &req, &resp)
+	return c.GetAccountsCtx(context.Background(), req)
+}
+
+func (c *client) GetAccountsCtx(ctx context.Context, req *RequestGetAccounts) (resp *ResponseGetAccounts, err error) {
+	err = c.do(ctx, "get_accounts", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -280,7 +393,11 @@ func (c *client) GetAccounts(req *RequestGetAccounts) (resp *ResponseGetAccounts
 }
 
 func (c *client) CreateAccount(req *RequestCreateAccount) (resp *ResponseCreateAccount, err error) {
-	err = c.do("create_account", &req, &resp)
+	return c.CreateAccountCtx(context.Background(), req)
+}
+
+func (c *client) CreateAccountCtx(ctx context.Context, req *RequestCreateAccount) (resp *ResponseCreateAccount, err error) {
+	err = c.do(ctx, "create_account", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -288,14 +405,22 @@ func (c *client) CreateAccount(req *RequestCreateAccount) (resp *ResponseCreateA
 }
 
 func (c *client) LabelAccount(req *RequestLabelAccount) (err error) {
-	err = c.do("label_account", &req, nil)
+	return c.LabelAccountCtx(context.Background(), req)
+}
+
+func (c *client) LabelAccountCtx(ctx context.Context, req *RequestLabelAccount) (err error) {
+	err = c.do(ctx, "label_account", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) GetAccountTags() (resp *ResponseGetAccountTags, err error) {
-	err = c.do("get_account_tags", nil, &resp)
+	return c.GetAccountTagsCtx(context.Background())
+}
+
+func (c *client) GetAccountTagsCtx(ctx context.Context) (resp *ResponseGetAccountTags, err error) {
+	err = c.do(ctx, "get_account_tags", nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -303,28 +428,44 @@ func (c *client) GetAccountTags() (resp *ResponseGetAccountTags, err error) {
 }
 
 func (c *client) TagAccounts(req *RequestTagAccounts) (err error) {
-	err = c.do("tag_accounts", &req, nil)
+	return c.TagAccountsCtx(context.Background(), req)
+}
+
+func (c *client) TagAccountsCtx(ctx context.Context, req *RequestTagAccounts) (err error) {
+	err = c.do(ctx, "tag_accounts", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) UntagAccounts(req *RequestUntagAccounts) (err error) {
-	err = c.do("untag_accounts", &req, nil)
+	return c.UntagAccountsCtx(context.Background(), req)
+}
+
+func (c *client) UntagAccountsCtx(ctx context.Context, req *RequestUntagAccounts) (err error) {
+	err = c.do(ctx, "untag_accounts", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) SetAccountTagDescription(req *RequestSetAccountTagDescription) (err error) {
-	err = c.do("set_account_tag_description", &req, nil)
+	return c.SetAccountTagDescriptionCtx(context.Background(), req)
+}
+
+func (c *client) SetAccountTagDescriptionCtx(ctx context.Context, req *RequestSetAccountTagDescription) (err error) {
+	err = c.do(ctx, "set_account_tag_description", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) GetHeight() (resp *ResponseGetHeight, err error) {
-	err = c.do("get_height", nil, &resp)
+	return c.GetHeightCtx(context.Background())
+}
+
+func (c *client) GetHeightCtx(ctx context.Context) (resp *ResponseGetHeight, err error) {
+	err = c.do(ctx, "get_height", nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -332,7 +473,11 @@ func (c *client) GetHeight() (resp *ResponseGetHeight, err error) {
 }
 
 func (c *client) Transfer(req *RequestTransfer) (resp *ResponseTransfer, err error) {
-	err = c.do("transfer", &req, &resp)
+	return c.TransferCtx(context.Background(), req)
+}
+
+func (c *client) TransferCtx(ctx context.Context, req *RequestTransfer) (resp *ResponseTransfer, err error) {
+	err = c.do(ctx, "transfer", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -340,7 +485,11 @@ func (c *client) Transfer(req *RequestTransfer) (resp *ResponseTransfer, err err
 }
 
 func (c *client) TransferSplit(req *RequestTransferSplit) (resp *ResponseTransferSplit, err error) {
-	err = c.do("transfer_split", &req, &resp)
+	return c.TransferSplitCtx(context.Background(), req)
+}
+
+func (c *client) TransferSplitCtx(ctx context.Context, req *RequestTransferSplit) (resp *ResponseTransferSplit, err error) {
+	err = c.do(ctx, "transfer_split", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -348,7 +497,11 @@ func (c *client) TransferSplit(req *RequestTransferSplit) (resp *ResponseTransfe
 }
 
 func (c *client) SignTransfer(req *RequestSignTransfer) (resp *ResponseSignTransfer, err error) {
-	err = c.do("sign_transfer", &req, &resp)
+	return c.SignTransferCtx(context.Background(), req)
+}
+
+func (c *client) SignTransferCtx(ctx context.Context, req *RequestSignTransfer) (resp *ResponseSignTransfer, err error) {
+	err = c.do(ctx, "sign_transfer", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -356,7 +509,11 @@ func (c *client) SignTransfer(req *RequestSignTransfer) (resp *ResponseSignTrans
 }
 
 func (c *client) SubmitTransfer(req *RequestSubmitTransfer) (resp *ResponseSubmitTransfer, err error) {
-	err = c.do("submit_transfer", &req, &resp)
+	return c.SubmitTransferCtx(context.Background(), req)
+}
+
+func (c *client) SubmitTransferCtx(ctx context.Context, req *RequestSubmitTransfer) (resp *ResponseSubmitTransfer, err error) {
+	err = c.do(ctx, "submit_transfer", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -364,7 +521,11 @@ func (c *client) SubmitTransfer(req *RequestSubmitTransfer) (resp *ResponseSubmi
 }
 
 func (c *client) SweepDust(req *RequestSweepDust) (resp *ResponseSweepDust, err error) {
-	err = c.do("sweep_dust", &req, &resp)
+	return c.SweepDustCtx(context.Background(), req)
+}
+
+func (c *client) SweepDustCtx(ctx context.Context, req *RequestSweepDust) (resp *ResponseSweepDust, err error) {
+	err = c.do(ctx, "sweep_dust", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -372,7 +533,11 @@ func (c *client) SweepDust(req *RequestSweepDust) (resp *ResponseSweepDust, err
 }
 
 func (c *client) SweepAll(req *RequestSweepAll) (resp *ResponseSweepAll, err error) {
-	err = c.do("sweep_all", &req, &resp)
+	return c.SweepAllCtx(context.Background(), req)
+}
+
+func (c *client) SweepAllCtx(ctx context.Context, req *RequestSweepAll) (resp *ResponseSweepAll, err error) {
+	err = c.do(ctx, "sweep_all", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -380,7 +545,11 @@ func (c *client) SweepAll(req *RequestSweepAll) (resp *ResponseSweepAll, err err
 }
 
 func (c *client) SweepSingle(req *RequestSweepSingle) (resp *ResponseSweepSingle, err error) {
-	err = c.do("sweep_single", &req, &resp)
+	return c.SweepSingleCtx(context.Background(), req)
+}
+
+func (c *client) SweepSingleCtx(ctx context.Context, req *RequestSweepSingle) (resp *ResponseSweepSingle, err error) {
+	err = c.do(ctx, "sweep_single", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -388,7 +557,11 @@ func (c *client) SweepSingle(req *RequestSweepSingle) (resp *ResponseSweepSingle
 }
 
 func (c *client) RelayTx(req *RequestRelayTx) (resp *ResponseRelayTx, err error) {
-	err = c.do("relay_tx", &req, &resp)
+	return c.RelayTxCtx(context.Background(), req)
+}
+
+func (c *client) RelayTxCtx(ctx context.Context, req *RequestRelayTx) (resp *ResponseRelayTx, err error) {
+	err = c.do(ctx, "relay_tx", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -396,14 +569,22 @@ func (c *client) RelayTx(req *RequestRelayTx) (resp *ResponseRelayTx, err error)
 }
 
 func (c *client) Store() (err error) {
-	err = c.do("store", nil, nil)
+	return c.StoreCtx(context.Background())
+}
+
+func (c *client) StoreCtx(ctx context.Context) (err error) {
+	err = c.do(ctx, "store", nil, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) GetPayments(req *RequestGetPayments) (resp *ResponseGetPayments, err error) {
-	err = c.do("get_payments", &req, &resp)
+	return c.GetPaymentsCtx(context.Background(), req)
+}
+
+func (c *client) GetPaymentsCtx(ctx context.Context, req *RequestGetPayments) (resp *ResponseGetPayments, err error) {
+	err = c.do(ctx, "get_payments", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -411,7 +592,11 @@ func (c *client) GetPayments(req *RequestGetPayments) (resp *ResponseGetPayments
 }
 
 func (c *client) GetBulkPayments(req *RequestGetBulkPayments) (resp *ResponseGetBulkPayments, err error) {
-	err = c.do("get_bulk_payments", &req, &resp)
+	return c.GetBulkPaymentsCtx(context.Background(), req)
+}
+
+func (c *client) GetBulkPaymentsCtx(ctx context.Context, req *RequestGetBulkPayments) (resp *ResponseGetBulkPayments, err error) {
+	err = c.do(ctx, "get_bulk_payments", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -419,7 +604,11 @@ func (c *client) GetBulkPayments(req *RequestGetBulkPayments) (resp *ResponseGet
 }
 
 func (c *client) IncomingTransfers(req *RequestIncomingTransfers) (resp *ResponseIncomingTransfers, err error) {
-	err = c.do("incoming_transfers", &req, &resp)
+	return c.IncomingTransfersCtx(context.Background(), req)
+}
+
+func (c *client) IncomingTransfersCtx(ctx context.Context, req *RequestIncomingTransfers) (resp *ResponseIncomingTransfers, err error) {
+	err = c.do(ctx, "incoming_transfers", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -427,7 +616,11 @@ func (c *client) IncomingTransfers(req *RequestIncomingTransfers) (resp *Respons
 }
 
 func (c *client) QueryKey(req *RequestQueryKey) (resp *ResponseQueryKey, err error) {
-	err = c.do("query_key", &req, &resp)
+	return c.QueryKeyCtx(context.Background(), req)
+}
+
+func (c *client) QueryKeyCtx(ctx context.Context, req *RequestQueryKey) (resp *ResponseQueryKey, err error) {
+	err = c.do(ctx, "query_key", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -435,7 +628,11 @@ func (c *client) QueryKey(req *RequestQueryKey) (resp *ResponseQueryKey, err err
 }
 
 func (c *client) MakeIntegratedAddress(req *RequestMakeIntegratedAddress) (resp *ResponseMakeIntegratedAddress, err error) {
-	err = c.do("make_integrated_address", &req, &resp)
+	return c.MakeIntegratedAddressCtx(context.Background(), req)
+}
+
+func (c *client) MakeIntegratedAddressCtx(ctx context.Context, req *RequestMakeIntegratedAddress) (resp *ResponseMakeIntegratedAddress, err error) {
+	err = c.do(ctx, "make_integrated_address", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -443,7 +640,11 @@ func (c *client) MakeIntegratedAddress(req *RequestMakeIntegratedAddress) (resp
 }
 
 func (c *client) SplitIntegratedAddress(req *RequestSplitIntegratedAddress) (resp *ResponseSplitIntegratedAddress, err error) {
-	err = c.do("split_integrated_address", &req, &resp)
+	return c.SplitIntegratedAddressCtx(context.Background(), req)
+}
+
+func (c *client) SplitIntegratedAddressCtx(ctx context.Context, req *RequestSplitIntegratedAddress) (resp *ResponseSplitIntegratedAddress, err error) {
+	err = c.do(ctx, "split_integrated_address", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -451,28 +652,44 @@ func (c *client) SplitIntegratedAddress(req *RequestSplitIntegratedAddress) (res
 }
 
 func (c *client) StopWallet() (err error) {
-	err = c.do("stop_wallet", nil, nil)
+	return c.StopWalletCtx(context.Background())
+}
+
+func (c *client) StopWalletCtx(ctx context.Context) (err error) {
+	err = c.do(ctx, "stop_wallet", nil, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) RescanBlockchain() (err error) {
-	err = c.do("rescan_blockchain", nil, nil)
+	return c.RescanBlockchainCtx(context.Background())
+}
+
+func (c *client) RescanBlockchainCtx(ctx context.Context) (err error) {
+	err = c.do(ctx, "rescan_blockchain", nil, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) SetTxNotes(req *RequestSetTxNotes) (err error) {
-	err = c.do("set_tx_notes", &req, nil)
+	return c.SetTxNotesCtx(context.Background(), req)
+}
+
+func (c *client) SetTxNotesCtx(ctx context.Context, req *RequestSetTxNotes) (err error) {
+	err = c.do(ctx, "set_tx_notes", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) GetTxNotes(req *RequestGetTxNotes) (resp *ResponseGetTxNotes, err error) {
-	err = c.do("get_tx_notes", &req, &resp)
+	return c.GetTxNotesCtx(context.Background(), req)
+}
+
+func (c *client) GetTxNotesCtx(ctx context.Context, req *RequestGetTxNotes) (resp *ResponseGetTxNotes, err error) {
+	err = c.do(ctx, "get_tx_notes", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -480,14 +697,22 @@ func (c *client) GetTxNotes(req *RequestGetTxNotes) (resp *ResponseGetTxNotes, e
 }
 
 func (c *client) SetAttribute(req *RequestSetAttribute) (err error) {
-	err = c.do("set_attribute", &req, nil)
+	return c.SetAttributeCtx(context.Background(), req)
+}
+
+func (c *client) SetAttributeCtx(ctx context.Context, req *RequestSetAttribute) (err error) {
+	err = c.do(ctx, "set_attribute", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) GetAttribute(req *RequestGetAttribute) (resp *ResponseGetAttribute, err error) {
-	err = c.do("get_attribute", &req, &resp)
+	return c.GetAttributeCtx(context.Background(), req)
+}
+
+func (c *client) GetAttributeCtx(ctx context.Context, req *RequestGetAttribute) (resp *ResponseGetAttribute, err error) {
+	err = c.do(ctx, "get_attribute", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -495,7 +720,11 @@ func (c *client) GetAttribute(req *RequestGetAttribute) (resp *ResponseGetAttrib
 }
 
 func (c *client) GetTxKey(req *RequestGetTxKey) (resp *ResponseGetTxKey, err error) {
-	err = c.do("get_tx_key", &req, &resp)
+	return c.GetTxKeyCtx(context.Background(), req)
+}
+
+func (c *client) GetTxKeyCtx(ctx context.Context, req *RequestGetTxKey) (resp *ResponseGetTxKey, err error) {
+	err = c.do(ctx, "get_tx_key", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -503,7 +732,11 @@ func (c *client) GetTxKey(req *RequestGetTxKey) (resp *ResponseGetTxKey, err err
 }
 
 func (c *client) CheckTxKey(req *RequestCheckTxKey) (resp *ResponseCheckTxKey, err error) {
-	err = c.do("check_tx_key", &req, &resp)
+	return c.CheckTxKeyCtx(context.Background(), req)
+}
+
+func (c *client) CheckTxKeyCtx(ctx context.Context, req *RequestCheckTxKey) (resp *ResponseCheckTxKey, err error) {
+	err = c.do(ctx, "check_tx_key", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -511,7 +744,11 @@ func (c *client) CheckTxKey(req *RequestCheckTxKey) (resp *ResponseCheckTxKey, e
 }
 
 func (c *client) GetTxProof(req *RequestGetTxProof) (resp *ResponseGetTxProof, err error) {
-	err = c.do("get_tx_proof", &req, &resp)
+	return c.GetTxProofCtx(context.Background(), req)
+}
+
+func (c *client) GetTxProofCtx(ctx context.Context, req *RequestGetTxProof) (resp *ResponseGetTxProof, err error) {
+	err = c.do(ctx, "get_tx_proof", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -519,7 +756,11 @@ func (c *client) GetTxProof(req *RequestGetTxProof) (resp *ResponseGetTxProof, e
 }
 
 func (c *client) CheckTxProof(req *RequestCheckTxProof) (resp *ResponseCheckTxProof, err error) {
-	err = c.do("check_tx_proof", &req, &resp)
+	return c.CheckTxProofCtx(context.Background(), req)
+}
+
+func (c *client) CheckTxProofCtx(ctx context.Context, req *RequestCheckTxProof) (resp *ResponseCheckTxProof, err error) {
+	err = c.do(ctx, "check_tx_proof", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -527,7 +768,11 @@ func (c *client) CheckTxProof(req *RequestCheckTxProof) (resp *ResponseCheckTxPr
 }
 
 func (c *client) GetSpendProof(req *RequestGetSpendProof) (resp *ResponseGetSpendProof, err error) {
-	err = c.do("get_spend_proof", &req, &resp)
+	return c.GetSpendProofCtx(context.Background(), req)
+}
+
+func (c *client) GetSpendProofCtx(ctx context.Context, req *RequestGetSpendProof) (resp *ResponseGetSpendProof, err error) {
+	err = c.do(ctx, "get_spend_proof", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -535,7 +780,11 @@ func (c *client) GetSpendProof(req *RequestGetSpendProof) (resp *ResponseGetSpen
 }
 
 func (c *client) CheckSpendProof(req *RequestCheckSpendProof) (resp *ResponseCheckSpendProof, err error) {
-	err = c.do("check_spend_proof", &req, &resp)
+	return c.CheckSpendProofCtx(context.Background(), req)
+}
+
+func (c *client) CheckSpendProofCtx(ctx context.Context, req *RequestCheckSpendProof) (resp *ResponseCheckSpendProof, err error) {
+	err = c.do(ctx, "check_spend_proof", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -543,7 +792,11 @@ func (c *client) CheckSpendProof(req *RequestCheckSpendProof) (resp *ResponseChe
 }
 
 func (c *client) GetReserveProof(req *RequestGetReserveProof) (resp *ResponseGetReserveProof, err error) {
-	err = c.do("get_reserve_proof", &req, &resp)
+	return c.GetReserveProofCtx(context.Background(), req)
+}
+
+func (c *client) GetReserveProofCtx(ctx context.Context, req *RequestGetReserveProof) (resp *ResponseGetReserveProof, err error) {
+	err = c.do(ctx, "get_reserve_proof", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -551,7 +804,11 @@ func (c *client) GetReserveProof(req *RequestGetReserveProof) (resp *ResponseGet
 }
 
 func (c *client) CheckReserveProof(req *RequestCheckReserveProof) (resp *ResponseCheckReserveProof, err error) {
-	err = c.do("check_reserve_proof", &req, &resp)
+	return c.CheckReserveProofCtx(context.Background(), req)
+}
+
+func (c *client) CheckReserveProofCtx(ctx context.Context, req *RequestCheckReserveProof) (resp *ResponseCheckReserveProof, err error) {
+	err = c.do(ctx, "check_reserve_proof", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -559,7 +816,11 @@ func (c *client) CheckReserveProof(req *RequestCheckReserveProof) (resp *Respons
 }
 
 func (c *client) GetTransfers(req *RequestGetTransfers) (resp *ResponseGetTransfers, err error) {
-	err = c.do("get_transfers", &req, &resp)
+	return c.GetTransfersCtx(context.Background(), req)
+}
+
+func (c *client) GetTransfersCtx(ctx context.Context, req *RequestGetTransfers) (resp *ResponseGetTransfers, err error) {
+	err = c.do(ctx, "get_transfers", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -567,7 +828,11 @@ func (c *client) GetTransfers(req *RequestGetTransfers) (resp *ResponseGetTransf
 }
 
 func (c *client) GetTransferByTxID(req *RequestGetTransferByTxID) (resp *ResponseGetTransferByTxID, err error) {
-	err = c.do("get_transfer_by_txid", &req, &resp)
+	return c.GetTransferByTxIDCtx(context.Background(), req)
+}
+
+func (c *client) GetTransferByTxIDCtx(ctx context.Context, req *RequestGetTransferByTxID) (resp *ResponseGetTransferByTxID, err error) {
+	err = c.do(ctx, "get_transfer_by_txid", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -575,7 +840,11 @@ func (c *client) GetTransferByTxID(req *RequestGetTransferByTxID) (resp *Respons
 }
 
 func (c *client) Sign(req *RequestSign) (resp *ResponseSign, err error) {
-	err = c.do("sign", &req, &resp)
+	return c.SignCtx(context.Background(), req)
+}
+
+func (c *client) SignCtx(ctx context.Context, req *RequestSign) (resp *ResponseSign, err error) {
+	err = c.do(ctx, "sign", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -583,7 +852,11 @@ func (c *client) Sign(req *RequestSign) (resp *ResponseSign, err error) {
 }
 
 func (c *client) Verify(req *RequestVerify) (resp *ResponseVerify, err error) {
-	err = c.do("verify", &req, &resp)
+	return c.VerifyCtx(context.Background(), req)
+}
+
+func (c *client) VerifyCtx(ctx context.Context, req *RequestVerify) (resp *ResponseVerify, err error) {
+	err = c.do(ctx, "verify", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -591,7 +864,11 @@ func (c *client) Verify(req *RequestVerify) (resp *ResponseVerify, err error) {
 }
 
 func (c *client) ExportOutputs() (resp *ResponseExportOutputs, err error) {
-	err = c.do("export_outputs", nil, &resp)
+	return c.ExportOutputsCtx(context.Background())
+}
+
+func (c *client) ExportOutputsCtx(ctx context.Context) (resp *ResponseExportOutputs, err error) {
+	err = c.do(ctx, "export_outputs", nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -599,7 +876,11 @@ func (c *client) ExportOutputs() (resp *ResponseExportOutputs, err error) {
 }
 
 func (c *client) ImportOutputs(req *RequestImportOutputs) (resp *ResponseImportOutputs, err error) {
-	err = c.do("import_outputs", &req, &resp)
+	return c.ImportOutputsCtx(context.Background(), req)
+}
+
+func (c *client) ImportOutputsCtx(ctx context.Context, req *RequestImportOutputs) (resp *ResponseImportOutputs, err error) {
+	err = c.do(ctx, "import_outputs", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -607,7 +888,11 @@ func (c *client) ImportOutputs(req *RequestImportOutputs) (resp *ResponseImportO
 }
 
 func (c *client) ExportKeyImages() (resp *ResponseExportKeyImages, err error) {
-	err = c.do("export_key_images", nil, &resp)
+	return c.ExportKeyImagesCtx(context.Background())
+}
+
+func (c *client) ExportKeyImagesCtx(ctx context.Context) (resp *ResponseExportKeyImages, err error) {
+	err = c.do(ctx, "export_key_images", nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -615,7 +900,11 @@ func (c *client) ExportKeyImages() (resp *ResponseExportKeyImages, err error) {
 }
 
 func (c *client) ImportKeyImages(req *RequestImportKeyImages) (resp *ResponseImportKeyImages, err error) {
-	err = c.do("import_key_images", &req, &resp)
+	return c.ImportKeyImagesCtx(context.Background(), req)
+}
+
+func (c *client) ImportKeyImagesCtx(ctx context.Context, req *RequestImportKeyImages) (resp *ResponseImportKeyImages, err error) {
+	err = c.do(ctx, "import_key_images", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -623,7 +912,11 @@ func (c *client) ImportKeyImages(req *RequestImportKeyImages) (resp *ResponseImp
 }
 
 func (c *client) MakeURI(req *RequestMakeURI) (resp *ResponseMakeURI, err error) {
-	err = c.do("make_uri", &req, &resp)
+	return c.MakeURICtx(context.Background(), req)
+}
+
+func (c *client) MakeURICtx(ctx context.Context, req *RequestMakeURI) (resp *ResponseMakeURI, err error) {
+	err = c.do(ctx, "make_uri", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -631,7 +924,11 @@ func (c *client) MakeURI(req *RequestMakeURI) (resp *ResponseMakeURI, err error)
 }
 
 func (c *client) ParseURI(req *RequestParseURI) (resp *ResponseParseURI, err error) {
-	err = c.do("parse_uri", &req, &resp)
+	return c.ParseURICtx(context.Background(), req)
+}
+
+func (c *client) ParseURICtx(ctx context.Context, req *RequestParseURI) (resp *ResponseParseURI, err error) {
+	err = c.do(ctx, "parse_uri", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -639,7 +936,11 @@ func (c *client) ParseURI(req *RequestParseURI) (resp *ResponseParseURI, err err
 }
 
 func (c *client) GetAddressBook(req *RequestGetAddressBook) (resp *ResponseGetAddressBook, err error) {
-	err = c.do("get_address_book", &req, &resp)
+	return c.GetAddressBookCtx(context.Background(), req)
+}
+
+func (c *client) GetAddressBookCtx(ctx context.Context, req *RequestGetAddressBook) (resp *ResponseGetAddressBook, err error) {
+	err = c.do(ctx, "get_address_book", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -647,7 +948,11 @@ func (c *client) GetAddressBook(req *RequestGetAddressBook) (resp *ResponseGetAd
 }
 
 func (c *client) AddAddressBook(req *RequestAddAddressBook) (resp *ResponseAddAddressBook, err error) {
-	err = c.do("add_address_book", &req, &resp)
+	return c.AddAddressBookCtx(context.Background(), req)
+}
+
+func (c *client) AddAddressBookCtx(ctx context.Context, req *RequestAddAddressBook) (resp *ResponseAddAddressBook, err error) {
+	err = c.do(ctx, "add_address_book", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -655,14 +960,22 @@ func (c *client) AddAddressBook(req *RequestAddAddressBook) (resp *ResponseAddAd
 }
 
 func (c *client) DeleteAddressBook(req *RequestDeleteAddressBook) (err error) {
-	err = c.do("delete_address_book", &req, nil)
+	return c.DeleteAddressBookCtx(context.Background(), req)
+}
+
+func (c *client) DeleteAddressBookCtx(ctx context.Context, req *RequestDeleteAddressBook) (err error) {
+	err = c.do(ctx, "delete_address_book", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) Refresh(req *RequestRefresh) (resp *ResponseRefresh, err error) {
-	err = c.do("refresh", &req, &resp)
+	return c.RefreshCtx(context.Background(), req)
+}
+
+func (c *client) RefreshCtx(ctx context.Context, req *RequestRefresh) (resp *ResponseRefresh, err error) {
+	err = c.do(ctx, "refresh", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -670,28 +983,44 @@ func (c *client) Refresh(req *RequestRefresh) (resp *ResponseRefresh, err error)
 }
 
 func (c *client) RescanSpent() (err error) {
-	err = c.do("rescan_spent", nil, nil)
+	return c.RescanSpentCtx(context.Background())
+}
+
+func (c *client) RescanSpentCtx(ctx context.Context) (err error) {
+	err = c.do(ctx, "rescan_spent", nil, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) StartMining(req *RequestStartMining) (err error) {
-	err = c.do("start_mining", &req, nil)
+	return c.StartMiningCtx(context.Background(), req)
+}
+
+func (c *client) StartMiningCtx(ctx context.Context, req *RequestStartMining) (err error) {
+	err = c.do(ctx, "start_mining", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) StopMining() (err error) {
-	err = c.do("stop_mining", nil, nil)
+	return c.StopMiningCtx(context.Background())
+}
+
+func (c *client) StopMiningCtx(ctx context.Context) (err error) {
+	err = c.do(ctx, "stop_mining", nil, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) GetLanguages() (resp *ResponseGetLanguages, err error) {
-	err = c.do("get_languages", nil, &resp)
+	return c.GetLanguagesCtx(context.Background())
+}
+
+func (c *client) GetLanguagesCtx(ctx context.Context) (resp *ResponseGetLanguages, err error) {
+	err = c.do(ctx, "get_languages", nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -699,42 +1028,66 @@ func (c *client) GetLanguages() (resp *ResponseGetLanguages, err error) {
 }
 
 func (c *client) CreateWallet(req *RequestCreateWallet) (err error) {
-	err = c.do("create_wallet", &req, nil)
+	return c.CreateWalletCtx(context.Background(), req)
+}
+
+func (c *client) CreateWalletCtx(ctx context.Context, req *RequestCreateWallet) (err error) {
+	err = c.do(ctx, "create_wallet", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) GenerateFromKeys(req *RequestGenerateFromKeys) (resp *ResponseGenerateFromKeys, err error) {
-	err = c.do("generate_from_keys", &req, &resp)
+	return c.GenerateFromKeysCtx(context.Background(), req)
+}
+
+func (c *client) GenerateFromKeysCtx(ctx context.Context, req *RequestGenerateFromKeys) (resp *ResponseGenerateFromKeys, err error) {
+	err = c.do(ctx, "generate_from_keys", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
 	return
 }
 func (c *client) OpenWallet(req *RequestOpenWallet) (err error) {
-	err = c.do("open_wallet", &req, nil)
+	return c.OpenWalletCtx(context.Background(), req)
+}
+
+func (c *client) OpenWalletCtx(ctx context.Context, req *RequestOpenWallet) (err error) {
+	err = c.do(ctx, "open_wallet", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) CloseWallet() (err error) {
-	err = c.do("close_wallet", nil, nil)
+	return c.CloseWalletCtx(context.Background())
+}
+
+func (c *client) CloseWalletCtx(ctx context.Context) (err error) {
+	err = c.do(ctx, "close_wallet", nil, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) ChangeWalletPassword(req *RequestChangeWalletPassword) (err error) {
-	err = c.do("change_wallet_password", &req, nil)
+	return c.ChangeWalletPasswordCtx(context.Background(), req)
+}
+
+func (c *client) ChangeWalletPasswordCtx(ctx context.Context, req *RequestChangeWalletPassword) (err error) {
+	err = c.do(ctx, "change_wallet_password", &req, nil)
 	if err != nil {
 		return err
 	}
 	return
 }
 func (c *client) IsMultisig() (resp *ResponseIsMultisig, err error) {
-	err = c.do("is_multisig", nil, &resp)
+	return c.IsMultisigCtx(context.Background())
+}
+
+func (c *client) IsMultisigCtx(ctx context.Context) (resp *ResponseIsMultisig, err error) {
+	err = c.do(ctx, "is_multisig", nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -742,7 +1095,11 @@ func (c *client) IsMultisig() (resp *ResponseIsMultisig, err error) {
 }
 
 func (c *client) PrepareMultisig() (resp *ResponsePrepareMultisig, err error) {
-	err = c.do("prepare_multisig", nil, &resp)
+	return c.PrepareMultisigCtx(context.Background())
+}
+
+func (c *client) PrepareMultisigCtx(ctx context.Context) (resp *ResponsePrepareMultisig, err error) {
+	err = c.do(ctx, "prepare_multisig", nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -750,7 +1107,11 @@ func (c *client) PrepareMultisig() (resp *ResponsePrepareMultisig, err error) {
 }
 
 func (c *client) MakeMultisig(req *RequestMakeMultisig) (resp *ResponseMakeMultisig, err error) {
-	err = c.do("make_multisig", &req, &resp)
+	return c.MakeMultisigCtx(context.Background(), req)
+}
+
+func (c *client) MakeMultisigCtx(ctx context.Context, req *RequestMakeMultisig) (resp *ResponseMakeMultisig, err error) {
+	err = c.do(ctx, "make_multisig", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -758,7 +1119,11 @@ func (c *client) MakeMultisig(req *RequestMakeMultisig) (resp *ResponseMakeMulti
 }
 
 func (c *client) ExportMultisigInfo() (resp *ResponseExportMultisigInfo, err error) {
-	err = c.do("export_multisig_info", nil, &resp)
+	return c.ExportMultisigInfoCtx(context.Background())
+}
+
+func (c *client) ExportMultisigInfoCtx(ctx context.Context) (resp *ResponseExportMultisigInfo, err error) {
+	err = c.do(ctx, "export_multisig_info", nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -766,7 +1131,11 @@ func (c *client) ExportMultisigInfo() (resp *ResponseExportMultisigInfo, err err
 }
 
 func (c *client) ImportMultisigInfo(req *RequestImportMultisigInfo) (resp *ResponseImportMultisigInfo, err error) {
-	err = c.do("import_multisig_info", &req, &resp)
+	return c.ImportMultisigInfoCtx(context.Background(), req)
+}
+
+func (c *client) ImportMultisigInfoCtx(ctx context.Context, req *RequestImportMultisigInfo) (resp *ResponseImportMultisigInfo, err error) {
+	err = c.do(ctx, "import_multisig_info", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -774,7 +1143,11 @@ func (c *client) ImportMultisigInfo(req *RequestImportMultisigInfo) (resp *Respo
 }
 
 func (c *client) FinalizeMultisig(req *RequestFinalizeMultisig) (resp *ResponseFinalizeMultisig, err error) {
-	err = c.do("finalize_multisig", &req, &resp)
+	return c.FinalizeMultisigCtx(context.Background(), req)
+}
+
+func (c *client) FinalizeMultisigCtx(ctx context.Context, req *RequestFinalizeMultisig) (resp *ResponseFinalizeMultisig, err error) {
+	err = c.do(ctx, "finalize_multisig", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -782,7 +1155,11 @@ func (c *client) FinalizeMultisig(req *RequestFinalizeMultisig) (resp *ResponseF
 }
 
 func (c *client) SignMultisig(req *RequestSignMultisig) (resp *ResponseSignMultisig, err error) {
-	err = c.do("sign_multisig", &req, &resp)
+	return c.SignMultisigCtx(context.Background(), req)
+}
+
+func (c *client) SignMultisigCtx(ctx context.Context, req *RequestSignMultisig) (resp *ResponseSignMultisig, err error) {
+	err = c.do(ctx, "sign_multisig", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -790,7 +1167,11 @@ func (c *client) SignMultisig(req *RequestSignMultisig) (resp *ResponseSignMulti
 }
 
 func (c *client) SubmitMultisig(req *RequestSubmitMultisig) (resp *ResponseSubmitMultisig, err error) {
-	err = c.do("submit_multisig", &req, &resp)
+	return c.SubmitMultisigCtx(context.Background(), req)
+}
+
+func (c *client) SubmitMultisigCtx(ctx context.Context, req *RequestSubmitMultisig) (resp *ResponseSubmitMultisig, err error) {
+	err = c.do(ctx, "submit_multisig", &req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -798,7 +1179,11 @@ func (c *client) SubmitMultisig(req *RequestSubmitMultisig) (resp *ResponseSubmi
 }
 
 func (c *client) GetVersion() (resp *ResponseGetVersion, err error) {
-	err = c.do("get_version", nil, &resp)
+	return c.GetVersionCtx(context.Background())
+}
+
+func (c *client) GetVersionCtx(ctx context.Context) (resp *ResponseGetVersion, err error) {
+	err = c.do(ctx, "get_version", nil, &resp)
 	if err != nil {
 		return nil, err
 	}
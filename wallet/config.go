@@ -2,6 +2,8 @@ package wallet
 
 import (
 	"net/http"
+
+	"github.com/chekist32/go-monero/utils"
 )
 
 // Config holds the configuration of a monero rpc client.
@@ -9,4 +11,8 @@ type Config struct {
 	Address       string
 	CustomHeaders map[string]string
 	Transport     http.RoundTripper
+	// RetryPolicy retries the failed calls of the idempotent methods (see IsRetryableError).
+	// Transfer, RelayTx and the other methods that change the wallet are never retried, unless listed in AlsoRetry.
+	// The calls aren't retried if it is nil.
+	RetryPolicy *utils.RetryPolicy
 }
@@ -1,8 +1,10 @@
 package wallet
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/chekist32/go-monero/utils"
 	"github.com/gorilla/rpc/v2/json2"
 )
 
@@ -69,6 +71,55 @@ func GetWalletError(err error) (isWalletError bool, werr *WalletError) {
 	return
 }
 
+// IsRetryableError reports whether the call is worth retrying:
+// the transport errors, the 5xx and 429 HTTP statuses and ErrDaemonIsBusy.
+func IsRetryableError(err error) bool {
+	var gerr *json2.Error
+	if errors.As(err, &gerr) {
+		return gerr.Code == json2.ErrorCode(ErrDaemonIsBusy)
+	}
+	return utils.IsTransientError(err)
+}
+
+// idempotentMethods are the methods that don't change the wallet, so they are safe to retry.
+var idempotentMethods = map[string]bool{
+	"get_balance":              true,
+	"get_address":              true,
+	"get_address_index":        true,
+	"validate_address":         true,
+	"get_accounts":             true,
+	"get_account_tags":         true,
+	"get_height":               true,
+	"get_payments":             true,
+	"get_bulk_payments":        true,
+	"incoming_transfers":       true,
+	"query_key":                true,
+	"make_integrated_address":  true,
+	"split_integrated_address": true,
+	"get_tx_notes":             true,
+	"get_attribute":            true,
+	"get_tx_key":               true,
+	"check_tx_key":             true,
+	"get_tx_proof":             true,
+	"check_tx_proof":           true,
+	"get_spend_proof":          true,
+	"check_spend_proof":        true,
+	"get_reserve_proof":        true,
+	"check_reserve_proof":      true,
+	"get_transfers":            true,
+	"get_transfer_by_txid":     true,
+	"sign":                     true,
+	"verify":                   true,
+	"export_outputs":           true,
+	"export_key_images":        true,
+	"make_uri":                 true,
+	"parse_uri":                true,
+	"get_address_book":         true,
+	"get_languages":            true,
+	"is_multisig":              true,
+	"get_version":              true,
+}
+
 // Priority represents a transaction priority
 type Priority uint
 